@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const desktopNotifyTimeout = 10 * time.Second
+
+// desktopSink shows health_score alerts as desktop notifications: osascript
+// on macOS, notify-send on Linux. Other alert kinds stay in the TUI, the
+// alert log and the webhook, where they already surface.
+type desktopSink struct{}
+
+var runDesktopNotify = func(title, body string) {
+	ctx, cancel := context.WithTimeout(context.Background(), desktopNotifyTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + appleScriptString(body) + " with title " + appleScriptString(title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", title, body)
+	}
+	_ = cmd.Run()
+}
+
+func (desktopSink) Send(event AlertEvent) {
+	if event.Metric != "health_score" {
+		return
+	}
+	go runDesktopNotify("Mole Status", event.Message)
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
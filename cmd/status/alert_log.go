@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	alertLogCapacity  = 50
+	alertLogQueueSize = 64
+)
+
+// alertLogEntry is one alert that fired during the session, or one whose
+// delivery to a sink failed (DeliveryError set).
+type alertLogEntry struct {
+	Event         AlertEvent
+	ResolvedAt    time.Time
+	DeliveryError string
+}
+
+// alertLogLine is one fired/resolved/delivery_failed record as written to the
+// file.
+type alertLogLine struct {
+	State string     `json:"state"`
+	At    time.Time  `json:"at"`
+	Event AlertEvent `json:"event"`
+	Error string     `json:"error,omitempty"`
+}
+
+// alertLog keeps a bounded in-memory history of alerts for the TUI and can
+// append each fired/resolved transition as JSON lines to a file. File writes
+// happen on a background worker behind a bounded queue, so a slow disk never
+// stalls the TUI; lines are dropped when the queue is full.
+type alertLog struct {
+	mu       sync.Mutex
+	entries  []alertLogEntry
+	capacity int
+	path     string
+	queue    chan alertLogLine
+}
+
+func newAlertLog(capacity int, path string) *alertLog {
+	l := &alertLog{capacity: capacity, path: path}
+	if path != "" {
+		l.queue = make(chan alertLogLine, alertLogQueueSize)
+		go l.run()
+	}
+	return l
+}
+
+// Send records a fired alert. It implements alertSink.
+func (l *alertLog) Send(event AlertEvent) {
+	l.add(alertLogEntry{Event: event})
+	l.enqueue(alertLogLine{State: "fired", At: event.Timestamp, Event: event})
+}
+
+// DeliveryFailed records an alert that a sink gave up delivering, so the
+// failure shows in the panel (and the file) instead of vanishing. It is called
+// from sink workers.
+func (l *alertLog) DeliveryFailed(event AlertEvent, err error) {
+	l.add(alertLogEntry{Event: event, DeliveryError: err.Error()})
+	l.enqueue(alertLogLine{State: "delivery_failed", At: time.Now(), Event: event, Error: err.Error()})
+}
+
+func (l *alertLog) add(entry alertLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > l.capacity {
+		l.entries = l.entries[len(l.entries)-l.capacity:]
+	}
+}
+
+// Resolve marks the newest matching open entry as resolved. It implements
+// alertResolver.
+func (l *alertLog) Resolve(event AlertEvent, at time.Time) {
+	l.mu.Lock()
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := &l.entries[i]
+		if e.ResolvedAt.IsZero() && e.DeliveryError == "" && e.Event.Metric == event.Metric && e.Event.Timestamp.Equal(event.Timestamp) && e.Event.Message == event.Message {
+			e.ResolvedAt = at
+			break
+		}
+	}
+	l.mu.Unlock()
+	l.enqueue(alertLogLine{State: "resolved", At: at, Event: event})
+}
+
+// Entries returns the history newest first.
+func (l *alertLog) Entries() []alertLogEntry {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]alertLogEntry, len(l.entries))
+	for i, e := range l.entries {
+		out[len(l.entries)-1-i] = e
+	}
+	return out
+}
+
+func (l *alertLog) enqueue(line alertLogLine) {
+	if l.queue == nil {
+		return
+	}
+	select {
+	case l.queue <- line:
+	default:
+	}
+}
+
+func (l *alertLog) run() {
+	for line := range l.queue {
+		l.appendToFile(line)
+	}
+}
+
+func (l *alertLog) appendToFile(entry alertLogLine) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// renderAlertLog renders the alert history panel shown with the "a" key.
+func renderAlertLog(entries []alertLogEntry, width int) string {
+	lines := []string{titleStyle.Render("Alert log") + subtleStyle.Render("  a to close")}
+	if len(entries) == 0 {
+		lines = append(lines, subtleStyle.Render("No alerts this session"))
+		return strings.Join(lines, "\n")
+	}
+	for _, e := range entries {
+		state, message := dangerStyle.Render("active  "), e.Event.Message
+		switch {
+		case e.DeliveryError != "":
+			state, message = warnStyle.Render("failed  "), "delivery failed: "+e.DeliveryError
+		case !e.ResolvedAt.IsZero():
+			state = okStyle.Render("resolved")
+		}
+		prefix := fmt.Sprintf("%s  %s  %-12s %6.1f  ", e.Event.Timestamp.Local().Format("15:04:05"), state, e.Event.Metric, e.Event.Value)
+		line := prefix + shorten(message, max(remainingLineWidth(width, prefix), 2))
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlertLogRecordsFiredAndResolvedAlerts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	history := newAlertLog(alertLogCapacity, path)
+	notifier := newAlertNotifier(history)
+
+	triggered := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	notifier.Observe(MetricsSnapshot{
+		CollectedAt: triggered,
+		ProcessAlerts: []ProcessAlert{
+			{PID: 7, Name: "ffmpeg", CPU: 240, Threshold: 100, Window: "5m0s", TriggeredAt: triggered, Status: "active"},
+		},
+	})
+	entries := history.Entries()
+	if len(entries) != 1 || !entries[0].ResolvedAt.IsZero() {
+		t.Fatalf("expected one open entry, got %#v", entries)
+	}
+
+	cleared := triggered.Add(2 * time.Minute)
+	notifier.Observe(MetricsSnapshot{CollectedAt: cleared})
+	entries = history.Entries()
+	if len(entries) != 1 || !entries[0].ResolvedAt.Equal(cleared) {
+		t.Fatalf("expected entry resolved at %v, got %#v", cleared, entries)
+	}
+
+	// The file is written by a background worker; wait for both lines.
+	var data []byte
+	var lines []string
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		data, _ = os.ReadFile(path)
+		lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) == 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected fired and resolved lines, got %q", data)
+	}
+	var last struct {
+		State string     `json:"state"`
+		Event AlertEvent `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &last); err != nil || last.State != "resolved" || last.Event.Value != 240 {
+		t.Fatalf("unexpected resolved line %q (%v)", lines[1], err)
+	}
+}
+
+func TestAlertLogIsBoundedAndNewestFirst(t *testing.T) {
+	history := newAlertLog(2, "")
+	for i := range 3 {
+		history.Send(AlertEvent{Metric: "process_cpu", Value: float64(i)})
+	}
+	entries := history.Entries()
+	if len(entries) != 2 || entries[0].Event.Value != 2 || entries[1].Event.Value != 1 {
+		t.Fatalf("expected the two newest entries newest first, got %#v", entries)
+	}
+}
+
+func TestRenderAlertLogShowsState(t *testing.T) {
+	at := time.Now()
+	out := stripANSI(renderAlertLog([]alertLogEntry{
+		{Event: AlertEvent{Metric: "process_cpu", Value: 150, Timestamp: at, Message: "node at 150%"}},
+		{Event: AlertEvent{Metric: "process_cpu", Value: 120, Timestamp: at, Message: "go at 120%"}, ResolvedAt: at},
+	}, 100))
+	if !strings.Contains(out, "active") || !strings.Contains(out, "resolved") || !strings.Contains(out, "node at 150%") {
+		t.Fatalf("unexpected alert log render %q", out)
+	}
+
+	l := newAlertLog(alertLogCapacity, "")
+	l.DeliveryFailed(AlertEvent{Metric: "cpu", Timestamp: at}, &webhookStatusError{code: 400, status: "400 Bad Request"})
+	if out := stripANSI(renderAlertLog(l.Entries(), 100)); !strings.Contains(out, "failed") || !strings.Contains(out, "delivery failed: webhook returned 400 Bad Request") {
+		t.Fatalf("delivery failure not shown: %q", out)
+	}
+	if empty := stripANSI(renderAlertLog(nil, 80)); !strings.Contains(empty, "No alerts this session") {
+		t.Fatalf("unexpected empty render %q", empty)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	webhookTimeout   = 5 * time.Second
+	webhookAttempts  = 3
+	webhookBackoff   = time.Second
+	webhookQueueSize = 16
+)
+
+// webhookSink POSTs alert events to an incoming-webhook URL from a background
+// worker. The queue is bounded and Send drops events when it is full, so a
+// slow or unreachable endpoint can never stall the TUI.
+type webhookSink struct {
+	url     string
+	tmpl    *template.Template
+	client  *http.Client
+	queue   chan AlertEvent
+	backoff time.Duration
+
+	// onFailure reports an event that could not be delivered; stderr when nil.
+	onFailure func(AlertEvent, error)
+}
+
+// webhookStatusError is a non-2xx response from the endpoint.
+type webhookStatusError struct {
+	code   int
+	status string
+}
+
+func (e *webhookStatusError) Error() string {
+	return "webhook returned " + e.status
+}
+
+func newWebhookSink(url, tmplText string) (*webhookSink, error) {
+	sink := &webhookSink{
+		url:     url,
+		client:  &http.Client{Timeout: webhookTimeout},
+		queue:   make(chan AlertEvent, webhookQueueSize),
+		backoff: webhookBackoff,
+	}
+	if tmplText != "" {
+		tmpl, err := parseWebhookTemplate(tmplText)
+		if err != nil {
+			return nil, err
+		}
+		sink.tmpl = tmpl
+	}
+	go sink.run()
+	return sink, nil
+}
+
+// parseWebhookTemplate accepts inline template text or "@path" to read it from
+// a file. The json helper quotes a value so templates can build valid payloads
+// for Slack ({"text": {{json .Message}}}), Discord, or PagerDuty.
+func parseWebhookTemplate(text string) (*template.Template, error) {
+	if path, ok := strings.CutPrefix(text, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read webhook template: %w", err)
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (s *webhookSink) Send(event AlertEvent) {
+	select {
+	case s.queue <- event:
+	default:
+	}
+}
+
+func (s *webhookSink) run() {
+	for event := range s.queue {
+		body, err := s.payload(event)
+		if err == nil {
+			err = s.deliver(body)
+		}
+		if err != nil {
+			s.reportFailure(event, err)
+		}
+	}
+}
+
+func (s *webhookSink) reportFailure(event AlertEvent, err error) {
+	if s.onFailure != nil {
+		s.onFailure(event, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "status: webhook delivery failed for %s alert: %v\n", event.Metric, err)
+}
+
+func (s *webhookSink) payload(event AlertEvent) ([]byte, error) {
+	if s.tmpl == nil {
+		return json.Marshal(event)
+	}
+	var buf bytes.Buffer
+	if err := s.tmpl.Execute(&buf, event); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *webhookSink) deliver(body []byte) error {
+	var lastErr error
+	for attempt := range webhookAttempts {
+		if attempt > 0 {
+			time.Sleep(s.backoff * time.Duration(attempt))
+		}
+		if lastErr = s.post(body); lastErr == nil || !retryableWebhookError(lastErr) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// retryableWebhookError reports whether another attempt could succeed: network
+// errors, server errors and rate limiting are retried, while other 4xx
+// responses mean the request itself was rejected and would be again.
+func retryableWebhookError(err error) bool {
+	var statusErr *webhookStatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.code >= 500 || statusErr.code == http.StatusTooManyRequests
+}
+
+func (s *webhookSink) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return &webhookStatusError{code: resp.StatusCode, status: resp.Status}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSinkPostsDefaultJSONPayload(t *testing.T) {
+	received := make(chan AlertEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var event AlertEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	sink, err := newWebhookSink(server.URL, "")
+	if err != nil {
+		t.Fatalf("newWebhookSink() error = %v", err)
+	}
+	sink.Send(AlertEvent{Metric: "process_cpu", Value: 150, Threshold: 100, Hostname: "mac"})
+
+	select {
+	case event := <-received:
+		if event.Metric != "process_cpu" || event.Value != 150 || event.Hostname != "mac" {
+			t.Fatalf("unexpected payload %#v", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+}
+
+func TestWebhookSinkRendersTemplateAndRetries(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	sink, err := newWebhookSink(server.URL, `{"text": {{json .Message}}}`)
+	if err != nil {
+		t.Fatalf("newWebhookSink() error = %v", err)
+	}
+	sink.backoff = time.Millisecond
+	sink.Send(AlertEvent{Message: `node "hot"`})
+
+	select {
+	case body := <-received:
+		if body != `{"text": "node \"hot\""}` {
+			t.Fatalf("templated body = %s", body)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("webhook retry was not delivered")
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("attempts = %d, want 2", attempts.Load())
+	}
+}
+
+func TestParseWebhookTemplateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slack.tmpl")
+	if err := os.WriteFile(path, []byte(`{"content": {{json .Metric}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseWebhookTemplate("@" + path); err != nil {
+		t.Fatalf("parseWebhookTemplate(@file) error = %v", err)
+	}
+	if _, err := parseWebhookTemplate("{{.Broken"); err == nil {
+		t.Fatal("expected malformed template to fail")
+	}
+}
+
+func TestWebhookSinkSendDoesNotBlockWhenQueueIsFull(t *testing.T) {
+	sink := &webhookSink{queue: make(chan AlertEvent, 1)}
+	done := make(chan struct{})
+	go func() {
+		sink.Send(AlertEvent{})
+		sink.Send(AlertEvent{})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Send blocked on a full queue")
+	}
+}
+
+func TestWebhookSinkRetriesOnlyTransientFailuresAndReportsTheLast(t *testing.T) {
+	for _, tc := range []struct {
+		status       int
+		wantAttempts int32
+	}{
+		{http.StatusBadRequest, 1},
+		{http.StatusTooManyRequests, webhookAttempts},
+		{http.StatusBadGateway, webhookAttempts},
+	} {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(tc.status)
+		}))
+
+		sink, err := newWebhookSink(server.URL, "")
+		if err != nil {
+			t.Fatalf("newWebhookSink() error = %v", err)
+		}
+		failed := make(chan error, 1)
+		sink.backoff = time.Millisecond
+		sink.onFailure = func(_ AlertEvent, err error) { failed <- err }
+		sink.Send(AlertEvent{Metric: "cpu"})
+
+		select {
+		case err := <-failed:
+			if !strings.Contains(err.Error(), strconv.Itoa(tc.status)) {
+				t.Fatalf("status %d: reported error = %v", tc.status, err)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("status %d: final failure was not reported", tc.status)
+		}
+		if got := attempts.Load(); got != tc.wantAttempts {
+			t.Fatalf("status %d: attempts = %d, want %d", tc.status, got, tc.wantAttempts)
+		}
+		server.Close()
+	}
+}
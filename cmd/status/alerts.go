@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlertEvent is one alert transition delivered to notification sinks.
+type AlertEvent struct {
+	Metric    string    `json:"metric"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Hostname  string    `json:"hostname"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// alertSink receives alert events. Send must never block the caller.
+type alertSink interface {
+	Send(AlertEvent)
+}
+
+// alertResolver is implemented by sinks that also want to know when a fired
+// alert clears.
+type alertResolver interface {
+	Resolve(event AlertEvent, at time.Time)
+}
+
+type processAlertKey struct {
+	pid         int
+	triggeredAt time.Time
+}
+
+// alertNotifier turns snapshot alert state into one-shot events so sinks fire
+// on the transition, not on every tick that still carries the alert.
+type alertNotifier struct {
+	sinks     []alertSink
+	seenProcs map[processAlertKey]AlertEvent
+
+	// healthBelow fires a health_score alert when the score drops under it
+	// (0 disables); healthAlert holds the open alert until the score recovers.
+	healthBelow float64
+	healthAlert *AlertEvent
+}
+
+func newAlertNotifier(sinks ...alertSink) *alertNotifier {
+	var active []alertSink
+	for _, sink := range sinks {
+		if sink != nil {
+			active = append(active, sink)
+		}
+	}
+	return &alertNotifier{
+		sinks:     active,
+		seenProcs: make(map[processAlertKey]AlertEvent),
+	}
+}
+
+// Observe emits events for alerts that fired since the previous snapshot.
+func (n *alertNotifier) Observe(snapshot MetricsSnapshot) []AlertEvent {
+	if n == nil || len(n.sinks) == 0 {
+		return nil
+	}
+	events := n.detect(snapshot)
+	for _, event := range events {
+		for _, sink := range n.sinks {
+			sink.Send(event)
+		}
+	}
+	return events
+}
+
+func (n *alertNotifier) detect(snapshot MetricsSnapshot) []AlertEvent {
+	var events []AlertEvent
+	current := make(map[processAlertKey]AlertEvent, len(snapshot.ProcessAlerts))
+	for _, alert := range activeAlerts(snapshot.ProcessAlerts) {
+		key := processAlertKey{pid: alert.PID, triggeredAt: alert.TriggeredAt}
+		if seen, ok := n.seenProcs[key]; ok {
+			current[key] = seen
+			continue
+		}
+		event := AlertEvent{
+			Metric:    "process_cpu",
+			Value:     alert.CPU,
+			Threshold: alert.Threshold,
+			Hostname:  snapshot.Host,
+			Timestamp: snapshot.CollectedAt,
+			Message: fmt.Sprintf("%s at %.1f%% CPU for %s (threshold %.1f%%)",
+				formatProcessLabel(ProcessInfo{PID: alert.PID, Name: alert.Name}), alert.CPU, alert.Window, alert.Threshold),
+		}
+		current[key] = event
+		events = append(events, event)
+	}
+	for key, event := range n.seenProcs {
+		if _, ok := current[key]; !ok {
+			n.resolve(event, snapshot.CollectedAt)
+		}
+	}
+	n.seenProcs = current
+	if event, ok := n.detectHealth(snapshot); ok {
+		events = append(events, event)
+	}
+	return events
+}
+
+// detectHealth fires once when the health score crosses below healthBelow
+// and re-arms, resolving the alert, once it climbs back to the threshold.
+func (n *alertNotifier) detectHealth(snapshot MetricsSnapshot) (AlertEvent, bool) {
+	if n.healthBelow <= 0 {
+		return AlertEvent{}, false
+	}
+	score := float64(snapshot.HealthScore)
+	if score >= n.healthBelow {
+		if n.healthAlert != nil {
+			n.resolve(*n.healthAlert, snapshot.CollectedAt)
+			n.healthAlert = nil
+		}
+		return AlertEvent{}, false
+	}
+	if n.healthAlert != nil {
+		return AlertEvent{}, false
+	}
+	event := AlertEvent{
+		Metric:    "health_score",
+		Value:     score,
+		Threshold: n.healthBelow,
+		Hostname:  snapshot.Host,
+		Timestamp: snapshot.CollectedAt,
+		Message:   fmt.Sprintf("Health %d (below %g): %s", snapshot.HealthScore, n.healthBelow, snapshot.HealthScoreMsg),
+	}
+	n.healthAlert = &event
+	return event, true
+}
+
+func (n *alertNotifier) resolve(event AlertEvent, at time.Time) {
+	for _, sink := range n.sinks {
+		if r, ok := sink.(alertResolver); ok {
+			r.Resolve(event, at)
+		}
+	}
+}
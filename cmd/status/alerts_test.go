@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	events []AlertEvent
+}
+
+func (s *recordingSink) Send(event AlertEvent) {
+	s.events = append(s.events, event)
+}
+
+func TestAlertNotifierFiresOncePerProcessAlert(t *testing.T) {
+	sink := &recordingSink{}
+	notifier := newAlertNotifier(sink)
+	triggered := time.Now()
+	snapshot := MetricsSnapshot{
+		Host:        "build-box",
+		CollectedAt: triggered,
+		ProcessAlerts: []ProcessAlert{
+			{PID: 42, Name: "node", CPU: 180, Threshold: 100, Window: "5m0s", TriggeredAt: triggered, Status: "active"},
+		},
+	}
+
+	notifier.Observe(snapshot)
+	notifier.Observe(snapshot)
+
+	if len(sink.events) != 1 {
+		t.Fatalf("expected one event for a persisting alert, got %d", len(sink.events))
+	}
+	event := sink.events[0]
+	if event.Metric != "process_cpu" || event.Value != 180 || event.Threshold != 100 || event.Hostname != "build-box" {
+		t.Fatalf("unexpected event %#v", event)
+	}
+
+	// The alert clears and later re-triggers: that is a new transition.
+	notifier.Observe(MetricsSnapshot{})
+	snapshot.ProcessAlerts[0].TriggeredAt = triggered.Add(time.Minute)
+	notifier.Observe(snapshot)
+	if len(sink.events) != 2 {
+		t.Fatalf("expected re-triggered alert to fire again, got %d events", len(sink.events))
+	}
+}
+
+func TestAlertNotifierWithoutSinksIsNoop(t *testing.T) {
+	var nilNotifier *alertNotifier
+	if events := nilNotifier.Observe(MetricsSnapshot{}); events != nil {
+		t.Fatalf("nil notifier returned events %#v", events)
+	}
+	if events := newAlertNotifier().Observe(MetricsSnapshot{
+		ProcessAlerts: []ProcessAlert{{PID: 1, Status: "active"}},
+	}); events != nil {
+		t.Fatalf("sinkless notifier returned events %#v", events)
+	}
+}
+
+type resolvingSink struct {
+	recordingSink
+	resolved []AlertEvent
+}
+
+func (s *resolvingSink) Resolve(event AlertEvent, _ time.Time) {
+	s.resolved = append(s.resolved, event)
+}
+
+func TestAlertNotifierHealthScoreFiresOnTransition(t *testing.T) {
+	sink := &resolvingSink{}
+	notifier := newAlertNotifier(sink)
+	notifier.healthBelow = 50
+
+	for _, score := range []int{80, 42, 38, 49, 55, 40} {
+		notifier.Observe(MetricsSnapshot{HealthScore: score, HealthScoreMsg: "Needs Attention: High CPU"})
+	}
+
+	if len(sink.events) != 2 {
+		t.Fatalf("expected one event per drop below the threshold, got %d", len(sink.events))
+	}
+	event := sink.events[0]
+	if event.Metric != "health_score" || event.Value != 42 || event.Threshold != 50 {
+		t.Fatalf("unexpected event %#v", event)
+	}
+	if !strings.Contains(event.Message, "High CPU") {
+		t.Fatalf("message %q should carry the health summary", event.Message)
+	}
+	if len(sink.resolved) != 1 {
+		t.Fatalf("expected the recovery to resolve the alert once, got %d", len(sink.resolved))
+	}
+}
+
+func TestDesktopSinkOnlyNotifiesHealthAlerts(t *testing.T) {
+	notified := make(chan string, 2)
+	orig := runDesktopNotify
+	runDesktopNotify = func(_, body string) { notified <- body }
+	t.Cleanup(func() { runDesktopNotify = orig })
+
+	desktopSink{}.Send(AlertEvent{Metric: "process_cpu", Message: "node"})
+	desktopSink{}.Send(AlertEvent{Metric: "health_score", Message: "Health 40"})
+
+	select {
+	case body := <-notified:
+		if body != "Health 40" {
+			t.Fatalf("notified %q, want the health alert", body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("health alert did not reach the desktop")
+	}
+	select {
+	case body := <-notified:
+		t.Fatalf("unexpected extra notification %q", body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAppleScriptStringEscapes(t *testing.T) {
+	if got := appleScriptString(`Disk "Macintosh HD" \ full`); got != `"Disk \"Macintosh HD\" \\ full"` {
+		t.Fatalf("appleScriptString() = %s", got)
+	}
+}
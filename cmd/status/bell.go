@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+const (
+	bellRecoverMargin = 5 // Score points above the critical band that end an episode
+	bellSoundTimeout  = 10 * time.Second
+)
+
+// criticalBell rings the terminal bell, and optionally plays a sound file,
+// once when the health score enters the critical band. The episode ends only
+// after the score climbs bellRecoverMargin points above the band, so a score
+// hovering at the boundary does not ring every tick.
+type criticalBell struct {
+	out     io.Writer
+	sound   string
+	alerted bool
+}
+
+var playSound = func(path string) {
+	player := "paplay"
+	if runtime.GOOS == "darwin" {
+		player = "afplay"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), bellSoundTimeout)
+	defer cancel()
+	_ = exec.CommandContext(ctx, player, path).Run()
+}
+
+func newCriticalBell(out io.Writer, sound string) *criticalBell {
+	return &criticalBell{out: out, sound: sound}
+}
+
+// Observe reports whether this snapshot rang the bell.
+func (b *criticalBell) Observe(snapshot MetricsSnapshot) bool {
+	if b == nil {
+		return false
+	}
+	if snapshot.HealthScore >= scoreFairThreshold+bellRecoverMargin {
+		b.alerted = false
+		return false
+	}
+	if b.alerted || snapshot.HealthScore >= scoreFairThreshold {
+		return false
+	}
+	b.alerted = true
+	_, _ = io.WriteString(b.out, "\a")
+	if b.sound != "" {
+		go playSound(b.sound)
+	}
+	return true
+}
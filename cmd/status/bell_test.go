@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCriticalBellRingsOncePerEpisode(t *testing.T) {
+	var out bytes.Buffer
+	played := make(chan string, 4)
+	orig := playSound
+	playSound = func(path string) { played <- path }
+	defer func() { playSound = orig }()
+
+	bell := newCriticalBell(&out, "/tmp/alert.aiff")
+	critical := scoreFairThreshold - 10
+	scores := []int{90, critical, critical - 5, scoreFairThreshold, critical, scoreFairThreshold + bellRecoverMargin, critical}
+	var rang []int
+	for i, score := range scores {
+		if bell.Observe(MetricsSnapshot{HealthScore: score}) {
+			rang = append(rang, i)
+		}
+	}
+	// Hovering just above the band (index 3) does not end the episode; a
+	// recovery past the margin (index 5) does.
+	if len(rang) != 2 || rang[0] != 1 || rang[1] != 6 {
+		t.Fatalf("bell rang at %v, want [1 6]", rang)
+	}
+	if out.String() != "\a\a" {
+		t.Fatalf("bell output = %q", out.String())
+	}
+	if got := <-played; got != "/tmp/alert.aiff" {
+		t.Fatalf("played %q", got)
+	}
+
+	var nilBell *criticalBell
+	if nilBell.Observe(MetricsSnapshot{HealthScore: 0}) {
+		t.Fatal("nil bell rang")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// statusConfig is the optional JSON config file for mo status. Every field is
+// optional; a missing default file means built-in behaviour.
+type statusConfig struct {
+	ProcessNameRules []processNameRule        `json:"process_name_rules"`
+	HealthHook       *healthHookConfig        `json:"health_hook"`
+	Profiles         map[string]statusProfile `json:"profiles"`
+	PrimaryInterface string                   `json:"primary_interface"` // Network card IP and first row; default-route detection when empty
+	HealthWeights    map[string]float64       `json:"health_weights"`    // Per-component maximum penalty (cpu, memory, disk, thermal, io, gpu); rescaled to sum to 100
+}
+
+// processNameRule rewrites process names matching Match to Name. Name may
+// reference capture groups ("$1").
+type processNameRule struct {
+	Match string `json:"match"`
+	Name  string `json:"name"`
+}
+
+// activeConfig is loaded once in main before any collector is created.
+var activeConfig statusConfig
+
+// defaultStatusConfigPath returns ~/.config/mole/status.json.
+func defaultStatusConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "mole", "status.json")
+}
+
+// loadStatusConfig reads the config at path. A missing file is only an error
+// when the user named it explicitly with --config.
+func loadStatusConfig(path string, explicit bool) (statusConfig, error) {
+	var cfg statusConfig
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if _, err := compileProcessNameRules(cfg.ProcessNameRules); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	if _, err := resolveHealthWeights(cfg.HealthWeights); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := validateProfiles(cfg.Profiles); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	if cfg.HealthHook != nil {
+		if _, err := newHealthHook(*cfg.HealthHook); err != nil {
+			return cfg, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+type compiledNameRule struct {
+	re   *regexp.Regexp
+	name string
+}
+
+// processNameRules applies the first matching rule to each process.
+type processNameRules []compiledNameRule
+
+func compileProcessNameRules(rules []processNameRule) (processNameRules, error) {
+	compiled := make(processNameRules, 0, len(rules))
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("process_name_rules: invalid match %q: %w", rule.Match, err)
+		}
+		if rule.Name == "" {
+			return nil, fmt.Errorf("process_name_rules: match %q has no name", rule.Match)
+		}
+		compiled = append(compiled, compiledNameRule{re: re, name: rule.Name})
+	}
+	return compiled, nil
+}
+
+// Apply rewrites names in place. Rules match the extracted name first and
+// then the full command, since ps truncates names at the first space.
+func (r processNameRules) Apply(procs []ProcessInfo) {
+	if len(r) == 0 {
+		return
+	}
+	for i := range procs {
+		procs[i].Name = r.normalize(procs[i].Name, procs[i].Command)
+	}
+}
+
+func (r processNameRules) normalize(name, command string) string {
+	for _, rule := range r {
+		for _, subject := range []string{name, command} {
+			if m := rule.re.FindStringSubmatchIndex(subject); m != nil {
+				return string(rule.re.ExpandString(nil, rule.name, subject, m))
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadStatusConfigMissingDefaultIsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	cfg, err := loadStatusConfig(path, false)
+	if err != nil || len(cfg.ProcessNameRules) != 0 {
+		t.Fatalf("loadStatusConfig(missing default) = %#v, %v", cfg, err)
+	}
+	if _, err := loadStatusConfig(path, true); err == nil {
+		t.Fatal("explicit --config should fail when the file is missing")
+	}
+}
+
+func TestLoadStatusConfigRejectsInvalidRule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	if err := os.WriteFile(path, []byte(`{"process_name_rules":[{"match":"(","name":"x"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadStatusConfig(path, true)
+	if err == nil || !strings.Contains(err.Error(), "process_name_rules") {
+		t.Fatalf("expected invalid rule error, got %v", err)
+	}
+}
+
+func TestProcessNameRulesNormalizeNames(t *testing.T) {
+	rules, err := compileProcessNameRules([]processNameRule{
+		{Match: `^Google Chrome Helper.*`, Name: "Chrome"},
+		{Match: `^python3\.(\d+)$`, Name: "python 3.$1"},
+	})
+	if err != nil {
+		t.Fatalf("compileProcessNameRules() error = %v", err)
+	}
+	procs := []ProcessInfo{
+		{Name: "Google", Command: "Google Chrome Helper (Renderer)"},
+		{Name: "python3.12", Command: "/usr/bin/python3.12"},
+		{Name: "zsh", Command: "zsh"},
+	}
+	rules.Apply(procs)
+
+	want := []string{"Chrome", "python 3.12", "zsh"}
+	for i, p := range procs {
+		if p.Name != want[i] {
+			t.Errorf("procs[%d].Name = %q, want %q", i, p.Name, want[i])
+		}
+	}
+}
+
+func TestLoadStatusConfigRejectsUnknownHealthWeight(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	if err := os.WriteFile(path, []byte(`{"health_weights":{"cpu":40,"fans":10}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadStatusConfig(path, true)
+	if err == nil || !strings.Contains(err.Error(), "health_weights") {
+		t.Fatalf("expected health_weights error, got %v", err)
+	}
+}
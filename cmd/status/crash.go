@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	crashSnapshotLimit = 5
+	crashHistoryBudget = 1 << 20 // Approximate bytes of retained snapshots
+)
+
+// crashReport is an actionable dump written when mo status panics: the panic,
+// its stack, and the last few good snapshots (which carry the sparkline
+// history), so a bug report shows what the machine looked like at the time.
+type crashReport struct {
+	Time      time.Time         `json:"time"`
+	Panic     string            `json:"panic"`
+	Stack     string            `json:"stack"`
+	Snapshots []MetricsSnapshot `json:"snapshots"`
+}
+
+// crashRecorder keeps the most recent snapshots for crash reports.
+type crashRecorder struct {
+	mu       sync.Mutex
+	history  *snapshotHistory
+	dir      string
+	lastPath string
+}
+
+var crashes = newCrashRecorder(defaultCrashDir())
+
+func newCrashRecorder(dir string) *crashRecorder {
+	return &crashRecorder{history: newSnapshotHistory(crashSnapshotLimit, crashHistoryBudget), dir: dir}
+}
+
+// defaultCrashDir returns ~/.cache/mole, next to the other Mole caches.
+func defaultCrashDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cache", "mole")
+}
+
+// Record retains a successfully collected snapshot, dropping the oldest.
+func (r *crashRecorder) Record(s MetricsSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history.Add(s)
+}
+
+// guard is deferred at the top of every goroutine that can panic. It writes
+// the crash report and re-panics, so Bubble Tea still restores the terminal
+// (or the runtime still prints the trace) exactly as before.
+func (r *crashRecorder) guard() {
+	if p := recover(); p != nil {
+		_, _ = r.write(p, debug.Stack(), time.Now())
+		panic(p)
+	}
+}
+
+func (r *crashRecorder) write(p any, stack []byte, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.dir == "" {
+		return "", fmt.Errorf("no crash report directory")
+	}
+	report := crashReport{
+		Time:      now,
+		Panic:     fmt.Sprint(p),
+		Stack:     string(stack),
+		Snapshots: r.history.Snapshots(),
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(r.dir, fmt.Sprintf("crash-%s.json", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	r.lastPath = path
+	return path, nil
+}
+
+// LastPath is the report written by the most recent panic, if any.
+func (r *crashRecorder) LastPath() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastPath
+}
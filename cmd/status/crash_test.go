@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashRecorderKeepsRecentSnapshots(t *testing.T) {
+	r := newCrashRecorder("")
+	for i := range crashSnapshotLimit + 2 {
+		r.Record(MetricsSnapshot{HealthScore: i})
+	}
+	snaps := r.history.Snapshots()
+	if len(snaps) != crashSnapshotLimit {
+		t.Fatalf("retained %d snapshots, want %d", len(snaps), crashSnapshotLimit)
+	}
+	if snaps[0].HealthScore != 2 || snaps[crashSnapshotLimit-1].HealthScore != crashSnapshotLimit+1 {
+		t.Fatalf("retained wrong window: first=%d last=%d", snaps[0].HealthScore, snaps[crashSnapshotLimit-1].HealthScore)
+	}
+}
+
+func TestCrashRecorderGuardWritesReportAndRepanics(t *testing.T) {
+	r := newCrashRecorder(t.TempDir())
+	r.Record(MetricsSnapshot{Host: "mbp", HealthScore: 77})
+
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Fatalf("guard should re-panic with the original value, got %v", p)
+			}
+		}()
+		defer r.guard()
+		panic("boom")
+	}()
+
+	path := r.LastPath()
+	if path == "" || !strings.HasPrefix(path, r.dir) {
+		t.Fatalf("LastPath = %q, want a file in %s", path, r.dir)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report crashReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Panic != "boom" || !strings.Contains(report.Stack, "TestCrashRecorderGuardWritesReportAndRepanics") {
+		t.Fatalf("report panic/stack = %q / %q", report.Panic, report.Stack)
+	}
+	if len(report.Snapshots) != 1 || report.Snapshots[0].Host != "mbp" {
+		t.Fatalf("report snapshots = %+v", report.Snapshots)
+	}
+}
+
+func TestCrashRecorderWriteWithoutDir(t *testing.T) {
+	r := newCrashRecorder("")
+	if _, err := r.write("x", nil, time.Now()); err == nil {
+		t.Fatal("write without a directory should fail")
+	}
+}
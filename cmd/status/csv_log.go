@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+var csvLogHeader = []string{"timestamp", "health_score", "cpu_percent", "mem_percent", "disk_percent", "cpu_temp_c", "net_rx_mbs", "net_tx_mbs"}
+
+const csvLogQueueSize = 64
+
+// csvLog appends one row of scalar metrics per snapshot to a CSV file for
+// later analysis. Like the alert log, rows are written by a background worker
+// behind a bounded queue so a slow disk never stalls the TUI; the file is
+// opened per row, so every written row is already on disk at Ctrl-C.
+type csvLog struct {
+	path  string
+	queue chan []string
+}
+
+func newCSVLog(path string) *csvLog {
+	l := &csvLog{path: path}
+	if path != "" {
+		l.queue = make(chan []string, csvLogQueueSize)
+		go l.run()
+	}
+	return l
+}
+
+// Observe queues the snapshot's row, dropping it when the queue is full.
+func (l *csvLog) Observe(snapshot MetricsSnapshot) {
+	if l == nil || l.queue == nil {
+		return
+	}
+	select {
+	case l.queue <- csvLogRow(snapshot):
+	default:
+	}
+}
+
+func (l *csvLog) run() {
+	for row := range l.queue {
+		l.appendRow(row)
+	}
+}
+
+// appendRow writes the header first when the file is new or empty. Write
+// errors are dropped so logging never interrupts the session.
+func (l *csvLog) appendRow(row []string) {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		_ = w.Write(csvLogHeader)
+	}
+	_ = w.Write(row)
+	w.Flush()
+}
+
+// csvLogRow formats the columns of csvLogHeader. Disk is the root volume and
+// network rates are summed over interfaces, matching the StatsD gauges.
+func csvLogRow(m MetricsSnapshot) []string {
+	num := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	var disk float64
+	if d, ok := rootDisk(m.Disks); ok {
+		disk = d.UsedPercent
+	}
+	var rx, tx float64
+	for _, n := range m.Network {
+		rx += n.RxRateMBs
+		tx += n.TxRateMBs
+	}
+	return []string{
+		m.CollectedAt.Format(time.RFC3339),
+		strconv.Itoa(m.HealthScore),
+		num(m.CPU.Usage),
+		num(m.Memory.UsedPercent),
+		num(disk),
+		num(m.Thermal.CPUTemp),
+		num(rx),
+		num(tx),
+	}
+}
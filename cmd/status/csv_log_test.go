@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVLogWritesHeaderOnceAndAppendsRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "status.csv")
+	l := newCSVLog(path)
+	snap := MetricsSnapshot{
+		CollectedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		HealthScore: 87,
+		CPU:         CPUStatus{Usage: 12.5},
+		Memory:      MemoryStatus{UsedPercent: 61.25},
+		Disks:       []DiskStatus{{Mount: "/", UsedPercent: 70}},
+		Thermal:     ThermalStatus{CPUTemp: 48},
+		Network:     []NetworkStatus{{RxRateMBs: 1.5, TxRateMBs: 0.25}, {RxRateMBs: 0.5}},
+	}
+	l.Observe(snap)
+	l.Observe(snap)
+
+	// Rows are written by a background worker; wait for both.
+	var data []byte
+	var lines []string
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		data, _ = os.ReadFile(path)
+		lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) == 3 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(lines) != 3 || lines[0] != strings.Join(csvLogHeader, ",") {
+		t.Fatalf("expected a header and two rows, got:\n%s", data)
+	}
+	if want := "2026-03-01T09:30:00Z,87,12.5,61.25,70,48,2,0.25"; lines[1] != want || lines[2] != want {
+		t.Fatalf("row = %q, want %q", lines[1], want)
+	}
+
+	var disabled *csvLog
+	disabled.Observe(snap) // nil logger is a no-op
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const daemonWriteTimeout = 5 * time.Second
+
+// daemonReadyTimeout is the longest a client waits for the first snapshot,
+// overridable in tests.
+var daemonReadyTimeout = 10 * time.Second
+
+// snapshotSocket hands the latest snapshot, as one JSON document, to every
+// client that connects to a Unix socket, then closes the connection. Clients
+// that connect before the first collection wait up to daemonReadyTimeout for
+// it, then get a JSON {"error": ...} document instead.
+type snapshotSocket struct {
+	ln    net.Listener
+	path  string
+	mu    sync.Mutex
+	data  []byte
+	ready chan struct{}
+	once  sync.Once
+}
+
+// listenSnapshotSocket binds path, replacing a stale socket left by a daemon
+// that did not shut down cleanly. A live daemon, or any file that is not a
+// socket, is left alone.
+func listenSnapshotSocket(path string) (*snapshotSocket, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("--daemon: %s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("--daemon: another daemon is serving %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("--daemon: remove stale socket: %w", err)
+		}
+	}
+	ln, err := listenPrivateSocket(path)
+	if err != nil {
+		return nil, fmt.Errorf("--daemon: %w", err)
+	}
+	s := &snapshotSocket{ln: ln, path: path, ready: make(chan struct{})}
+	go s.serve()
+	return s, nil
+}
+
+// listenPrivateSocket binds the socket inside a fresh 0700 directory next to
+// path, narrows it to 0600 and only then renames it into place, so other users
+// can never connect while it still has the umask's mode. Snapshots include
+// process command lines.
+func listenPrivateSocket(path string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".mole-sock-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "s")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	ln.SetUnlinkOnClose(false) // The path changes below; Close removes it.
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// Publish replaces the snapshot handed to new clients.
+func (s *snapshotSocket) Publish(snap MetricsSnapshot) {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.data = append(data, '\n')
+	s.mu.Unlock()
+	s.once.Do(func() { close(s.ready) })
+}
+
+func (s *snapshotSocket) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				fmt.Fprintf(os.Stderr, "status: daemon socket stopped: %v\n", err)
+			}
+			return
+		}
+		go s.answer(conn)
+	}
+}
+
+func (s *snapshotSocket) answer(conn net.Conn) {
+	defer conn.Close()
+	var data []byte
+	select {
+	case <-s.ready:
+		s.mu.Lock()
+		data = s.data
+		s.mu.Unlock()
+	case <-time.After(daemonReadyTimeout):
+		data, _ = json.Marshal(map[string]string{"error": "no snapshot collected yet"})
+		data = append(data, '\n')
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(daemonWriteTimeout))
+	_, _ = conn.Write(data)
+}
+
+// Close stops accepting clients and removes the socket file.
+func (s *snapshotSocket) Close() error {
+	err := s.ln.Close()
+	if rmErr := os.Remove(s.path); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// runDaemonMode keeps one warm Collector on the TUI's cadence and serves its
+// latest snapshot over a Unix socket, so several frontends share one set of
+// system_profiler and nvidia-smi runs. It runs until SIGINT or SIGTERM.
+func runDaemonMode(path string, interval time.Duration, notifier *alertNotifier, hook *healthHook, statsd *statsdExporter, bell *criticalBell, csv *csvLog) error {
+	sock, err := listenSnapshotSocket(path)
+	if err != nil {
+		return err
+	}
+	defer sock.Close()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	collector := newCollectorFromFlags()
+	defer persistRateState(collector)
+	var st watchState
+	for {
+		wasReady := st.ready
+		snap, err := st.collect(collector)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "status: collect failed: %v\n", err)
+		}
+		// A failing collector (e.g. nvidia-smi missing) still yields a
+		// partial snapshot; serve it as --watch does.
+		if !snap.CollectedAt.IsZero() {
+			sock.Publish(snap)
+		}
+		if err == nil {
+			notifier.Observe(snap)
+			hook.Observe(snap)
+			statsd.Observe(snap)
+			bell.Observe(snap)
+			csv.Observe(snap)
+		}
+		wait := interval
+		if !wasReady && err == nil {
+			wait = 0 // Follow the first fast snapshot with a full one right away.
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
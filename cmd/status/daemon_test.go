@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// shortSocketPath keeps the path under the ~104 byte sun_path limit, which
+// t.TempDir() can exceed on macOS.
+func shortSocketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "mole")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "status.sock")
+}
+
+func readSocketSnapshot(t *testing.T, path string) MetricsSnapshot {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	var snap MetricsSnapshot
+	if err := json.NewDecoder(conn).Decode(&snap); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return snap
+}
+
+func TestSnapshotSocketServesLatestSnapshot(t *testing.T) {
+	path := shortSocketPath(t)
+	sock, err := listenSnapshotSocket(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sock.Publish(MetricsSnapshot{HealthScore: 71})
+	sock.Publish(MetricsSnapshot{HealthScore: 88, Host: "studio"})
+	if snap := readSocketSnapshot(t, path); snap.HealthScore != 88 || snap.Host != "studio" {
+		t.Fatalf("served %+v, want the latest snapshot", snap)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("socket mode = %v, %v; want 0600", info, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Fatalf("the private bind directory should be gone, found %d entries", len(entries))
+	}
+
+	if _, err := listenSnapshotSocket(path); err == nil {
+		t.Fatal("a second daemon should not take over a live socket")
+	}
+	if err := sock.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Fatalf("socket file should be removed on close, stat err = %v", err)
+	}
+}
+
+func TestListenSnapshotSocketRefusesRegularFile(t *testing.T) {
+	path := shortSocketPath(t)
+	if err := os.WriteFile(path, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listenSnapshotSocket(path); err == nil {
+		t.Fatal("expected an error for a non-socket path")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep me" {
+		t.Fatal("a regular file at the socket path must not be replaced")
+	}
+}
+
+func TestListenSnapshotSocketReplacesStaleSocket(t *testing.T) {
+	path := shortSocketPath(t)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false) // Simulate a daemon that was killed.
+	ln.Close()
+
+	sock, err := listenSnapshotSocket(path)
+	if err != nil {
+		t.Fatalf("stale socket should be replaced: %v", err)
+	}
+	sock.Close()
+}
+
+func TestSnapshotSocketAnswersWithErrorBeforeFirstSnapshot(t *testing.T) {
+	orig := daemonReadyTimeout
+	daemonReadyTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { daemonReadyTimeout = orig })
+	path := shortSocketPath(t)
+	sock, err := listenSnapshotSocket(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sock.Close()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	var reply map[string]string
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil || reply["error"] == "" {
+		t.Fatalf("reply = %v, %v; want a JSON error", reply, err)
+	}
+}
@@ -33,9 +33,27 @@ func statusDiagnosisLine(m MetricsSnapshot) string {
 			return "Battery cycles high"
 		}
 	}
+	if states := m.ProcessStates; states.Elevated() {
+		if states.Uninterruptible >= uninterruptibleWarnCount {
+			return fmt.Sprintf("%d processes stuck in IO wait", states.Uninterruptible)
+		}
+		return fmt.Sprintf("%d zombie processes", states.Zombie)
+	}
+	if limits := m.SystemLimits; limits.FilesNearLimit() {
+		return sprintNum("Open files at %.0f%% of limit", limits.FilePercent())
+	}
+	if m.LogErrors != nil && m.LogErrors.Spiking {
+		return sprintNum("System log errors spiking, %d/min", m.LogErrors.PerMinute)
+	}
+	if m.SystemLimits.EntropyLow() {
+		return "Entropy pool low"
+	}
 	if m.Thermal.CPUTemp > thermalNormalThreshold {
 		return "CPU temperature high"
 	}
+	if m.Thermal.GPUTemp > gpuTempNormalThreshold {
+		return "GPU temperature high"
+	}
 	if totalIO := m.DiskIO.ReadRate + m.DiskIO.WriteRate; totalIO > ioHighThreshold {
 		return "Disk I/O busy"
 	}
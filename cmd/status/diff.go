@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// snapshotChange is one field that differs between two snapshots.
+type snapshotChange struct {
+	Label  string
+	Before string
+	After  string
+	Delta  string
+}
+
+func loadSnapshotFile(path string) (MetricsSnapshot, error) {
+	var snap MetricsSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return snap, nil
+}
+
+// diffSnapshots compares the headline numbers of two snapshots captured with
+// --json. Values that did not move are omitted.
+func diffSnapshots(a, b MetricsSnapshot) []snapshotChange {
+	var changes []snapshotChange
+	addInt := func(label string, before, after int) {
+		if before != after {
+			changes = append(changes, snapshotChange{label, fmt.Sprintf("%d", before), fmt.Sprintf("%d", after), fmt.Sprintf("%+d", after-before)})
+		}
+	}
+	addPercent := func(label string, before, after float64) {
+		if math.Abs(after-before) >= 0.05 {
+			changes = append(changes, snapshotChange{label, fmt.Sprintf("%.1f%%", before), fmt.Sprintf("%.1f%%", after), fmt.Sprintf("%+.1f%%", after-before)})
+		}
+	}
+	addFloat := func(label, unit string, before, after float64) {
+		if math.Abs(after-before) >= 0.005 {
+			changes = append(changes, snapshotChange{label, fmt.Sprintf("%.2f%s", before, unit), fmt.Sprintf("%.2f%s", after, unit), fmt.Sprintf("%+.2f%s", after-before, unit)})
+		}
+	}
+	addBytes := func(label string, before, after uint64) {
+		if before != after {
+			changes = append(changes, snapshotChange{label, humanBytes(before), humanBytes(after), signedBytes(before, after)})
+		}
+	}
+
+	addInt("Health", a.HealthScore, b.HealthScore)
+	addPercent("CPU", a.CPU.Usage, b.CPU.Usage)
+	addFloat("Load 1m", "", a.CPU.Load1, b.CPU.Load1)
+	addFloat("CPU temp", "°C", a.Thermal.CPUTemp, b.Thermal.CPUTemp)
+	addBytes("Memory used", a.Memory.Used, b.Memory.Used)
+	addBytes("Swap used", a.Memory.SwapUsed, b.Memory.SwapUsed)
+	addInt("Processes", int(a.Procs), int(b.Procs))
+
+	beforeDisks := make(map[string]DiskStatus, len(a.Disks))
+	for _, d := range a.Disks {
+		beforeDisks[d.Mount] = d
+	}
+	for _, d := range b.Disks {
+		if prev, ok := beforeDisks[d.Mount]; ok {
+			addBytes("Disk "+d.Mount, prev.Used, d.Used)
+		}
+	}
+	addBytes("Trash", a.TrashSize, b.TrashSize)
+	addFloat("Disk read", " MB/s", a.DiskIO.ReadRate, b.DiskIO.ReadRate)
+	addFloat("Disk write", " MB/s", a.DiskIO.WriteRate, b.DiskIO.WriteRate)
+
+	beforeNet := make(map[string]NetworkStatus, len(a.Network))
+	for _, n := range a.Network {
+		beforeNet[n.Name] = n
+	}
+	for _, n := range b.Network {
+		if prev, ok := beforeNet[n.Name]; ok {
+			addFloat(n.Name+" down", " MB/s", prev.RxRateMBs, n.RxRateMBs)
+			addFloat(n.Name+" up", " MB/s", prev.TxRateMBs, n.TxRateMBs)
+		}
+	}
+
+	if len(a.Batteries) > 0 && len(b.Batteries) > 0 {
+		addPercent("Battery", a.Batteries[0].Percent, b.Batteries[0].Percent)
+	}
+	return changes
+}
+
+func signedBytes(before, after uint64) string {
+	if after >= before {
+		return "+" + humanBytes(after-before)
+	}
+	return "-" + humanBytes(before-after)
+}
+
+func writeSnapshotDiff(w io.Writer, a, b MetricsSnapshot) {
+	if !a.CollectedAt.IsZero() && !b.CollectedAt.IsZero() {
+		fmt.Fprintf(w, "%s → %s (%s)\n", a.CollectedAt.Format("2006-01-02 15:04:05"), b.CollectedAt.Format("2006-01-02 15:04:05"), b.CollectedAt.Sub(a.CollectedAt).Round(time.Second))
+	}
+	changes := diffSnapshots(a, b)
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No differences")
+		return
+	}
+	labelWidth := 0
+	for _, c := range changes {
+		labelWidth = max(labelWidth, len(c.Label))
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%-*s  %s → %s  %s\n", labelWidth, c.Label, c.Before, c.After, c.Delta)
+	}
+}
+
+// runDiffMode prints the changes between two --json snapshots.
+func runDiffMode(pathA, pathB string) {
+	a, err := loadSnapshotFile(pathA)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading snapshot: %v\n", err)
+		os.Exit(1)
+	}
+	b, err := loadSnapshotFile(pathB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading snapshot: %v\n", err)
+		os.Exit(1)
+	}
+	writeSnapshotDiff(os.Stdout, a, b)
+}
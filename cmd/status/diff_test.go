@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshotsReportsChangedFields(t *testing.T) {
+	before := MetricsSnapshot{
+		HealthScore: 90,
+		CPU:         CPUStatus{Usage: 20},
+		Memory:      MemoryStatus{Used: 8 << 30},
+		Disks:       []DiskStatus{{Mount: "/", Used: 100 << 30}},
+		Network:     []NetworkStatus{{Name: "en0", RxRateMBs: 1}},
+	}
+	after := before
+	after.HealthScore = 80
+	after.CPU.Usage = 35
+	after.Disks = []DiskStatus{{Mount: "/", Used: 102 << 30}}
+
+	changes := diffSnapshots(before, after)
+	got := map[string]string{}
+	for _, c := range changes {
+		got[c.Label] = c.Delta
+	}
+	want := map[string]string{"Health": "-10", "CPU": "+15.0%", "Disk /": "+2.0 GiB"}
+	if len(got) != len(want) {
+		t.Fatalf("diffSnapshots() = %#v, want only %v", changes, want)
+	}
+	for label, delta := range want {
+		if got[label] != delta {
+			t.Errorf("%s delta = %q, want %q", label, got[label], delta)
+		}
+	}
+}
+
+func TestSnapshotDiffRoundTripsJSON(t *testing.T) {
+	at := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	snap := MetricsSnapshot{CollectedAt: at, HealthScore: 75, Memory: MemoryStatus{Used: 4 << 30}}
+	path := filepath.Join(t.TempDir(), "a.json")
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("loadSnapshotFile() error = %v", err)
+	}
+	var out bytes.Buffer
+	writeSnapshotDiff(&out, snap, loaded)
+	if !strings.Contains(out.String(), "No differences") {
+		t.Fatalf("round-tripped snapshot should not differ, got %q", out.String())
+	}
+
+	loaded.Memory.Used = 2 << 30
+	out.Reset()
+	writeSnapshotDiff(&out, snap, loaded)
+	if !strings.Contains(out.String(), "Memory used  4.0 GiB → 2.0 GiB  -2.0 GiB") {
+		t.Fatalf("unexpected diff output %q", out.String())
+	}
+}
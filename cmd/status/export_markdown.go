@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// renderMarkdownReport renders one snapshot as a Markdown document for pasting
+// into issues and docs. It reuses the TUI formatters but never emits ANSI.
+func renderMarkdownReport(m MetricsSnapshot) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Mole status: %s\n\n", markdownCell(m.Host))
+	if !m.CollectedAt.IsZero() {
+		fmt.Fprintf(&b, "Collected %s", m.CollectedAt.Format(time.RFC3339))
+		if m.Uptime != "" {
+			fmt.Fprintf(&b, ", up %s", m.Uptime)
+		}
+		b.WriteString("\n\n")
+	}
+
+	writeMarkdownSection(&b, "Health", []string{"Score", "Summary"}, [][]string{
+		{fmt.Sprintf("%d", m.HealthScore), m.HealthScoreMsg},
+	})
+
+	hw := m.Hardware
+	if hw.Model != "" || hw.CPUModel != "" || hw.OSVersion != "" {
+		writeMarkdownSection(&b, "Hardware", []string{"Model", "CPU", "RAM", "Disk", "OS"}, [][]string{
+			{hw.Model, hw.CPUModel, hw.TotalRAM, hw.DiskSize, hw.OSVersion},
+		})
+	}
+
+	cpuTemp := ""
+	if m.Thermal.CPUTemp > 0 {
+		cpuTemp = fmt.Sprintf("%.1f°C", m.Thermal.CPUTemp)
+	}
+	writeMarkdownSection(&b, "CPU", []string{"Usage", "Load 1/5/15", "Cores", "Temp"}, [][]string{{
+		fmt.Sprintf("%.1f%%", m.CPU.Usage),
+		fmt.Sprintf("%.2f / %.2f / %.2f", m.CPU.Load1, m.CPU.Load5, m.CPU.Load15),
+		fmt.Sprintf("%d", m.CPU.LogicalCPU),
+		cpuTemp,
+	}})
+
+	mem := m.Memory
+	writeMarkdownSection(&b, "Memory", []string{"Used", "Total", "Available", "Swap", "Pressure"}, [][]string{{
+		fmt.Sprintf("%s (%.1f%%)", humanBytes(mem.Used), mem.UsedPercent),
+		humanBytes(mem.Total),
+		humanBytes(mem.Available),
+		humanBytes(mem.SwapUsed) + " / " + humanBytes(mem.SwapTotal),
+		formatMarkdownPressure(mem),
+	}})
+
+	var diskRows [][]string
+	for _, d := range m.Disks {
+		diskRows = append(diskRows, []string{
+			d.Mount, d.Fstype,
+			humanBytes(d.Used), humanBytes(d.Total),
+			fmt.Sprintf("%.1f%%", d.UsedPercent),
+		})
+	}
+	writeMarkdownSection(&b, "Disks", []string{"Mount", "FS", "Used", "Total", "Used %"}, diskRows)
+	fmt.Fprintf(&b, "Disk IO: read %s, write %s\n\n", formatRate(m.DiskIO.ReadRate), formatRate(m.DiskIO.WriteRate))
+
+	var netRows [][]string
+	for _, n := range m.Network {
+		netRows = append(netRows, []string{n.Name, formatRate(n.RxRateMBs), formatRate(n.TxRateMBs), n.IP})
+	}
+	writeMarkdownSection(&b, "Network", []string{"Interface", "Down", "Up", "IP"}, netRows)
+
+	var battRows [][]string
+	for _, batt := range m.Batteries {
+		capacity := ""
+		if batt.Capacity > 0 {
+			capacity = fmt.Sprintf("%d%%", batt.Capacity)
+		}
+		battRows = append(battRows, []string{
+			fmt.Sprintf("%.0f%%", batt.Percent), batt.Status, batt.TimeLeft, capacity, fmt.Sprintf("%d", batt.CycleCount),
+		})
+	}
+	writeMarkdownSection(&b, "Battery", []string{"Level", "Status", "Time left", "Capacity", "Cycles"}, battRows)
+
+	var sensorRows [][]string
+	for _, s := range m.Sensors {
+		sensorRows = append(sensorRows, []string{s.Label, fmt.Sprintf("%.1f %s", s.Value, s.Unit), s.Note})
+	}
+	writeMarkdownSection(&b, "Sensors", []string{"Sensor", "Value", "Note"}, sensorRows)
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// writeMarkdownSection writes a heading and table, skipping sections with no rows.
+func writeMarkdownSection(b *strings.Builder, title string, header []string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %s\n\n", title)
+	writeMarkdownRow(b, header)
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeMarkdownRow(b, sep)
+	for _, row := range rows {
+		writeMarkdownRow(b, row)
+	}
+	b.WriteString("\n")
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = markdownCell(cell)
+	}
+	b.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// runExportMode collects one full snapshot and writes it as Markdown.
+func runExportMode(path string) {
+	data, err := newCollectorFromFlags().Collect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error collecting metrics: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, []byte(renderMarkdownReport(data)), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing export: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// formatMarkdownPressure renders e.g. "normal (38%)".
+func formatMarkdownPressure(mem MemoryStatus) string {
+	if mem.Pressure == "" || mem.PressurePercent <= 0 {
+		return mem.Pressure
+	}
+	return fmt.Sprintf("%s (%.0f%%)", mem.Pressure, mem.PressurePercent)
+}
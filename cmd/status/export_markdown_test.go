@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderMarkdownReportIncludesSectionTables(t *testing.T) {
+	m := MetricsSnapshot{
+		CollectedAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Host:           "studio",
+		Uptime:         "3d 4h",
+		HealthScore:    88,
+		HealthScoreMsg: "Good",
+		CPU:            CPUStatus{Usage: 42.5, Load1: 1.5, Load5: 1.25, Load15: 1, LogicalCPU: 10},
+		Thermal:        ThermalStatus{CPUTemp: 61.2},
+		Memory:         MemoryStatus{Used: 8 << 30, Total: 16 << 30, UsedPercent: 50},
+		Disks:          []DiskStatus{{Mount: "/", Fstype: "apfs", Used: 100 << 30, Total: 500 << 30, UsedPercent: 20}},
+		Network:        []NetworkStatus{{Name: "en0", RxRateMBs: 2.5, TxRateMBs: 0.25, IP: "10.0.0.2"}},
+		Sensors:        []SensorReading{{Label: "NVMe|A", Value: 45, Unit: "°C"}},
+	}
+
+	got := renderMarkdownReport(m)
+	for _, want := range []string{
+		"# Mole status: studio",
+		"Collected 2026-01-02T03:04:05Z, up 3d 4h",
+		"## Health\n\n| Score | Summary |\n| --- | --- |\n| 88 | Good |",
+		"| 42.5% | 1.50 / 1.25 / 1.00 | 10 | 61.2°C |",
+		"| / | apfs | 100.0 GiB | 500.0 GiB | 20.0% |",
+		"| en0 | 2.5 MB/s | 0.25 MB/s | 10.0.0.2 |",
+		`| NVMe\|A | 45.0 °C |  |`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report missing %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "## Battery") {
+		t.Errorf("empty sections should be omitted, got\n%s", got)
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("report should not contain ANSI escapes")
+	}
+}
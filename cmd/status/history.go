@@ -0,0 +1,119 @@
+package main
+
+import "time"
+
+// Rough per-record sizes for approxSnapshotBytes: fixed fields of each struct
+// (numbers, bools, string and slice headers), not counting string contents.
+const (
+	snapshotBaseBytes   = 1280
+	gpuRecordBytes      = 112
+	diskRecordBytes     = 128
+	deviceIORecordBytes = 32
+	netRecordBytes      = 104
+	batteryRecordBytes  = 96
+	sensorRecordBytes   = 72
+	btRecordBytes       = 48
+	alertRecordBytes    = 112
+	procRecordBytes     = 72
+	float64Bytes        = 8
+)
+
+// tuiHistoryBudget bounds everything the TUI retains across ticks: the
+// movers snapshots and the CPU and memory Trend buffers.
+const tuiHistoryBudget = 2 << 20
+
+// newTUIHistory sizes the Trend buffers for trendSamples and the movers
+// history to cover moversWindow at interval, giving the latter what is left
+// of tuiHistoryBudget.
+func newTUIHistory(trendSamples int, interval time.Duration) (recent *snapshotHistory, cpuTrend, memTrend *RingBuffer) {
+	cpuTrend, memTrend = NewRingBuffer(trendSamples), NewRingBuffer(trendSamples)
+	budget := tuiHistoryBudget - cpuTrend.Bytes() - memTrend.Bytes()
+	return newSnapshotHistory(moversHistorySamples(interval), budget), cpuTrend, memTrend
+}
+
+// historyBytes is the approximate in-use size of the TUI's retained history.
+func (m model) historyBytes() int {
+	return m.recent.Bytes() + m.cpuTrend.Bytes() + m.memTrend.Bytes()
+}
+
+// snapshotHistory retains recent snapshots bounded by both a sample count and
+// an approximate byte budget, evicting the oldest when either is exceeded.
+// Snapshots vary a lot in size (per-core, per-process, sensor and sparkline
+// slices), so a count alone does not keep a long-running process stable.
+type snapshotHistory struct {
+	maxSamples int
+	maxBytes   int
+	samples    []MetricsSnapshot
+	sizes      []int
+	bytes      int
+}
+
+func newSnapshotHistory(maxSamples, maxBytes int) *snapshotHistory {
+	return &snapshotHistory{maxSamples: maxSamples, maxBytes: maxBytes}
+}
+
+// Add appends s and evicts from the front until both limits hold. The newest
+// sample is always kept, even if it alone exceeds the byte budget. A nil
+// history ignores samples.
+func (h *snapshotHistory) Add(s MetricsSnapshot) {
+	if h == nil {
+		return
+	}
+	size := approxSnapshotBytes(s)
+	h.samples = append(h.samples, s)
+	h.sizes = append(h.sizes, size)
+	h.bytes += size
+
+	drop := 0
+	for len(h.samples)-drop > 1 &&
+		((h.maxSamples > 0 && len(h.samples)-drop > h.maxSamples) || (h.maxBytes > 0 && h.bytes > h.maxBytes)) {
+		h.bytes -= h.sizes[drop]
+		drop++
+	}
+	if drop > 0 {
+		h.samples = append(h.samples[:0], h.samples[drop:]...)
+		h.sizes = append(h.sizes[:0], h.sizes[drop:]...)
+	}
+}
+
+// Snapshots returns the retained samples, oldest first.
+func (h *snapshotHistory) Snapshots() []MetricsSnapshot {
+	if h == nil {
+		return nil
+	}
+	return h.samples
+}
+
+// Bytes is the approximate in-use size of the retained samples.
+func (h *snapshotHistory) Bytes() int {
+	if h == nil {
+		return 0
+	}
+	return h.bytes
+}
+
+// approxSnapshotBytes estimates the heap a snapshot holds: its fixed fields,
+// one record per slice element, and string contents. It is an estimate for
+// budgeting, not an exact accounting.
+func approxSnapshotBytes(s MetricsSnapshot) int {
+	n := snapshotBaseBytes
+	n += len(s.Host) + len(s.Platform) + len(s.Uptime) + len(s.HealthScoreMsg)
+	n += float64Bytes * (len(s.CPU.PerCore) + len(s.CPU.PerCoreTemp))
+	n += float64Bytes * (len(s.NetworkHistory.RxHistory) + len(s.NetworkHistory.TxHistory))
+	n += len(s.GPU) * gpuRecordBytes
+	n += len(s.Disks) * diskRecordBytes
+	n += len(s.DiskIO.Devices) * deviceIORecordBytes
+	n += len(s.Network) * netRecordBytes
+	n += len(s.Batteries) * batteryRecordBytes
+	n += len(s.Sensors) * sensorRecordBytes
+	n += len(s.Bluetooth) * btRecordBytes
+	n += len(s.ProcessAlerts) * alertRecordBytes
+	n += len(s.TopProcesses) * procRecordBytes
+	for _, p := range s.TopProcesses {
+		n += len(p.Name) + len(p.Command)
+	}
+	for k, v := range s.CollectErrors {
+		n += len(k) + len(v)
+	}
+	return n
+}
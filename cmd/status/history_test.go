@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnapshotHistoryEvictsByCount(t *testing.T) {
+	h := newSnapshotHistory(3, 0)
+	for i := range 5 {
+		h.Add(MetricsSnapshot{HealthScore: i})
+	}
+	snaps := h.Snapshots()
+	if len(snaps) != 3 || snaps[0].HealthScore != 2 || snaps[2].HealthScore != 4 {
+		t.Fatalf("history = %+v, want scores 2..4", snaps)
+	}
+	if want := 3 * approxSnapshotBytes(MetricsSnapshot{}); h.Bytes() != want {
+		t.Fatalf("Bytes = %d, want %d", h.Bytes(), want)
+	}
+}
+
+func TestSnapshotHistoryEvictsByBudget(t *testing.T) {
+	small := MetricsSnapshot{HealthScore: 1}
+	big := MetricsSnapshot{HealthScore: 2, TopProcesses: []ProcessInfo{{Command: strings.Repeat("x", 4096)}}}
+	budget := approxSnapshotBytes(small)*2 + 100
+
+	h := newSnapshotHistory(100, budget)
+	h.Add(small)
+	h.Add(small)
+	if len(h.Snapshots()) != 2 {
+		t.Fatalf("two small samples should fit the budget")
+	}
+	h.Add(big)
+	snaps := h.Snapshots()
+	if len(snaps) != 1 || snaps[0].HealthScore != 2 {
+		t.Fatalf("oversized sample should evict older ones but stay itself, got %d samples", len(snaps))
+	}
+	if h.Bytes() != approxSnapshotBytes(big) {
+		t.Fatalf("Bytes = %d, want %d", h.Bytes(), approxSnapshotBytes(big))
+	}
+}
+
+func TestApproxSnapshotBytesGrowsWithSlices(t *testing.T) {
+	base := approxSnapshotBytes(MetricsSnapshot{})
+	withCores := approxSnapshotBytes(MetricsSnapshot{CPU: CPUStatus{PerCore: make([]float64, 16)}})
+	if withCores != base+16*8 {
+		t.Fatalf("per-core estimate = %d, want %d", withCores, base+16*8)
+	}
+}
+
+func TestTUIHistorySharesOneBudget(t *testing.T) {
+	recent, cpuTrend, memTrend := newTUIHistory(maxTrendSamples, refreshInterval)
+	if got := recent.maxBytes + cpuTrend.Bytes() + memTrend.Bytes(); got != tuiHistoryBudget {
+		t.Fatalf("movers budget plus trend buffers = %d, want %d", got, tuiHistoryBudget)
+	}
+	m := model{recent: recent, cpuTrend: cpuTrend, memTrend: memTrend}
+	recent.Add(MetricsSnapshot{})
+	if want := approxSnapshotBytes(MetricsSnapshot{}) + 2*8*maxTrendSamples; m.historyBytes() != want {
+		t.Fatalf("historyBytes = %d, want %d", m.historyBytes(), want)
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultHookThreshold = 40
+	defaultHookSustain   = 30 * time.Second
+	defaultHookCooldown  = 10 * time.Minute
+	hookTimeout          = 30 * time.Second
+	hookOutputLimit      = 512
+	hookArgEnvPrefix     = "MOLE_HOOK_ARG_" // Env vars carrying the template's values, numbered from 1
+)
+
+// healthHookConfig is the "health_hook" config section: a shell command run
+// when the health score stays at or below Threshold for Sustain.
+type healthHookConfig struct {
+	Command   string `json:"command"`   // Go template over MetricsSnapshot, run with sh -c; values arrive as env vars
+	Threshold int    `json:"threshold"` // Health score at or below which the machine is critical
+	Sustain   string `json:"sustain"`   // e.g. "30s"
+	Cooldown  string `json:"cooldown"`  // Minimum gap between runs, e.g. "10m"
+}
+
+// hookResult is the outcome of one hook run.
+type hookResult struct {
+	At       time.Time
+	ExitCode int
+	Output   string
+	Err      string
+}
+
+var runHookCommand = func(ctx context.Context, command string, env []string) (string, int, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return string(out), -1, err
+	}
+	return string(out), 0, nil
+}
+
+// healthHook runs the configured command once per sustained critical episode.
+// The episode ends when the score recovers above the threshold; the cooldown
+// also spaces out runs across quickly repeating episodes.
+type healthHook struct {
+	tmpl      *template.Template
+	args      []string // Values printed by the current template run
+	threshold int
+	sustain   time.Duration
+	cooldown  time.Duration
+
+	criticalSince time.Time
+	fired         bool
+	lastRun       time.Time
+
+	mu       sync.Mutex
+	running  bool
+	last     hookResult
+	hasLast  bool
+	onResult func(hookResult)
+}
+
+func newHealthHook(cfg healthHookConfig) (*healthHook, error) {
+	if strings.TrimSpace(cfg.Command) == "" {
+		return nil, errors.New("health_hook: command is empty")
+	}
+	h := &healthHook{
+		threshold: cfg.Threshold,
+		sustain:   defaultHookSustain,
+		cooldown:  defaultHookCooldown,
+	}
+	tmpl, err := template.New("health_hook").Funcs(template.FuncMap{
+		"hookarg":       func(v any) string { return `"` + h.hookArg(v) + `"` },
+		"hookargquoted": h.hookArg,
+	}).Parse(cfg.Command)
+	if err != nil {
+		return nil, fmt.Errorf("health_hook: parse command: %w", err)
+	}
+	for _, t := range tmpl.Templates() {
+		quote := quoteNone
+		if err := routeTemplateActions(t.Tree, t.Tree.Root, &quote); err != nil {
+			return nil, err
+		}
+	}
+	h.tmpl = tmpl
+	if h.threshold <= 0 {
+		h.threshold = defaultHookThreshold
+	}
+	if h.sustain, err = parseHookDuration("sustain", cfg.Sustain, defaultHookSustain); err != nil {
+		return nil, err
+	}
+	if h.cooldown, err = parseHookDuration("cooldown", cfg.Cooldown, defaultHookCooldown); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// hookArg records a printed value and returns the parameter expansion that
+// reads it back, so snapshot strings such as a host or process name never
+// become shell text.
+func (h *healthHook) hookArg(v any) string {
+	h.args = append(h.args, fmt.Sprint(v))
+	return fmt.Sprintf("${%s%d}", hookArgEnvPrefix, len(h.args))
+}
+
+// Shell quoting state at a point in the command text.
+const (
+	quoteNone = iota
+	quoteSingle
+	quoteDouble
+)
+
+// routeTemplateActions pipes every value the template prints through hookarg,
+// or hookargquoted when it sits inside double quotes, so the command sh -c
+// sees is "${MOLE_HOOK_ARG_1}" and never the value itself. Single quotes
+// cannot expand a variable, so an action inside them is rejected. quote
+// tracks the state through the text in document order. Variable declarations
+// print nothing and are left alone.
+func routeTemplateActions(tree *parse.Tree, node parse.Node, quote *int) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := routeTemplateActions(tree, child, quote); err != nil {
+				return err
+			}
+		}
+	case *parse.TextNode:
+		*quote = scanShellQuotes(string(n.Text), *quote)
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return nil
+		}
+		fn := "hookarg"
+		switch *quote {
+		case quoteSingle:
+			return fmt.Errorf("health_hook: %s is inside single quotes; use double quotes or none", n)
+		case quoteDouble:
+			fn = "hookargquoted"
+		}
+		ident := parse.NewIdentifier(fn).SetTree(tree).SetPos(n.Pos)
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{ident}})
+	case *parse.IfNode:
+		return routeBranches(tree, n.List, n.ElseList, quote)
+	case *parse.RangeNode:
+		return routeBranches(tree, n.List, n.ElseList, quote)
+	case *parse.WithNode:
+		return routeBranches(tree, n.List, n.ElseList, quote)
+	}
+	return nil
+}
+
+func routeBranches(tree *parse.Tree, list, elseList *parse.ListNode, quote *int) error {
+	if err := routeTemplateActions(tree, list, quote); err != nil {
+		return err
+	}
+	return routeTemplateActions(tree, elseList, quote)
+}
+
+// scanShellQuotes returns the quoting state after text, starting from quote.
+func scanShellQuotes(text string, quote int) int {
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote == quoteSingle:
+			if c == '\'' {
+				quote = quoteNone
+			}
+		case c == '\\':
+			i++ // The next byte is literal, outside or inside double quotes.
+		case quote == quoteDouble:
+			if c == '"' {
+				quote = quoteNone
+			}
+		case c == '\'':
+			quote = quoteSingle
+		case c == '"':
+			quote = quoteDouble
+		}
+	}
+	return quote
+}
+
+func parseHookDuration(field, raw string, fallback time.Duration) (time.Duration, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("health_hook: invalid %s %q", field, raw)
+	}
+	return d, nil
+}
+
+// Observe advances the episode state and starts the command in the background
+// when due. It reports whether a run started.
+func (h *healthHook) Observe(snapshot MetricsSnapshot) bool {
+	if h == nil {
+		return false
+	}
+	now := snapshot.CollectedAt
+	if snapshot.HealthScore > h.threshold {
+		h.criticalSince = time.Time{}
+		h.fired = false
+		return false
+	}
+	if h.criticalSince.IsZero() {
+		h.criticalSince = now
+	}
+	if h.fired || now.Sub(h.criticalSince) < h.sustain {
+		return false
+	}
+	if !h.lastRun.IsZero() && now.Sub(h.lastRun) < h.cooldown {
+		return false
+	}
+
+	var cmd bytes.Buffer
+	h.args = h.args[:0]
+	if err := h.tmpl.Execute(&cmd, snapshot); err != nil {
+		h.fired = true
+		h.record(hookResult{At: now, ExitCode: -1, Err: err.Error()})
+		return false
+	}
+
+	h.mu.Lock()
+	if h.running {
+		h.mu.Unlock()
+		return false
+	}
+	h.running = true
+	h.mu.Unlock()
+
+	h.fired = true
+	h.lastRun = now
+	env := []string{
+		fmt.Sprintf("MOLE_HEALTH_SCORE=%d", snapshot.HealthScore),
+		"MOLE_HEALTH_MSG=" + snapshot.HealthScoreMsg,
+		"MOLE_HOST=" + snapshot.Host,
+	}
+	for i, arg := range h.args {
+		env = append(env, fmt.Sprintf("%s%d=%s", hookArgEnvPrefix, i+1, arg))
+	}
+	go h.run(cmd.String(), env, now)
+	return true
+}
+
+func (h *healthHook) run(command string, env []string, at time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	out, code, err := runHookCommand(ctx, command, env)
+	result := hookResult{At: at, ExitCode: code, Output: truncateHookOutput(out)}
+	if err != nil {
+		result.Err = err.Error()
+	}
+	h.mu.Lock()
+	h.running = false
+	h.mu.Unlock()
+	h.record(result)
+}
+
+func (h *healthHook) record(result hookResult) {
+	h.mu.Lock()
+	h.last = result
+	h.hasLast = true
+	onResult := h.onResult
+	h.mu.Unlock()
+	if onResult != nil {
+		onResult(result)
+	}
+}
+
+// LastResult returns the most recent completed run.
+func (h *healthHook) LastResult() (hookResult, bool) {
+	if h == nil {
+		return hookResult{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last, h.hasLast
+}
+
+// truncateHookOutput keeps the last hookOutputLimit bytes, starting on a
+// rune boundary so a multi-byte character is never split.
+func truncateHookOutput(out string) string {
+	out = strings.TrimSpace(out)
+	if len(out) > hookOutputLimit {
+		start := len(out) - hookOutputLimit
+		for start < len(out) && !utf8.RuneStart(out[start]) {
+			start++
+		}
+		out = "…" + out[start:]
+	}
+	return out
+}
+
+// formatHookResult is the one-line form used by the TUI banner and stderr.
+func formatHookResult(r hookResult) string {
+	status := fmt.Sprintf("exit %d", r.ExitCode)
+	if r.Err != "" {
+		status = r.Err
+	}
+	text := fmt.Sprintf("Health hook ran at %s: %s", r.At.Local().Format("15:04:05"), status)
+	if r.Output != "" {
+		lastLine := r.Output
+		if i := strings.LastIndex(lastLine, "\n"); i >= 0 {
+			lastLine = lastLine[i+1:]
+		}
+		text += " · " + lastLine
+	}
+	return text
+}
+
+// healthHookFromFlags builds the hook from config. Hooks run arbitrary
+// commands, so they stay off unless --enable-hooks is also passed.
+func healthHookFromFlags(cfg statusConfig) (*healthHook, error) {
+	if cfg.HealthHook == nil {
+		return nil, nil
+	}
+	if !*enableHooks {
+		fmt.Fprintln(os.Stderr, "note: health_hook is configured but ignored without --enable-hooks")
+		return nil, nil
+	}
+	return newHealthHook(*cfg.HealthHook)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func stubHookCommand(t *testing.T) chan string {
+	t.Helper()
+	orig := runHookCommand
+	t.Cleanup(func() { runHookCommand = orig })
+	ran := make(chan string, 4)
+	runHookCommand = func(_ context.Context, command string, env []string) (string, int, error) {
+		ran <- command + "|" + strings.Join(env, ",")
+		return "paused backups\n", 0, nil
+	}
+	return ran
+}
+
+func waitHookResult(t *testing.T, h *healthHook) hookResult {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		h.mu.Lock()
+		running := h.running
+		h.mu.Unlock()
+		if result, ok := h.LastResult(); ok && !running {
+			return result
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("hook did not finish")
+	return hookResult{}
+}
+
+func TestHealthHookRunsOncePerSustainedEpisode(t *testing.T) {
+	ran := stubHookCommand(t)
+	hook, err := newHealthHook(healthHookConfig{
+		Command:   "pause-jobs --score {{.HealthScore}}",
+		Threshold: 30,
+		Sustain:   "10s",
+		Cooldown:  "1m",
+	})
+	if err != nil {
+		t.Fatalf("newHealthHook() error = %v", err)
+	}
+
+	start := time.Now()
+	critical := func(offset time.Duration) MetricsSnapshot {
+		return MetricsSnapshot{CollectedAt: start.Add(offset), HealthScore: 25, Host: "box"}
+	}
+
+	if hook.Observe(critical(0)) || hook.Observe(critical(5*time.Second)) {
+		t.Fatal("hook should wait for the sustain window")
+	}
+	if !hook.Observe(critical(10 * time.Second)) {
+		t.Fatal("hook should fire once critical is sustained")
+	}
+	if got := <-ran; !strings.HasPrefix(got, `pause-jobs --score "${MOLE_HOOK_ARG_1}"|`) || !strings.Contains(got, "MOLE_HOOK_ARG_1=25") || !strings.Contains(got, "MOLE_HEALTH_SCORE=25") {
+		t.Fatalf("unexpected command %q", got)
+	}
+	result := waitHookResult(t, hook)
+	if result.ExitCode != 0 || result.Output != "paused backups" {
+		t.Fatalf("unexpected result %#v", result)
+	}
+
+	if hook.Observe(critical(20 * time.Second)) {
+		t.Fatal("hook should not fire twice in one episode")
+	}
+
+	// Recovery ends the episode; a new one inside the cooldown still waits.
+	hook.Observe(MetricsSnapshot{CollectedAt: start.Add(25 * time.Second), HealthScore: 80})
+	if hook.Observe(critical(40 * time.Second)) {
+		t.Fatal("new episode should restart the sustain window")
+	}
+	if hook.Observe(critical(55 * time.Second)) {
+		t.Fatal("cooldown should hold off a second run")
+	}
+	if !hook.Observe(critical(75 * time.Second)) {
+		t.Fatal("hook should fire again after the cooldown")
+	}
+	<-ran
+	waitHookResult(t, hook)
+}
+
+func TestNewHealthHookValidatesConfig(t *testing.T) {
+	if _, err := newHealthHook(healthHookConfig{}); err == nil {
+		t.Fatal("empty command should be rejected")
+	}
+	if _, err := newHealthHook(healthHookConfig{Command: "true", Sustain: "soon"}); err == nil {
+		t.Fatal("invalid sustain should be rejected")
+	}
+	hook, err := newHealthHook(healthHookConfig{Command: "true"})
+	if err != nil || hook.threshold != defaultHookThreshold || hook.sustain != defaultHookSustain || hook.cooldown != defaultHookCooldown {
+		t.Fatalf("expected defaults, got %#v, %v", hook, err)
+	}
+}
+
+func TestFormatHookResult(t *testing.T) {
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.Local)
+	got := formatHookResult(hookResult{At: at, ExitCode: 2, Output: "line one\nflush failed"})
+	if got != "Health hook ran at 09:00:00: exit 2 · flush failed" {
+		t.Fatalf("formatHookResult() = %q", got)
+	}
+}
+
+func TestHealthHookPassesTemplateValuesInEnv(t *testing.T) {
+	ran := stubHookCommand(t)
+	hook, err := newHealthHook(healthHookConfig{
+		Command: `notify {{.Host}}{{if .HealthScoreMsg}} --msg "Mole: {{.HealthScoreMsg}}"{{end}}{{$s := .HealthScore}} --score {{$s}}`,
+		Sustain: "0s",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook.Observe(MetricsSnapshot{CollectedAt: time.Now(), HealthScore: 20, Host: "box; rm -rf ~", HealthScoreMsg: "Needs Attention: $(reboot)"})
+	got, env, _ := strings.Cut(<-ran, "|")
+	if want := `notify "${MOLE_HOOK_ARG_1}" --msg "Mole: ${MOLE_HOOK_ARG_2}" --score "${MOLE_HOOK_ARG_3}"`; got != want {
+		t.Fatalf("command = %q, want %q", got, want)
+	}
+	for _, want := range []string{"MOLE_HOOK_ARG_1=box; rm -rf ~", "MOLE_HOOK_ARG_2=Needs Attention: $(reboot)", "MOLE_HOOK_ARG_3=20"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("env %q missing %q", env, want)
+		}
+	}
+	waitHookResult(t, hook)
+}
+
+func TestHealthHookValuesNeverReachTheShellAsCode(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	hook, err := newHealthHook(healthHookConfig{Command: `printf '%s|%s' "host {{.Host}}" {{.HealthScoreMsg}}`, Sustain: "0s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook.Observe(MetricsSnapshot{CollectedAt: time.Now(), HealthScore: 20, Host: `$(echo pwned)"`, HealthScoreMsg: "a `echo pwned` b"})
+	out := waitHookResult(t, hook).Output
+	if want := "host $(echo pwned)\"|a `echo pwned` b"; out != want {
+		t.Fatalf("hook output = %q, want %q", out, want)
+	}
+}
+
+func TestNewHealthHookRejectsActionsInSingleQuotes(t *testing.T) {
+	if _, err := newHealthHook(healthHookConfig{Command: `notify '{{.HealthScoreMsg}}'`}); err == nil {
+		t.Fatal("an action inside single quotes should be rejected")
+	}
+	if _, err := newHealthHook(healthHookConfig{Command: `notify "it's {{.HealthScore}}" \'{{.Host}}`}); err != nil {
+		t.Fatalf("quotes inside double quotes and escaped quotes should not count: %v", err)
+	}
+}
+
+func TestTruncateHookOutputKeepsRunesWhole(t *testing.T) {
+	// The cut lands on the second byte of the first "é".
+	out := "x" + strings.Repeat("é", hookOutputLimit/2) + "x"
+	got := truncateHookOutput(out)
+	if !utf8.ValidString(got) || !strings.HasPrefix(got, "…é") {
+		t.Fatalf("truncateHookOutput() = %q...", got[:8])
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// runLineMode prints one status line for a tmux status bar or shell prompt
+// and exits. It samples like --once so the network rates are real.
+func runLineMode() {
+	collector := newCollectorFromFlags()
+	_, _ = collector.CollectFast()
+	time.Sleep(onceSampleGap)
+	data, err := collector.Collect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error collecting metrics: %v\n", err)
+		os.Exit(1)
+	}
+	persistRateState(collector)
+	fmt.Println(formatStatusLine(data, os.Getenv("NO_COLOR") == ""))
+}
+
+// formatStatusLine renders "CPU 23% MEM 61% DISK 74% NET ↓1.2↑0.3 TEMP 54° ♥87".
+// Network rates are MB/s summed over interfaces and disk is the root volume.
+// With color false (NO_COLOR) the line carries no escape codes at all.
+func formatStatusLine(m MetricsSnapshot, color bool) string {
+	percent := func(v float64) string {
+		text := sprintNum("%.0f%%", v)
+		if color {
+			return colorizePercent(v, text)
+		}
+		return text
+	}
+	parts := []string{
+		"CPU " + percent(m.CPU.Usage),
+		"MEM " + percent(m.Memory.UsedPercent),
+	}
+	if d, ok := rootDisk(m.Disks); ok {
+		parts = append(parts, "DISK "+percent(d.UsedPercent))
+	}
+	var rx, tx float64
+	for _, n := range m.Network {
+		rx += n.RxRateMBs
+		tx += n.TxRateMBs
+	}
+	parts = append(parts, "NET ↓"+formatRateCompact(rx)+"↑"+formatRateCompact(tx))
+	if m.Thermal.CPUTemp > 0 {
+		temp := sprintNum("%.0f", m.Thermal.CPUTemp)
+		if color {
+			temp = tempStyle(m.Thermal.CPUTemp).Render(temp)
+		}
+		parts = append(parts, "TEMP "+temp+"°")
+	}
+	health := fmt.Sprintf("♥%d", m.HealthScore)
+	if color {
+		health = getScoreStyle(m.HealthScore).Render(health)
+	}
+	parts = append(parts, health)
+	return strings.Join(parts, " ")
+}
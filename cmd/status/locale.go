@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// numberPrinter formats numbers for the TUI and text reports when the user's
+// locale uses a decimal comma. It stays nil for "." locales so the default
+// output is byte-for-byte unchanged. JSON output never goes through it.
+var (
+	numberPrinter *message.Printer
+	decimalSep    = "."
+)
+
+// localeFromEnv follows POSIX precedence for numeric formatting.
+func localeFromEnv(getenv func(string) string) string {
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if v := getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseLocaleTag turns "de_DE.UTF-8" or "pt-BR" into a language tag. The C and
+// POSIX locales, and anything unparseable, report false.
+func parseLocaleTag(raw string) (language.Tag, bool) {
+	raw = strings.TrimSpace(raw)
+	if i := strings.IndexAny(raw, ".@"); i >= 0 {
+		raw = raw[:i]
+	}
+	if raw == "" || raw == "C" || raw == "POSIX" {
+		return language.Und, false
+	}
+	tag, err := language.Parse(strings.ReplaceAll(raw, "_", "-"))
+	if err != nil {
+		return language.Und, false
+	}
+	return tag, true
+}
+
+// setNumberLocale picks the locale from --lang, falling back to the
+// environment, and enables localized formatting only for decimal-comma locales.
+func setNumberLocale(lang string) {
+	numberPrinter, decimalSep = nil, "."
+	if lang == "" {
+		lang = localeFromEnv(os.Getenv)
+	}
+	tag, ok := parseLocaleTag(lang)
+	if !ok {
+		return
+	}
+	p := message.NewPrinter(tag)
+	if sep := strings.Trim(p.Sprintf("%.1f", 1.5), "15"); sep != "." {
+		numberPrinter, decimalSep = p, sep
+	}
+}
+
+// sprintNum is fmt.Sprintf with locale-aware number verbs.
+func sprintNum(format string, a ...any) string {
+	if numberPrinter == nil {
+		return fmt.Sprintf(format, a...)
+	}
+	return numberPrinter.Sprintf(format, a...)
+}
+
+// localizeDecimal swaps the decimal point in an already formatted value such
+// as "1.5 GB" for the locale separator.
+func localizeDecimal(s string) string {
+	if decimalSep == "." {
+		return s
+	}
+	return strings.Replace(s, ".", decimalSep, 1)
+}
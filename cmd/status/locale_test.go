@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func useNumberLocale(t *testing.T, lang string) {
+	t.Helper()
+	setNumberLocale(lang)
+	t.Cleanup(func() { numberPrinter, decimalSep = nil, "." })
+}
+
+func TestParseLocaleTag(t *testing.T) {
+	cases := map[string]string{
+		"de_DE.UTF-8":     "de-DE",
+		"pt-BR":           "pt-BR",
+		"fr_FR@euro":      "fr-FR",
+		"en_US.UTF-8":     "en-US",
+		"C":               "",
+		"POSIX":           "",
+		"C.UTF-8":         "",
+		"":                "",
+		"not a locale!!!": "",
+	}
+	for raw, want := range cases {
+		tag, ok := parseLocaleTag(raw)
+		if want == "" {
+			if ok {
+				t.Errorf("parseLocaleTag(%q) = %v, want no tag", raw, tag)
+			}
+			continue
+		}
+		if !ok || tag.String() != want {
+			t.Errorf("parseLocaleTag(%q) = %v/%v, want %s", raw, tag, ok, want)
+		}
+	}
+}
+
+func TestLocaleFromEnvPrecedence(t *testing.T) {
+	env := map[string]string{"LANG": "en_US.UTF-8", "LC_NUMERIC": "de_DE.UTF-8"}
+	if got := localeFromEnv(func(k string) string { return env[k] }); got != "de_DE.UTF-8" {
+		t.Fatalf("localeFromEnv = %q, want LC_NUMERIC to win over LANG", got)
+	}
+	env["LC_ALL"] = "fr_FR.UTF-8"
+	if got := localeFromEnv(func(k string) string { return env[k] }); got != "fr_FR.UTF-8" {
+		t.Fatalf("localeFromEnv = %q, want LC_ALL to win", got)
+	}
+}
+
+func TestDecimalCommaLocaleFormatsDisplayNumbers(t *testing.T) {
+	useNumberLocale(t, "de_DE.UTF-8")
+
+	if got := formatRate(2.5); got != "2,5 MB/s" {
+		t.Errorf("formatRate = %q, want 2,5 MB/s", got)
+	}
+	if got := humanBytes(1536 << 20); got != "1,5 GiB" {
+		t.Errorf("humanBytes = %q, want 1,5 GiB", got)
+	}
+	if got := humanCount(1234); got != "1,2K" {
+		t.Errorf("humanCount = %q, want 1,2K", got)
+	}
+	if got := formatLoadLine(CPUStatus{Load1: 1.25, Load5: 0.5, Load15: 0.75, LogicalCPU: 8}); !strings.Contains(got, "1,25 / 0,50 / 0,75") {
+		t.Errorf("formatLoadLine = %q, want comma decimals", got)
+	}
+
+	data, err := json.Marshal(MetricsSnapshot{CPU: CPUStatus{Usage: 12.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"usage":12.5`) {
+		t.Fatalf("JSON must stay locale-independent: %s", data)
+	}
+}
+
+func TestDecimalPointLocaleKeepsDefaultFormatting(t *testing.T) {
+	useNumberLocale(t, "en_US.UTF-8")
+	if numberPrinter != nil {
+		t.Fatalf("en_US should not enable the locale printer")
+	}
+	if got := formatRate(1234); got != "1234 MB/s" {
+		t.Fatalf("formatRate = %q, want ungrouped 1234 MB/s", got)
+	}
+}
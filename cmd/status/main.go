@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,20 +18,77 @@ import (
 
 const (
 	refreshInterval      = time.Second
+	minRefreshInterval   = 250 * time.Millisecond
 	processWatchInterval = refreshInterval
 	slowRefreshInterval  = 30 * time.Second
+	refreshIntervalEnv   = "MOLE_INTERVAL"
+	hideInterfacesEnv    = "MOLE_HIDE_INTERFACES"
 )
 
 var (
 	// Command-line flags
 	jsonOutput       = flag.Bool("json", false, "output metrics as JSON instead of TUI")
+	configPath       = flag.String("config", "", "JSON config file (default ~/.config/mole/status.json)")
+	diffMode         = flag.Bool("diff", false, "compare two --json snapshots: --diff before.json after.json")
+	onceMode         = flag.Bool("once", false, "print the card layout once as plain text and exit (for cron and SSH)")
+	lineMode         = flag.Bool("line", false, "print one compact status line (CPU, memory, disk, network, temperature, health) and exit; for tmux or a shell prompt")
+	exportPath       = flag.String("export", "", "write a one-shot Markdown report to this file and exit")
 	procCPUThreshold = flag.Float64("proc-cpu-threshold", 100, "alert when a process stays above this CPU percent")
 	procCPUWindow    = flag.Duration("proc-cpu-window", 5*time.Minute, "continuous duration a process must exceed the CPU threshold")
 	procCPUAlerts    = flag.Bool("proc-cpu-alerts", true, "enable persistent high-CPU process alerts")
+	byteUnitsMode    = flag.String("units", "iec", "byte units: iec (GiB, 1024-based) or si (GB, 1000-based, matches vendor disk sizes)")
+	numberLang       = flag.String("lang", "", "locale for number formatting (e.g. de_DE); defaults to LC_ALL/LC_NUMERIC/LANG")
+	checkUpdates     = flag.Bool("updates", false, "check for pending OS/package updates every few hours (softwareupdate, apt, or dnf)")
+	pingProbe        = flag.Bool("ping", false, "measure latency to "+defaultPingHost+" every 10s with a TCP connect to port 443")
+	pingHost         = flag.String("ping-host", "", "measure latency to this host instead (host:port to override the port); implies --ping")
+	publicIPLookup   = flag.Bool("public-ip", false, "look up the public (egress) IP every 2 minutes via "+publicIPURL)
+	logErrorRates    = flag.Bool("log-errors", false, "sample system log errors per minute (runs log show / journalctl once a minute)")
+	topProcCount     = flag.Int("top-procs", defaultShownProcesses, "number of top processes the process card lists (1-20)")
+	hideInterfaces   = flag.String("hide-interfaces", "", "comma-separated interface prefixes to hide: bare names replace the defaults (lo,awdl,utun,...), +name adds, -name removes; defaults to $MOLE_HIDE_INTERFACES")
+	showAllIfaces    = flag.Bool("show-all-interfaces", false, "list every network interface, including loopback and tunnels")
+	allDisks         = flag.Bool("all-disks", false, "list every mounted volume instead of the 3 largest")
+	watchProcess     = flag.String("watch-process", "", "always show the combined CPU and memory of processes whose name contains this text (case-insensitive)")
+	processSort      = flag.String("sort", processSortCPU, "rank top processes by cpu or mem")
+	persistRates     = flag.Bool("persist-rates", false, "save network and disk counters on exit so the next run within 5 minutes shows rates immediately")
+	rateAvgWindow    = flag.Duration("rate-window", 0, "average network and disk IO rates over this span (e.g. 5s); 0 uses one refresh interval")
 
 	// Watch mode: stream NDJSON (one snapshot per line) from a single warm collector.
 	watchMode     = flag.Bool("watch", false, "stream metrics continuously as newline-delimited JSON instead of the one-shot TUI/JSON")
-	watchInterval = flag.String("interval", "", "with --watch, collection interval (e.g. 1s, 2s); defaults to 1s")
+	daemonSocket  = flag.String("daemon", "", "keep collecting in the background and serve the latest snapshot as JSON to each client of this Unix socket")
+	watchInterval = flag.String("interval", "", "refresh interval for the TUI and --watch (e.g. 500ms, 2s; min 250ms in the TUI); defaults to $MOLE_INTERVAL or 1s")
+
+	// Alert delivery.
+	webhookURL      = flag.String("webhook-url", "", "POST a JSON payload to this URL when an alert fires")
+	webhookTemplate = flag.String("webhook-template", "", "payload template for --webhook-url (Go text/template, or @file)")
+	alertBelow      = flag.Float64("alert-below", 0, "show a desktop notification when the health score drops below this (1-100; 0 disables)")
+	enableHooks     = flag.Bool("enable-hooks", false, "allow the config's health_hook command to run")
+	bellOnCritical  = flag.Bool("bell", false, "ring the terminal bell once when the health score turns critical")
+	bellSound       = flag.String("bell-sound", "", "with --bell, also play this sound file (afplay on macOS, paplay on Linux)")
+	profileName     = flag.String("profile", "", "apply a named profile from the config file (cycle with p)")
+	stillMole       = flag.Bool("still-mole", false, "keep the mole in place instead of walking across the screen")
+	noAnimation     = flag.Bool("no-animation", false, "freeze the mole, centered, so the header never redraws (tmux, screen capture)")
+	cardList        = flag.String("cards", "", "cards to show, in order (e.g. network,cpu,memory,disk); default shows all")
+	trendSamples    = flag.Int("history", defaultTrendSamples, "samples kept for the CPU and memory Trend sparklines (2-600)")
+	singleColumn    = flag.Bool("single-column", false, "stack cards in one full-width column even on wide terminals (always on below 81 columns)")
+	noMole          = flag.Bool("no-mole", false, "start with the mole hidden and not animated (k still shows it)")
+	ambientMode     = flag.Bool("ambient", false, "start in ambient mode: a dimmed, slow-refreshing glanceable screen (toggle with z)")
+	absoluteFigures = flag.Bool("absolute", false, "lead memory and disk cards with used/total sizes instead of percentages")
+	density         = flag.String("density", densityNormal, "card layout: normal, or compact for one line per metric")
+	exitSummary     = flag.Bool("summary", false, "print a one-line key=value summary to stdout when the TUI exits")
+	alertLogPath    = flag.String("alert-log", "", "also append fired and resolved alerts to this file as JSON lines")
+	csvLogPath      = flag.String("csv", "", "append a CSV row (time, health, CPU, memory, disk, CPU temp, network) to this file every refresh")
+
+	// HTTP metrics endpoint. A bare ":port" binds to loopback only.
+	serveAddr = flag.String("serve", "", "serve metrics over HTTP on this address (e.g. :9100 for localhost, 0.0.0.0:9100 for all interfaces)")
+	tlsCert   = flag.String("tls-cert", "", "with --serve, TLS certificate file")
+	tlsKey    = flag.String("tls-key", "", "with --serve, TLS private key file")
+	authToken = flag.String("auth-token", "", "with --serve, require this bearer token (or basic-auth password)")
+	headless  = flag.Bool("headless", false, "with --serve, serve metrics without starting the TUI")
+
+	// StatsD push exporter.
+	statsdAddr   = flag.String("statsd", "", "push key metrics as StatsD gauges over UDP to host:port every refresh")
+	statsdPrefix = flag.String("statsd-prefix", "mole", "with --statsd, metric name prefix")
+	statsdTags   = flag.String("statsd-tags", statsdTagsNone, "with --statsd, host tag dialect: none, datadog, or influx")
 )
 
 func shouldUseJSONOutput(forceJSON bool, stdout *os.File) bool {
@@ -76,6 +135,22 @@ type model struct {
 	collecting    bool
 	animFrame     int
 	catHidden     bool // true = hidden, false = visible
+	notifier      *alertNotifier
+	alertLog      *alertLog
+	hook          *healthHook
+	recent        *snapshotHistory // Recent snapshots for the movers panel
+	cpuTrend      *RingBuffer      // CPU usage per tick for the CPU card's Trend line
+	memTrend      *RingBuffer      // Memory used percent per tick
+	statsd        *statsdExporter
+	bell          *criticalBell
+	csv           *csvLog
+	showAlertLog  bool
+	view          viewOptions
+	startedAt     time.Time
+	interval      time.Duration // Tick between collections; zero means refreshInterval
+	killTarget    *ProcessInfo  // Selected process awaiting kill confirmation
+	killNotice    string        // Result of the last kill, shown briefly
+	paused        bool          // Space freezes the snapshot and stops collecting
 }
 
 // padViewToHeight ensures the rendered frame always overwrites the full
@@ -133,10 +208,24 @@ func saveCatHidden(hidden bool) {
 	_ = os.WriteFile(path, []byte(value+"\n"), 0644)
 }
 
-func newModel() model {
+func newModel(notifier *alertNotifier, history *alertLog, hook *healthHook, statsd *statsdExporter, bell *criticalBell, csv *csvLog) model {
+	interval, _ := refreshIntervalFromFlags(os.Getenv) // Validated in main
+	recent, cpuTrend, memTrend := newTUIHistory(*trendSamples, interval)
 	return model{
-		collector: NewCollector(processWatchOptionsFromFlags()),
-		catHidden: loadCatHidden(),
+		collector: newCollectorFromFlags(),
+		catHidden: loadCatHidden() || *noMole,
+		notifier:  notifier,
+		alertLog:  history,
+		hook:      hook,
+		recent:    recent,
+		cpuTrend:  cpuTrend,
+		memTrend:  memTrend,
+		statsd:    statsd,
+		bell:      bell,
+		csv:       csv,
+		startedAt: time.Now(),
+		interval:  interval,
+		view:      activeConfig.applyProfile(viewOptions{ambient: *ambientMode, absolute: *absoluteFigures, topProcs: *topProcCount, sortByMem: *processSort == processSortMem, oneColumn: *singleColumn}, *profileName),
 	}
 }
 
@@ -155,25 +244,209 @@ func validateFlags() error {
 	if *procCPUWindow <= 0 {
 		return fmt.Errorf("--proc-cpu-window must be > 0")
 	}
+	if *diffMode && flag.NArg() != 2 {
+		return fmt.Errorf("--diff needs two snapshot files: --diff before.json after.json")
+	}
+	if !validDensity(*density) {
+		return fmt.Errorf("--density must be normal or compact")
+	}
+	if *topProcCount < 1 || *topProcCount > maxShownProcesses {
+		return fmt.Errorf("--top-procs must be between 1 and %d", maxShownProcesses)
+	}
+	if !validProcessSort(*processSort) {
+		return fmt.Errorf("--sort must be cpu or mem")
+	}
+	if *rateAvgWindow < 0 {
+		return fmt.Errorf("--rate-window must be >= 0")
+	}
+	if *webhookURL != "" {
+		u, err := url.Parse(*webhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--webhook-url must be an http(s) URL")
+		}
+	}
+	if *alertBelow < 0 || *alertBelow > 100 {
+		return fmt.Errorf("--alert-below must be between 0 and 100")
+	}
+	if (*tlsCert == "") != (*tlsKey == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be set together")
+	}
+	if *watchMode || *daemonSocket != "" {
+		// The TUI checks it before starting; other modes never read it.
+		if _, err := refreshIntervalFromFlags(os.Getenv); err != nil {
+			return err
+		}
+	}
+	if _, err := noiseInterfacesFromFlags(os.Getenv); err != nil {
+		return err
+	}
+	if *onceMode && (*watchMode || *jsonOutput) {
+		return fmt.Errorf("--once cannot be combined with --watch or --json")
+	}
+	if *lineMode && (*onceMode || *watchMode || *jsonOutput || *daemonSocket != "") {
+		return fmt.Errorf("--line cannot be combined with --once, --watch, --json or --daemon")
+	}
+	if *daemonSocket != "" && (*watchMode || *onceMode || *jsonOutput || *headless) {
+		return fmt.Errorf("--daemon cannot be combined with --watch, --once, --json or --headless")
+	}
+	if *byteUnitsMode != "iec" && *byteUnitsMode != "si" {
+		return fmt.Errorf("--units must be iec or si")
+	}
+	if *bellSound != "" && !*bellOnCritical {
+		return fmt.Errorf("--bell-sound requires --bell")
+	}
+	if *serveAddr == "" && (*tlsCert != "" || *authToken != "") {
+		return fmt.Errorf("--tls-cert, --tls-key and --auth-token require --serve")
+	}
+	if *trendSamples < 2 || *trendSamples > maxTrendSamples {
+		return fmt.Errorf("--history must be between 2 and %d", maxTrendSamples)
+	}
+	if *headless && *serveAddr == "" {
+		return fmt.Errorf("--headless requires --serve")
+	}
+	if !validStatsdTags(*statsdTags) {
+		return fmt.Errorf("--statsd-tags must be none, datadog, or influx")
+	}
+	if *statsdAddr != "" {
+		if _, _, err := net.SplitHostPort(*statsdAddr); err != nil {
+			return fmt.Errorf("--statsd must be host:port: %v", err)
+		}
+	}
+	if *serveAddr != "" {
+		if _, err := normalizeServeAddr(*serveAddr); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// newCollectorFromFlags applies the collection flags shared by every mode.
+func newCollectorFromFlags() *Collector {
+	c := NewCollector(processWatchOptionsFromFlags())
+	c.rateWindow = *rateAvgWindow
+	c.topProcs = *topProcCount
+	c.sortByMem = *processSort == processSortMem
+	c.watchName = strings.TrimSpace(*watchProcess)
+	c.allDisks = *allDisks
+	c.noisePrefixes, _ = noiseInterfacesFromFlags(os.Getenv) // Validated in validateFlags
+	c.logErrors = *logErrorRates
+	c.checkUpdates = *checkUpdates
+	c.lookupPublicIP = *publicIPLookup
+	c.pingHost = pingHostFromFlags(*pingProbe, *pingHost)
+	c.primaryInterface = strings.TrimSpace(activeConfig.PrimaryInterface)
+	c.nameRules, _ = compileProcessNameRules(activeConfig.ProcessNameRules)
+	activeConfig.applyProfileSettings(c, *profileName)
+	if *persistRates {
+		c.restoreRateState(defaultRateStatePath(), time.Now())
+	}
+	return c
+}
+
+func serverOptionsFromFlags() serverOptions {
+	return serverOptions{
+		Addr:      *serveAddr,
+		TLSCert:   *tlsCert,
+		TLSKey:    *tlsKey,
+		AuthToken: *authToken,
+	}
+}
+
+// alertNotifierFromFlags builds the sinks requested on the command line.
+func alertNotifierFromFlags(extra ...alertSink) (*alertNotifier, error) {
+	sinks := append([]alertSink(nil), extra...)
+	if *webhookURL != "" {
+		sink, err := newWebhookSink(*webhookURL, *webhookTemplate)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if *alertBelow > 0 {
+		sinks = append(sinks, desktopSink{})
+	}
+	notifier := newAlertNotifier(sinks...)
+	notifier.healthBelow = *alertBelow
+	return notifier, nil
+}
+
+// routeWebhookFailures replaces the stderr report of every webhook sink. It
+// must run before the first alert is sent.
+func routeWebhookFailures(notifier *alertNotifier, report func(AlertEvent, error)) {
+	for _, sink := range notifier.sinks {
+		if webhook, ok := sink.(*webhookSink); ok {
+			webhook.onFailure = report
+		}
+	}
+}
+
 func (m model) Init() tea.Cmd {
+	if moleFrozen {
+		return tickAfter(0)
+	}
 	return tea.Batch(tickAfter(0), animTick())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer crashes.guard()
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.killTarget != nil && msg.String() != "ctrl+c" {
+			return m.updateKillConfirm(msg.String())
+		}
 		switch msg.String() {
-		case "q", "esc", "ctrl+c":
+		case "esc":
+			if m.view.selectedPID != 0 {
+				m.view.selectedPID = 0
+				return m, nil
+			}
+			return m, tea.Quit
+		case "q", "ctrl+c":
 			return m, tea.Quit
+		case " ":
+			// The tick chains keep running while paused; they just skip
+			// collecting and advancing the mole.
+			m.paused = !m.paused
+			return m, nil
+		case "up":
+			return m.moveProcessSelection(-1), nil
+		case "down":
+			return m.moveProcessSelection(1), nil
+		case "x":
+			if i := processIndex(m.shownProcesses(), m.view.selectedPID); i >= 0 {
+				target := m.shownProcesses()[i]
+				m.killTarget = &target
+			}
+			return m, nil
 		case "k":
 			// Toggle cat visibility and persist preference
 			m.catHidden = !m.catHidden
 			saveCatHidden(m.catHidden)
 			return m, nil
+		case "b":
+			m.view.sinceBoot = !m.view.sinceBoot
+			return m, nil
+		case "a":
+			m.showAlertLog = !m.showAlertLog
+			return m, nil
+		case "z":
+			m.view.ambient = !m.view.ambient
+			return m, nil
+		case "m":
+			m.view.movers = !m.view.movers
+			return m, nil
+		case "p":
+			name := activeConfig.nextProfile(m.view.profile)
+			m.view = activeConfig.applyProfile(m.view, name)
+			activeConfig.applyProfileSettings(m.collector, name)
+			return m, nil
 		}
+	case killResultMsg:
+		return m.handleKillResult(msg)
+	case clearKillNoticeMsg:
+		if m.killNotice == msg.notice {
+			m.killNotice = ""
+		}
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -182,9 +455,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.collecting {
 			return m, nil
 		}
+		if m.paused {
+			return m, tickAfter(m.refreshDelay())
+		}
 		m.collecting = true
 		return m, m.collectCmd(m.nextCollectionMode(time.Now()))
 	case metricsMsg:
+		if m.paused && m.ready {
+			// Collected just before the pause; keep the frozen snapshot.
+			m.collecting = false
+			return m, tickAfter(m.refreshDelay())
+		}
 		wasReady := m.ready
 		if msg.err != nil {
 			m.errMessage = msg.err.Error()
@@ -193,6 +474,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.metrics = msg.data
 		m.lastUpdated = msg.data.CollectedAt
+		if msg.err == nil {
+			crashes.Record(msg.data)
+			m.recent.Add(msg.data)
+			if m.cpuTrend != nil && m.memTrend != nil {
+				m.cpuTrend.Add(msg.data.CPU.Usage)
+				m.memTrend.Add(msg.data.Memory.UsedPercent)
+			}
+			m.notifier.Observe(msg.data)
+			m.hook.Observe(msg.data)
+			m.statsd.Observe(msg.data)
+			m.bell.Observe(msg.data)
+			m.csv.Observe(msg.data)
+		}
 		if msg.err == nil {
 			recordCollectionFreshness(msg.mode, msg.data.CollectedAt, &m.lastFullAt, &m.lastProcessAt)
 		}
@@ -201,19 +495,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.ready {
 			m.ready = true
 		}
-		delay := refreshInterval
+		delay := m.refreshDelay()
 		if !wasReady {
 			delay = 0
 		}
 		return m, tickAfter(delay)
 	case animTickMsg:
-		m.animFrame++
+		if !m.paused {
+			m.animFrame++
+		}
+		if m.view.ambient {
+			return m, tea.Tick(ambientAnimInterval, func(time.Time) tea.Msg { return animTickMsg{} })
+		}
 		return m, animTickWithSpeed(m.metrics.CPU.Usage)
 	}
 	return m, nil
 }
 
 func (m model) View() string {
+	defer crashes.guard()
 	if !m.ready {
 		return "Loading..."
 	}
@@ -225,34 +525,44 @@ func (m model) View() string {
 
 	header, mole := renderHeader(m.metrics, m.errMessage, m.animFrame, termWidth, m.catHidden)
 	alertBar := renderProcessAlertBar(m.metrics.ProcessAlerts, termWidth)
+	hookBar := renderHookBar(m.hook, termWidth)
+	killBar := renderKillBar(m.killTarget, m.killNotice)
+	if m.view.ambient && !m.showAlertLog {
+		return renderAmbient(m.metrics, alertBar, m.animFrame, termWidth, m.height, m.catHidden)
+	}
 
 	var cardContent string
-	if termWidth <= 80 {
+	if m.showAlertLog {
+		cardContent = renderAlertLog(m.alertLog.Entries(), termWidth)
+	} else if termWidth <= singleColumnMaxWidth || m.view.oneColumn {
 		cardWidth := termWidth
 		if cardWidth > 2 {
 			cardWidth -= 2
 		}
-		cards := buildCards(m.metrics, cardWidth)
-
-		var rendered []string
-		for i, c := range cards {
-			if i > 0 {
-				rendered = append(rendered, "")
-			}
-			rendered = append(rendered, renderCard(c, cardWidth, 0))
-		}
-		cardContent = lipgloss.JoinVertical(lipgloss.Left, rendered...)
+		cardContent = renderSingleColumn(m.withMovers(buildCards(m.metrics, cardWidth, m.cardOptions())), cardWidth)
 	} else {
 		cardWidth := max(24, termWidth/2-4)
-		cards := buildCards(m.metrics, cardWidth)
+		cards := m.withMovers(buildCards(m.metrics, cardWidth, m.cardOptions()))
 		cardContent = renderTwoColumns(cards, termWidth)
 	}
 
 	// Combine header, mole, and cards with consistent spacing
 	parts := []string{header}
+	if m.paused {
+		parts = append(parts, warnStyle.Render("❚❚ PAUSED")+subtleStyle.Render("  space to resume"))
+	}
+	if m.view.profile != "" {
+		parts = append(parts, subtleStyle.Render("Profile "+m.view.profile))
+	}
 	if alertBar != "" {
 		parts = append(parts, alertBar)
 	}
+	if hookBar != "" {
+		parts = append(parts, hookBar)
+	}
+	if killBar != "" {
+		parts = append(parts, killBar)
+	}
 	if mole != "" {
 		parts = append(parts, mole)
 	}
@@ -261,6 +571,28 @@ func (m model) View() string {
 	return padViewToHeight(output, m.height)
 }
 
+// refreshDelay is the wait between collections.
+func (m model) refreshDelay() time.Duration {
+	if m.view.ambient {
+		return ambientRefreshInterval
+	}
+	if m.interval > 0 {
+		return m.interval
+	}
+	return refreshInterval
+}
+
+// cardOptions is the view options plus the recent CPU and memory samples the
+// Trend lines draw.
+func (m model) cardOptions() viewOptions {
+	opts := m.view
+	if m.cpuTrend != nil && m.memTrend != nil {
+		opts.cpuTrend = m.cpuTrend.Slice()
+		opts.memTrend = m.memTrend.Slice()
+	}
+	return opts
+}
+
 func (m model) nextCollectionMode(now time.Time) collectionMode {
 	return nextCollectionMode(m.ready, m.lastFullAt, m.lastProcessAt, now)
 }
@@ -289,6 +621,7 @@ func recordCollectionFreshness(mode collectionMode, collectedAt time.Time, lastF
 
 func (m model) collectCmd(mode collectionMode) tea.Cmd {
 	return func() tea.Msg {
+		defer crashes.guard()
 		var (
 			data MetricsSnapshot
 			err  error
@@ -321,13 +654,14 @@ func animTickWithSpeed(cpuUsage float64) tea.Cmd {
 
 // runJSONMode collects metrics once and outputs as JSON.
 func runJSONMode() {
-	collector := NewCollector(processWatchOptionsFromFlags())
+	collector := newCollectorFromFlags()
 
 	data, err := collector.Collect()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error collecting metrics: %v\n", err)
 		os.Exit(1)
 	}
+	persistRateState(collector)
 
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -338,50 +672,226 @@ func runJSONMode() {
 }
 
 // runTUIMode runs the interactive terminal UI.
-func runTUIMode() {
-	p := tea.NewProgram(newModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+func runTUIMode(notifier *alertNotifier, history *alertLog, hook *healthHook, statsd *statsdExporter, bell *criticalBell, csv *csvLog) {
+	p := tea.NewProgram(newModel(notifier, history, hook, statsd, bell, csv), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "system status error: %v\n", err)
+		if path := crashes.LastPath(); path != "" {
+			fmt.Fprintf(os.Stderr, "crash report written to %s\n", path)
+		}
 		os.Exit(1)
 	}
+	m, ok := final.(model)
+	if !ok {
+		return
+	}
+	persistRateState(m.collector)
+	if *exitSummary {
+		if line := formatExitSummary(m, time.Now()); line != "" {
+			fmt.Println(line)
+		}
+	}
+}
+
+// formatExitSummary renders the last snapshot as one key=value line so a quick
+// check leaves a takeaway in the scrollback. It is empty before the first
+// snapshot arrives.
+func formatExitSummary(m model, now time.Time) string {
+	if !m.ready {
+		return ""
+	}
+	session := now.Sub(m.startedAt).Round(time.Second)
+	if m.startedAt.IsZero() || session < 0 {
+		session = 0
+	}
+	parts := []string{
+		"host=" + m.metrics.Host,
+		fmt.Sprintf("health=%d", m.metrics.HealthScore),
+		fmt.Sprintf("cpu=%.1f", m.metrics.CPU.Usage),
+		fmt.Sprintf("mem=%.1f", m.metrics.Memory.UsedPercent),
+		"session=" + session.String(),
+	}
+	if alerts := len(activeAlerts(m.metrics.ProcessAlerts)); alerts > 0 {
+		parts = append(parts, fmt.Sprintf("alerts=%d", alerts))
+	}
+	return "mole status: " + strings.Join(parts, " ")
+}
+
+// refreshIntervalFromFlags resolves --interval, falling back to
+// $MOLE_INTERVAL and then refreshInterval. Rates divide by the measured
+// elapsed time, so shorter intervals stay accurate; the Bluetooth, GPU info
+// and other slow caches keep their own TTLs regardless. The minRefreshInterval
+// floor guards the TUI tick; --watch only emits JSON and accepts any positive
+// interval, as it always has.
+func refreshIntervalFromFlags(getenv func(string) string) (time.Duration, error) {
+	floor := minRefreshInterval
+	if *watchMode {
+		floor = 0
+	}
+	if *watchInterval != "" {
+		return parseRefreshInterval(*watchInterval, "--interval", floor)
+	}
+	return parseRefreshInterval(getenv(refreshIntervalEnv), refreshIntervalEnv, floor)
 }
 
-func parseWatchInterval(raw string) (time.Duration, error) {
+// noiseInterfacesFromFlags resolves the hidden interface prefixes from
+// --show-all-interfaces, --hide-interfaces, then $MOLE_HIDE_INTERFACES.
+func noiseInterfacesFromFlags(getenv func(string) string) ([]string, error) {
+	if *showAllIfaces {
+		return nil, nil
+	}
+	if *hideInterfaces != "" {
+		return parseNoiseInterfaces(*hideInterfaces, "--hide-interfaces")
+	}
+	return parseNoiseInterfaces(getenv(hideInterfacesEnv), hideInterfacesEnv)
+}
+
+func parseRefreshInterval(raw, source string, floor time.Duration) (time.Duration, error) {
 	if raw == "" {
 		return refreshInterval, nil
 	}
 
 	d, err := time.ParseDuration(raw)
 	if err != nil {
-		return 0, fmt.Errorf("invalid --interval %q (want e.g. 1s, 2s): %w", raw, err)
+		return 0, fmt.Errorf("invalid %s %q (want e.g. 500ms, 2s): %w", source, raw, err)
 	}
 	if d <= 0 {
-		return 0, fmt.Errorf("invalid --interval %q (must be > 0)", raw)
+		return 0, fmt.Errorf("invalid %s %q (must be > 0)", source, raw)
+	}
+	if d < floor {
+		return 0, fmt.Errorf("invalid %s %q (must be at least %s)", source, raw, floor)
 	}
 	return d, nil
 }
 
 func main() {
+	defer crashes.guard()
 	flag.Parse()
 	if err := validateFlags(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(2)
 	}
+	setNumberLocale(*numberLang)
+	siByteUnits.Store(*byteUnitsMode == "si")
+	moleAnchored = *stillMole
+	moleFrozen = *noAnimation || *noMole
+	var unknownCards []string
+	flagCards, unknownCards = parseCardList(*cardList)
+	if len(unknownCards) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: --cards: ignoring unknown %s (known: %s)\n", strings.Join(unknownCards, ", "), strings.Join(cardNames, ", "))
+	}
 
-	if *watchMode {
-		interval, err := parseWatchInterval(*watchInterval)
-		if err != nil {
+	if *diffMode {
+		runDiffMode(flag.Arg(0), flag.Arg(1))
+		return
+	}
+
+	cfgPath, explicit := *configPath, *configPath != ""
+	if !explicit {
+		cfgPath = defaultStatusConfigPath()
+	}
+	cfg, err := loadStatusConfig(cfgPath, explicit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	activeConfig = cfg
+	if err := applyProfileFlags(cfg, *profileName); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	if *exportPath != "" {
+		runExportMode(*exportPath)
+		return
+	}
+
+	if *onceMode {
+		runOnceMode()
+		return
+	}
+
+	if *lineMode {
+		runLineMode()
+		return
+	}
+
+	hook, err := healthHookFromFlags(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	history := newAlertLog(alertLogCapacity, *alertLogPath)
+	notifier, err := alertNotifierFromFlags(history)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	var statsd *statsdExporter
+	if *statsdAddr != "" {
+		if statsd, err = newStatsdExporter(*statsdAddr, *statsdPrefix, *statsdTags); err != nil {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(2)
 		}
-		runWatchMode(interval)
+	}
+
+	var bell *criticalBell
+	if *bellOnCritical {
+		// stderr reaches the terminal in both the TUI and --watch (whose stdout is JSON).
+		bell = newCriticalBell(os.Stderr, *bellSound)
+	}
+
+	var csv *csvLog
+	if *csvLogPath != "" {
+		csv = newCSVLog(*csvLogPath)
+	}
+
+	if *serveAddr != "" {
+		opts := serverOptionsFromFlags()
+		if addr, _ := normalizeServeAddr(opts.Addr); !isLoopbackAddr(addr) && opts.AuthToken == "" {
+			fmt.Fprintf(os.Stderr, "warning: serving metrics on %s without --auth-token\n", addr)
+		}
+		if _, err := startMetricsServer(opts, newCollectorFromFlags()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if *headless {
+			select {} // Serve until killed.
+		}
+	}
+
+	if *daemonSocket != "" {
+		interval, _ := refreshIntervalFromFlags(os.Getenv)
+		if err := runDaemonMode(*daemonSocket, interval, notifier, hook, statsd, bell, csv); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *watchMode {
+		interval, _ := refreshIntervalFromFlags(os.Getenv)
+		if hook != nil {
+			hook.onResult = func(r hookResult) { fmt.Fprintln(os.Stderr, "status: "+formatHookResult(r)) }
+		}
+		runWatchMode(interval, notifier, hook, statsd, bell, csv)
 		return
 	}
 
 	if shouldUseJSONOutput(*jsonOutput, os.Stdout) {
 		runJSONMode()
 	} else {
-		runTUIMode()
+		if _, err := refreshIntervalFromFlags(os.Getenv); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+		// Stderr would garble the full-screen view, so webhook delivery
+		// failures go to the alert log panel (and --alert-log) instead.
+		routeWebhookFailures(notifier, history.DeliveryFailed)
+		runTUIMode(notifier, history, hook, statsd, bell, csv)
 	}
 }
 
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestShouldUseJSONOutput_ForceFlag(t *testing.T) {
@@ -93,15 +96,18 @@ func TestValidateFlags(t *testing.T) {
 	}
 }
 
-func TestParseWatchInterval(t *testing.T) {
+func TestParseRefreshInterval(t *testing.T) {
 	tests := []struct {
 		name    string
 		raw     string
+		floor   time.Duration
 		want    time.Duration
 		wantErr bool
 	}{
-		{name: "default", raw: "", want: refreshInterval},
-		{name: "duration", raw: "250ms", want: 250 * time.Millisecond},
+		{name: "default", raw: "", floor: minRefreshInterval, want: refreshInterval},
+		{name: "duration", raw: "250ms", floor: minRefreshInterval, want: 250 * time.Millisecond},
+		{name: "below minimum", raw: "100ms", floor: minRefreshInterval, wantErr: true},
+		{name: "no floor", raw: "100ms", want: 100 * time.Millisecond},
 		{name: "invalid", raw: "soon", wantErr: true},
 		{name: "zero", raw: "0s", wantErr: true},
 		{name: "negative", raw: "-1s", wantErr: true},
@@ -109,23 +115,59 @@ func TestParseWatchInterval(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseWatchInterval(tt.raw)
+			got, err := parseRefreshInterval(tt.raw, "--interval", tt.floor)
 			if tt.wantErr {
 				if err == nil {
-					t.Fatalf("parseWatchInterval(%q) returned nil error", tt.raw)
+					t.Fatalf("parseRefreshInterval(%q) returned nil error", tt.raw)
 				}
 				return
 			}
 			if err != nil {
-				t.Fatalf("parseWatchInterval(%q) error = %v", tt.raw, err)
+				t.Fatalf("parseRefreshInterval(%q) error = %v", tt.raw, err)
 			}
 			if got != tt.want {
-				t.Fatalf("parseWatchInterval(%q) = %v, want %v", tt.raw, got, tt.want)
+				t.Fatalf("parseRefreshInterval(%q) = %v, want %v", tt.raw, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestRefreshIntervalFlagOverridesEnv(t *testing.T) {
+	env := func(string) string { return "2s" }
+	orig := *watchInterval
+	t.Cleanup(func() { *watchInterval = orig })
+
+	*watchInterval = ""
+	if got, err := refreshIntervalFromFlags(env); err != nil || got != 2*time.Second {
+		t.Fatalf("env fallback = %v, %v; want 2s", got, err)
+	}
+	*watchInterval = "500ms"
+	if got, err := refreshIntervalFromFlags(env); err != nil || got != 500*time.Millisecond {
+		t.Fatalf("flag = %v, %v; want 500ms", got, err)
+	}
+	*watchInterval = ""
+	_, err := refreshIntervalFromFlags(func(string) string { return "10ms" })
+	if err == nil || !strings.Contains(err.Error(), refreshIntervalEnv) {
+		t.Fatalf("invalid env value error = %v, want it to name %s", err, refreshIntervalEnv)
+	}
+}
+
+func TestRefreshIntervalFloorSkipsWatchAndOtherModes(t *testing.T) {
+	origInterval, origWatch, origJSON := *watchInterval, *watchMode, *jsonOutput
+	t.Cleanup(func() { *watchInterval, *watchMode, *jsonOutput = origInterval, origWatch, origJSON })
+
+	*watchInterval, *watchMode = "100ms", true
+	if got, err := refreshIntervalFromFlags(os.Getenv); err != nil || got != 100*time.Millisecond {
+		t.Fatalf("--watch interval = %v, %v; want 100ms", got, err)
+	}
+
+	*watchInterval, *watchMode, *jsonOutput = "", false, true
+	t.Setenv(refreshIntervalEnv, "bogus")
+	if err := validateFlags(); err != nil {
+		t.Fatalf("--json should ignore %s, got %v", refreshIntervalEnv, err)
+	}
+}
+
 func TestNextCollectionModeUsesFastFirstThenPeriodicFull(t *testing.T) {
 	now := time.Now()
 
@@ -230,7 +272,7 @@ func TestProcessCollectionUpdatesProcessFreshness(t *testing.T) {
 func TestCollectorAppliesCachedEnrichmentToFastSnapshot(t *testing.T) {
 	previous := MetricsSnapshot{
 		CPU:         CPUStatus{PCoreCount: 8, ECoreCount: 4},
-		Memory:      MemoryStatus{Cached: 512, Pressure: "warn"},
+		Memory:      MemoryStatus{Cached: 512, Pressure: "warn", PressurePercent: 42},
 		Hardware:    HardwareInfo{Model: "MacBook Pro", CPUModel: "M3", OSVersion: "macOS 15", RefreshRate: "120Hz"},
 		GPU:         []GPUStatus{{Name: "Apple GPU", Usage: 12}},
 		TrashSize:   42,
@@ -269,7 +311,7 @@ func TestCollectorAppliesCachedEnrichmentToFastSnapshot(t *testing.T) {
 	if next.CPU.PCoreCount != 8 || next.CPU.ECoreCount != 4 {
 		t.Fatalf("expected CPU topology to be preserved, got %#v", next.CPU)
 	}
-	if next.Memory.Cached != 512 || next.Memory.Pressure != "warn" {
+	if next.Memory.Cached != 512 || next.Memory.Pressure != "warn" || next.Memory.PressurePercent != 42 {
 		t.Fatalf("expected slow memory annotations to be preserved, got %#v", next.Memory)
 	}
 	if next.TrashSize != 42 || !next.TrashApprox {
@@ -396,32 +438,47 @@ func TestCollectorKeepsLiveProcessDataWhenApplyingEnrichment(t *testing.T) {
 
 func TestMetricsSnapshotFieldsHaveCollectionClassifications(t *testing.T) {
 	classified := map[string]string{
-		"CollectedAt":    "fast",
-		"Host":           "fast",
-		"Platform":       "fast",
-		"Uptime":         "fast",
-		"UptimeSeconds":  "fast",
-		"Procs":          "fast",
-		"Hardware":       "enrichment",
-		"HealthScore":    "recomputed",
-		"HealthScoreMsg": "recomputed",
-		"CPU":            "mixed",
-		"GPU":            "enrichment",
-		"Memory":         "mixed",
-		"Disks":          "enrichment",
-		"TrashSize":      "enrichment",
-		"TrashApprox":    "enrichment",
-		"DiskIO":         "fast",
-		"Network":        "fast",
-		"NetworkHistory": "fast",
-		"Proxy":          "enrichment",
-		"Batteries":      "enrichment",
-		"Thermal":        "enrichment",
-		"Sensors":        "enrichment",
-		"Bluetooth":      "enrichment",
-		"TopProcesses":   "live-or-enrichment",
-		"ProcessWatch":   "config",
-		"ProcessAlerts":  "live-or-enrichment",
+		"CollectedAt":     "fast",
+		"Host":            "fast",
+		"Platform":        "fast",
+		"Uptime":          "fast",
+		"UptimeSeconds":   "fast",
+		"Procs":           "fast",
+		"Hardware":        "enrichment",
+		"HealthScore":     "recomputed",
+		"HealthScoreMsg":  "recomputed",
+		"HealthBreakdown": "recomputed",
+		"CPU":             "mixed",
+		"GPU":             "enrichment",
+		"Memory":          "mixed",
+		"Disks":           "enrichment",
+		"TrashSize":       "enrichment",
+		"TrashApprox":     "enrichment",
+		"DiskIO":          "fast",
+		"Network":         "fast",
+		"NetworkHistory":  "fast",
+		"Proxy":           "enrichment",
+		"Batteries":       "enrichment",
+		"Thermal":         "enrichment",
+		"Sensors":         "enrichment",
+		"Bluetooth":       "enrichment",
+		"WiFi":            "enrichment",
+		"TopProcesses":    "live-or-enrichment",
+		"ProcessWatch":    "config",
+		"ProcessAlerts":   "live-or-enrichment",
+		"WatchedProcess":  "live-or-enrichment",
+		"ProcessStates":   "enrichment",
+		"NetworkTalker":   "fast",
+		"SystemLimits":    "enrichment",
+		"LogErrors":       "enrichment",
+		"PendingUpdates":  "enrichment",
+		"PublicIP":        "enrichment",
+		"TCPConnections":  "enrichment",
+		"Ping":            "enrichment",
+		"VPN":             "enrichment",
+		"CollectErrors":   "fast",
+		"Virtualized":     "fast",
+		"Virtualization":  "fast",
 	}
 
 	typ := reflect.TypeFor[MetricsSnapshot]()
@@ -435,3 +492,112 @@ func TestMetricsSnapshotFieldsHaveCollectionClassifications(t *testing.T) {
 		t.Fatalf("field classification count = %d, want %d", len(classified), typ.NumField())
 	}
 }
+
+func TestModelTogglesSinceBootView(t *testing.T) {
+	m := model{}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if !updated.(model).view.sinceBoot {
+		t.Fatal("expected b to enable since-boot view")
+	}
+}
+
+func TestModelTogglesAlertLogView(t *testing.T) {
+	m := model{ready: true, alertLog: newAlertLog(alertLogCapacity, "")}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	got := updated.(model)
+	if !got.showAlertLog {
+		t.Fatal("expected a to open the alert log")
+	}
+	if view := stripANSI(got.View()); !strings.Contains(view, "Alert log") {
+		t.Fatalf("expected alert log in view, got %q", view)
+	}
+}
+
+func TestModelTogglesAmbientView(t *testing.T) {
+	m := model{ready: true, width: 80, height: 24, catHidden: true, metrics: MetricsSnapshot{
+		HealthScore: 87,
+		CPU:         CPUStatus{Usage: 12},
+		Memory:      MemoryStatus{UsedPercent: 54},
+	}}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	got := updated.(model)
+	if !got.view.ambient {
+		t.Fatal("expected z to enable ambient mode")
+	}
+	view := stripANSI(got.View())
+	if !strings.Contains(view, renderBigNumber(87)[:len("███ ███")]) || !strings.Contains(view, "CPU 12% · Mem 54%") {
+		t.Fatalf("ambient view missing score or summary:\n%s", view)
+	}
+	if strings.Contains(view, "Processes") {
+		t.Fatalf("ambient view should hide cards:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 24 {
+		t.Fatalf("ambient view should fill the terminal height, got %d lines", lines)
+	}
+
+	_, cmd := got.Update(metricsMsg{data: got.metrics})
+	if cmd == nil {
+		t.Fatal("expected a follow-up tick")
+	}
+}
+
+func TestRenderBigNumber(t *testing.T) {
+	if got, want := renderBigNumber(10), " █  ███\n██  █ █\n █  █ █\n █  █ █\n███ ███"; got != want {
+		t.Fatalf("renderBigNumber(10) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatExitSummary(t *testing.T) {
+	started := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	m := model{
+		ready:     true,
+		startedAt: started,
+		metrics: MetricsSnapshot{
+			Host:        "studio",
+			HealthScore: 87,
+			CPU:         CPUStatus{Usage: 12.34},
+			Memory:      MemoryStatus{UsedPercent: 54},
+		},
+	}
+
+	got := formatExitSummary(m, started.Add(3*time.Minute+12*time.Second))
+	want := "mole status: host=studio health=87 cpu=12.3 mem=54.0 session=3m12s"
+	if got != want {
+		t.Fatalf("formatExitSummary() = %q, want %q", got, want)
+	}
+	if got := formatExitSummary(model{}, started); got != "" {
+		t.Fatalf("expected no summary before the first snapshot, got %q", got)
+	}
+}
+
+func TestModelPauseFreezesSnapshotAndMole(t *testing.T) {
+	m := model{ready: true, metrics: MetricsSnapshot{HealthScore: 80}}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m = updated.(model)
+	if !m.paused {
+		t.Fatal("space should pause")
+	}
+
+	updated, cmd := m.Update(tickMsg{})
+	m = updated.(model)
+	if m.collecting || cmd == nil {
+		t.Fatalf("paused tick should reschedule without collecting, collecting=%v", m.collecting)
+	}
+	updated, _ = m.Update(metricsMsg{data: MetricsSnapshot{HealthScore: 20}})
+	m = updated.(model)
+	if m.metrics.HealthScore != 80 {
+		t.Fatalf("paused model took a new snapshot: %d", m.metrics.HealthScore)
+	}
+	updated, _ = m.Update(animTickMsg{})
+	if m = updated.(model); m.animFrame != 0 {
+		t.Fatal("paused model advanced the mole")
+	}
+	if !strings.Contains(stripANSI(m.View()), "PAUSED") {
+		t.Fatal("paused view should say PAUSED")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if updated.(model).paused {
+		t.Fatal("space again should resume")
+	}
+}
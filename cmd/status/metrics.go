@@ -36,6 +36,14 @@ func (rb *RingBuffer) Add(val float64) {
 	}
 }
 
+// Bytes is the size of the preallocated backing array.
+func (rb *RingBuffer) Bytes() int {
+	if rb == nil {
+		return 0
+	}
+	return float64Bytes * rb.cap
+}
+
 // Slice returns the data in chronological order (oldest to newest).
 func (rb *RingBuffer) Slice() []float64 {
 	if rb.size == 0 {
@@ -58,33 +66,48 @@ func (rb *RingBuffer) Slice() []float64 {
 }
 
 type MetricsSnapshot struct {
-	CollectedAt    time.Time    `json:"collected_at"`
-	Host           string       `json:"host"`
-	Platform       string       `json:"platform"`
-	Uptime         string       `json:"uptime"`
-	UptimeSeconds  uint64       `json:"uptime_seconds"`
-	Procs          uint64       `json:"procs"`
-	Hardware       HardwareInfo `json:"hardware"`
-	HealthScore    int          `json:"health_score"`     // 0-100 system health score
-	HealthScoreMsg string       `json:"health_score_msg"` // Brief explanation
-
-	CPU            CPUStatus          `json:"cpu"`
-	GPU            []GPUStatus        `json:"gpu"`
-	Memory         MemoryStatus       `json:"memory"`
-	Disks          []DiskStatus       `json:"disks"`
-	TrashSize      uint64             `json:"trash_size"`
-	TrashApprox    bool               `json:"trash_approx"`
-	DiskIO         DiskIOStatus       `json:"disk_io"`
-	Network        []NetworkStatus    `json:"network"`
-	NetworkHistory NetworkHistory     `json:"network_history"`
-	Proxy          ProxyStatus        `json:"proxy"`
-	Batteries      []BatteryStatus    `json:"batteries"`
-	Thermal        ThermalStatus      `json:"thermal"`
-	Sensors        []SensorReading    `json:"sensors"`
-	Bluetooth      []BluetoothDevice  `json:"bluetooth"`
-	TopProcesses   []ProcessInfo      `json:"top_processes"`
-	ProcessWatch   ProcessWatchConfig `json:"process_watch"`
-	ProcessAlerts  []ProcessAlert     `json:"process_alerts"`
+	CollectedAt     time.Time       `json:"collected_at"`
+	Host            string          `json:"host"`
+	Platform        string          `json:"platform"`
+	Uptime          string          `json:"uptime"`
+	UptimeSeconds   uint64          `json:"uptime_seconds"`
+	Procs           uint64          `json:"procs"`
+	Hardware        HardwareInfo    `json:"hardware"`
+	HealthScore     int             `json:"health_score"`     // 0-100 system health score
+	HealthScoreMsg  string          `json:"health_score_msg"` // Brief explanation
+	HealthBreakdown HealthBreakdown `json:"health_breakdown"` // Penalties and issues behind the score
+
+	CPU            CPUStatus           `json:"cpu"`
+	GPU            []GPUStatus         `json:"gpu"`
+	Memory         MemoryStatus        `json:"memory"`
+	Disks          []DiskStatus        `json:"disks"`
+	TrashSize      uint64              `json:"trash_size"`
+	TrashApprox    bool                `json:"trash_approx"`
+	DiskIO         DiskIOStatus        `json:"disk_io"`
+	Network        []NetworkStatus     `json:"network"`
+	NetworkHistory NetworkHistory      `json:"network_history"`
+	Proxy          ProxyStatus         `json:"proxy"`
+	Batteries      []BatteryStatus     `json:"batteries"`
+	Thermal        ThermalStatus       `json:"thermal"`
+	Sensors        []SensorReading     `json:"sensors"`
+	Bluetooth      []BluetoothDevice   `json:"bluetooth"`
+	WiFi           *WiFiStatus         `json:"wifi,omitempty"`      // Current WiFi association; nil on ethernet-only machines
+	PublicIP       string              `json:"public_ip,omitempty"` // Egress address (--public-ip)
+	TCPConnections int                 `json:"tcp_connections"`     // Established TCP connections
+	Ping           *LatencyProbe       `json:"ping,omitempty"`      // Round trip with --ping
+	VPN            *VPNStatus          `json:"vpn,omitempty"`       // Active VPN tunnel; nil when none is up
+	TopProcesses   []ProcessInfo       `json:"top_processes"`
+	ProcessWatch   ProcessWatchConfig  `json:"process_watch"`
+	ProcessAlerts  []ProcessAlert      `json:"process_alerts"`
+	WatchedProcess *WatchedProcess     `json:"watched_process,omitempty"` // Totals for --watch-process
+	ProcessStates  *ProcessStateCounts `json:"process_states,omitempty"`  // Zombie and uninterruptible counts
+	NetworkTalker  *NetworkTalker      `json:"network_talker,omitempty"`  // Top network process while throughput is high
+	SystemLimits   *SystemLimits       `json:"system_limits,omitempty"`   // Open files vs limit, entropy pool
+	LogErrors      *LogErrorRate       `json:"log_errors,omitempty"`      // System log errors per minute (--log-errors)
+	PendingUpdates *PendingUpdates     `json:"pending_updates,omitempty"` // OS/package updates waiting (--updates)
+	CollectErrors  map[string]string   `json:"collect_errors,omitempty"`  // Persistent per-source failures
+	Virtualized    bool                `json:"virtualized"`               // Running in a container or virtual machine
+	Virtualization string              `json:"virtualization,omitempty"`  // docker, podman, lxc, kubernetes, kvm, ...
 }
 
 type HardwareInfo struct {
@@ -97,6 +120,14 @@ type HardwareInfo struct {
 }
 
 type DiskIOStatus struct {
+	ReadRate  float64          `json:"read_rate"`         // MB/s
+	WriteRate float64          `json:"write_rate"`        // MB/s
+	Devices   []DeviceIOStatus `json:"devices,omitempty"` // Per whole disk, busiest first
+}
+
+// DeviceIOStatus is one disk's share of DiskIOStatus.
+type DeviceIOStatus struct {
+	Name      string  `json:"name"`
 	ReadRate  float64 `json:"read_rate"`  // MB/s
 	WriteRate float64 `json:"write_rate"` // MB/s
 }
@@ -113,52 +144,87 @@ type ProcessInfo struct {
 
 type CPUStatus struct {
 	Usage            float64   `json:"usage"`
+	BootUsage        float64   `json:"boot_usage"` // Average utilization since boot
 	PerCore          []float64 `json:"per_core"`
 	PerCoreEstimated bool      `json:"per_core_estimated"`
+	PerCoreTemp      []float64 `json:"per_core_temp,omitempty"` // °C per logical CPU where per-core sensors exist
 	Load1            float64   `json:"load1"`
 	Load5            float64   `json:"load5"`
 	Load15           float64   `json:"load15"`
 	CoreCount        int       `json:"core_count"`
 	LogicalCPU       int       `json:"logical_cpu"`
-	PCoreCount       int       `json:"p_core_count"` // Performance cores (Apple Silicon)
-	ECoreCount       int       `json:"e_core_count"` // Efficiency cores (Apple Silicon)
+	PCoreCount       int       `json:"p_core_count"`        // Performance cores (Apple Silicon)
+	ECoreCount       int       `json:"e_core_count"`        // Efficiency cores (Apple Silicon)
+	CPULimit         float64   `json:"cpu_limit,omitempty"` // Cores allowed by a container's cgroup quota
 }
 
 type GPUStatus struct {
-	Name        string  `json:"name"`
-	Usage       float64 `json:"usage"`
-	MemoryUsed  float64 `json:"memory_used"`
-	MemoryTotal float64 `json:"memory_total"`
-	CoreCount   int     `json:"core_count"`
-	Note        string  `json:"note"`
+	Name        string        `json:"name"`
+	Usage       float64       `json:"usage"`
+	MemoryUsed  float64       `json:"memory_used"`
+	MemoryTotal float64       `json:"memory_total"`
+	CoreCount   int           `json:"core_count"`
+	Note        string        `json:"note"`
+	External    bool          `json:"external"`              // Removable Thunderbolt eGPU (macOS)
+	Temperature float64       `json:"temperature,omitempty"` // Celsius, when nvidia-smi or powermetrics report it
+	PowerWatts  float64       `json:"power_watts,omitempty"` // Board power draw from nvidia-smi
+	Displays    []DisplayInfo `json:"displays,omitempty"`
+}
+
+// DisplayInfo describes a monitor attached to a GPU.
+type DisplayInfo struct {
+	Name        string `json:"name"`
+	Resolution  string `json:"resolution"`   // Native pixels, e.g. 3024x1964
+	RefreshRate int    `json:"refresh_rate"` // Hz, 0 when unknown
+	BuiltIn     bool   `json:"built_in"`
+	Main        bool   `json:"main"`
 }
 
 type MemoryStatus struct {
-	Used        uint64  `json:"used"`
-	Total       uint64  `json:"total"`
-	Available   uint64  `json:"available"`
-	UsedPercent float64 `json:"used_percent"`
-	SwapUsed    uint64  `json:"swap_used"`
-	SwapTotal   uint64  `json:"swap_total"`
-	Cached      uint64  `json:"cached"`   // File cache that can be freed if needed
-	Pressure    string  `json:"pressure"` // macOS memory pressure: normal/warn/critical
+	Used            uint64  `json:"used"`
+	Total           uint64  `json:"total"`
+	Available       uint64  `json:"available"`
+	UsedPercent     float64 `json:"used_percent"`
+	SwapUsed        uint64  `json:"swap_used"`
+	SwapTotal       uint64  `json:"swap_total"`
+	SwapFiles       int     `json:"swap_files,omitempty"`       // Swap files (macOS /var/vm) or areas (Linux /proc/swaps)
+	SwapFileBytes   uint64  `json:"swap_file_bytes,omitempty"`  // Total size of those files
+	SwapGrowing     bool    `json:"swap_growing,omitempty"`     // Swap files grew rapidly in the last few minutes
+	SwapInRate      float64 `json:"swap_in_rate"`               // Pages swapped in per second
+	SwapOutRate     float64 `json:"swap_out_rate"`              // Pages swapped out per second
+	Cached          uint64  `json:"cached"`                     // File cache that can be freed if needed
+	Wired           uint64  `json:"wired,omitempty"`            // Locked by the kernel (macOS)
+	Compressed      uint64  `json:"compressed,omitempty"`       // Held by the macOS memory compressor
+	Active          uint64  `json:"active,omitempty"`           // Recently used pages
+	Pressure        string  `json:"pressure"`                   // macOS memory pressure: normal/warn/critical
+	PressurePercent float64 `json:"pressure_percent,omitempty"` // 100 minus memory_pressure's free percentage (macOS)
 }
 
 type DiskStatus struct {
-	Mount       string  `json:"mount"`
-	Device      string  `json:"device"`
-	Used        uint64  `json:"used"`
-	Total       uint64  `json:"total"`
-	UsedPercent float64 `json:"used_percent"`
-	Fstype      string  `json:"fstype"`
-	External    bool    `json:"external"`
+	Mount             string  `json:"mount"`
+	Device            string  `json:"device"`
+	Used              uint64  `json:"used"`
+	Total             uint64  `json:"total"`
+	UsedPercent       float64 `json:"used_percent"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"`
+	Fstype            string  `json:"fstype"`
+	External          bool    `json:"external"`
+	SmartStatus       string  `json:"smart_status,omitempty"` // PASSED or FAILED from smartctl -H; empty when unavailable
+	Rotational        *bool   `json:"rotational,omitempty"`   // Spinning disk (HDD); nil when the media type is unknown
+	Temperature       float64 `json:"temperature,omitempty"`  // Celsius, from SMART when smartctl can read the drive
 }
 
 type NetworkStatus struct {
-	Name      string  `json:"name"`
-	RxRateMBs float64 `json:"rx_rate_mbs"`
-	TxRateMBs float64 `json:"tx_rate_mbs"`
-	IP        string  `json:"ip"`
+	Name         string  `json:"name"`
+	RxRateMBs    float64 `json:"rx_rate_mbs"`
+	TxRateMBs    float64 `json:"tx_rate_mbs"`
+	RxBootBytes  uint64  `json:"rx_boot_bytes"` // Cumulative interface counters
+	TxBootBytes  uint64  `json:"tx_boot_bytes"`
+	RxTotalBytes uint64  `json:"rx_total_bytes"` // Moved since Mole started
+	TxTotalBytes uint64  `json:"tx_total_bytes"`
+	IP           string  `json:"ip"`
+	IPv6         string  `json:"ipv6,omitempty"`    // Global address; link-local and ULA are skipped
+	Primary      bool    `json:"primary,omitempty"` // primary_interface from config, else the default route
 }
 
 // NetworkHistory holds the global network usage history.
@@ -170,32 +236,53 @@ type NetworkHistory struct {
 const NetworkHistorySize = 120 // Increased history size for wider graph
 
 type ProxyStatus struct {
-	Enabled bool   `json:"enabled"`
-	Type    string `json:"type"` // HTTP, HTTPS, SOCKS, PAC, WPAD, TUN
-	Host    string `json:"host"`
+	Enabled   bool   `json:"enabled"`
+	Type      string `json:"type"`                // HTTP, HTTPS, SOCKS, PAC, WPAD, TUN, WireGuard
+	Host      string `json:"host"`                // Proxy address, or the WireGuard peer endpoint
+	Interface string `json:"interface,omitempty"` // WireGuard interface, e.g. wg0
+
+	// From the environment only: the proxy for each scheme (https, http,
+	// all) that has one, and the NO_PROXY hosts that bypass them.
+	Routes  []ProxyRoute `json:"routes,omitempty"`
+	NoProxy []string     `json:"no_proxy,omitempty"`
+}
+
+// ProxyRoute is one *_PROXY variable. Scheme is the traffic it covers, Type
+// the proxy protocol.
+type ProxyRoute struct {
+	Scheme string `json:"scheme"` // https, http, or all
+	Type   string `json:"type"`   // HTTP or SOCKS
+	Host   string `json:"host"`
 }
 
 type BatteryStatus struct {
+	Name       string  `json:"name,omitempty"` // pmset source (InternalBattery-0) or sysfs supply (BAT0)
 	Percent    float64 `json:"percent"`
 	Status     string  `json:"status"`
 	TimeLeft   string  `json:"time_left"`
 	Health     string  `json:"health"`
 	CycleCount int     `json:"cycle_count"`
-	Capacity   int     `json:"capacity"` // Maximum capacity percentage (e.g., 85 means 85% of original)
+	Capacity   int     `json:"capacity"`              // Maximum capacity percentage (e.g., 85 means 85% of original)
+	PowerWatts float64 `json:"power_watts,omitempty"` // Charge (+) or discharge (-) power in Watts
 }
 
 type ThermalStatus struct {
 	CPUTemp      float64 `json:"cpu_temp"`
 	GPUTemp      float64 `json:"gpu_temp"`
 	BatteryTemp  float64 `json:"battery_temp"` // Battery temperature in Celsius when exposed by AppleSmartBattery
-	FanSpeed     int     `json:"fan_speed"`
+	FanSpeed     int     `json:"fan_speed"`    // Fastest fan in RPM
 	FanCount     int     `json:"fan_count"`
-	SystemPower  float64 `json:"system_power"`  // System power consumption in Watts
-	AdapterPower float64 `json:"adapter_power"` // AC adapter max power in Watts
-	BatteryPower float64 `json:"battery_power"` // Battery charge/discharge power in Watts (positive = discharging)
+	FanSpeeds    []int   `json:"fan_speeds,omitempty"` // Per-fan RPM, in SMC order
+	SystemPower  float64 `json:"system_power"`         // System power consumption in Watts
+	AdapterPower float64 `json:"adapter_power"`        // AC adapter max power in Watts
+	BatteryPower float64 `json:"battery_power"`        // Battery charge/discharge power in Watts (positive = discharging)
+
+	ThermalPressure string `json:"thermal_pressure,omitempty"` // macOS pressure level: Nominal, Moderate, Heavy, Trapping, Sleeping
+	Throttling      bool   `json:"throttling"`                 // CPU is being slowed to shed heat
 }
 
 type SensorReading struct {
+	Key   string  `json:"key,omitempty"` // Normalized chip_label key, e.g. coretemp_core_0
 	Label string  `json:"label"`
 	Value float64 `json:"value"`
 	Unit  string  `json:"unit"`
@@ -203,9 +290,10 @@ type SensorReading struct {
 }
 
 type BluetoothDevice struct {
-	Name      string `json:"name"`
-	Connected bool   `json:"connected"`
-	Battery   string `json:"battery"`
+	Name           string `json:"name"`
+	Connected      bool   `json:"connected"`
+	Battery        string `json:"battery"`                   // e.g. "80%"; empty when not reported
+	BatteryPercent int    `json:"battery_percent,omitempty"` // Lowest reported level (AirPods report each bud and the case)
 }
 
 type Collector struct {
@@ -215,26 +303,116 @@ type Collector struct {
 	hasStatic bool
 
 	// Slow cache (30s-1m).
-	lastBTAt time.Time
-	lastBT   []BluetoothDevice
+	lastBTAt   time.Time
+	lastBT     []BluetoothDevice
+	lastWiFiAt time.Time
+	wifi       *WiFiStatus
 
-	// Fast metrics (1s).
-	prevNet        map[string]net.IOCountersStat
-	lastNetAt      time.Time
-	rxHistoryBuf   *RingBuffer
-	txHistoryBuf   *RingBuffer
-	lastNetIPAt    time.Time
-	cachedNetIPs   map[string]string
-	lastGPUAt      time.Time
-	cachedGPU      []GPUStatus
-	lastGPUUsageAt time.Time
-	cachedGPUUsage float64
-	prevDiskIO     disk.IOCountersStat
-	lastDiskAt     time.Time
+	swapSamples []swapSample
+
+	prevSwapIn         uint64
+	prevSwapOut        uint64
+	lastSwapActivityAt time.Time
 
+	// Fast metrics (1s).
+	prevNet                   map[string]net.IOCountersStat
+	netBaseline               map[string]net.IOCountersStat // First counters seen per interface, for session totals
+	tunnelTrafficAt           map[string]time.Time          // Last time each VPN tunnel moved bytes
+	lastNetAt                 time.Time
+	rxHistoryBuf              *RingBuffer
+	txHistoryBuf              *RingBuffer
+	lastNetIPAt               time.Time
+	cachedNetIPs              map[string]string
+	cachedNetIPv6             map[string]string
+	lastGPUAt                 time.Time
+	cachedGPU                 []GPUStatus
+	lastGPUUsageAt            time.Time
+	cachedGPUUsage            float64
+	cachedGPUTemp             float64
+	lastPowermetricsThermalAt time.Time
+	cachedPowermetricsThermal powermetricsThermal
+	prevThrottleCount         uint64
+	hasThrottleCount          bool
+	prevDiskIO                disk.IOCountersStat
+	prevDiskDevs              map[string]disk.IOCountersStat
+	lastDiskAt                time.Time
+
+	// Optional rate averaging span; zero measures over one refresh interval.
+	rateWindow time.Duration
+	netWindows map[string]*rateWindow
+	diskWindow rateWindow
+
+	nameRules processNameRules
+	topProcs  int    // --top-procs; the snapshot keeps at least minTopProcesses
+	sortByMem bool   // --sort mem ranks top processes by memory instead of CPU
+	watchName string // --watch-process pattern, matched against process names
+	allDisks  bool   // --all-disks lifts the maxShownDisks cap
+
+	noisePrefixes []string // Interface name prefixes the network card hides; nil shows all
+
+	// Primary interface: pinned by config, otherwise the default route.
+	primaryInterface string
+	cachedPrimary    string
+	lastPrimaryAt    time.Time
+
+	tcpConns       int
+	lastTCPConnsAt time.Time
+
+	// Best-effort top network process, looked up in the background only
+	// under load.
+	talkerMu        sync.Mutex
+	talkerRunning   bool
+	talker          *NetworkTalker
+	lastTalkerAt    time.Time
+	prevTalkerBytes map[int]talkerBytes
+	prevTalkerAt    time.Time
+
+	procStat procStatSampler
+
+	// Optional system log error sampling (--log-errors).
+	logErrors       bool
+	lastLogErrorsAt time.Time
+	logErrorRate    *LogErrorRate
+
+	// Optional pending-update checks (--updates), run in the background.
+	checkUpdates   bool
+	updatesMu      sync.Mutex
+	updatesRunning bool
+	lastUpdatesAt  time.Time
+	updates        *PendingUpdates
+
+	// Optional public IP lookups (--public-ip), run in the background.
+	lookupPublicIP  bool
+	publicIPMu      sync.Mutex
+	publicIPRunning bool
+	lastPublicIPAt  time.Time
+	publicIP        string
+
+	// Optional latency probe (--ping or --ping-host), run in the background.
+	pingHost    string
+	pingMu      sync.Mutex
+	pingRunning bool
+	lastPingAt  time.Time
+	ping        *LatencyProbe
+
+	// Container or VM, detected on first use.
+	virtOnce sync.Once
+	virt     string
+
+	// Cumulative cgroup CPU time, for usage against a CPU quota.
+	prevCgroupCPU   time.Duration
+	prevCgroupCPUAt time.Time
+
+	// Last good readings reused across transient failures.
+	cpuGood    lastGood[CPUStatus]
+	diskIOGood lastGood[DiskIOStatus]
+
+	// watchMu also guards healthWeights and the CPU threshold, which a
+	// profile switch changes while collection runs.
 	watchMu        sync.Mutex
 	processWatch   ProcessWatchConfig
 	processWatcher *ProcessWatcher
+	healthWeights  healthWeights // Config or profile health_weights, or defaultHealthWeights
 	enrichment     snapshotEnrichment
 	hasEnrichment  bool
 }
@@ -247,36 +425,64 @@ type collectedMetrics struct {
 	trashApprox  bool
 	diskIO       DiskIOStatus
 	netStats     []NetworkStatus
+	talker       *NetworkTalker
 	proxyStats   ProxyStatus
 	batteryStats []BatteryStatus
 	thermalStats ThermalStatus
 	sensorStats  []SensorReading
 	gpuStats     []GPUStatus
 	btStats      []BluetoothDevice
+	wifi         *WiFiStatus
 	allProcs     []ProcessInfo
 	hasProcesses bool
+	procStates   *ProcessStateCounts
+	limits       *SystemLimits
+	logErrors    *LogErrorRate
+	updates      *PendingUpdates
+	publicIP     string
+	tcpConns     int
+	ping         *LatencyProbe
+	vpnService   string
+	vpn          *VPNStatus
 }
 
 type snapshotEnrichment struct {
 	// When adding MetricsSnapshot fields, update
 	// TestMetricsSnapshotFieldsHaveCollectionClassifications.
-	hardware       HardwareInfo
-	cpuPCores      int
-	cpuECores      int
-	memoryCached   uint64
-	memoryPressure string
-	disks          []DiskStatus
-	hasDisks       bool
-	gpu            []GPUStatus
-	trashSize      uint64
-	trashApprox    bool
-	proxy          ProxyStatus
-	batteries      []BatteryStatus
-	thermal        ThermalStatus
-	sensors        []SensorReading
-	bluetooth      []BluetoothDevice
-	topProcesses   []ProcessInfo
-	processAlerts  []ProcessAlert
+	hardware         HardwareInfo
+	cpuPCores        int
+	cpuECores        int
+	memoryCached     uint64
+	memoryCompressed uint64
+	memoryPressure   string
+	pressurePercent  float64
+	swapFiles        int
+	swapFileBytes    uint64
+	swapGrowing      bool
+	swapInRate       float64
+	swapOutRate      float64
+	disks            []DiskStatus
+	hasDisks         bool
+	gpu              []GPUStatus
+	trashSize        uint64
+	trashApprox      bool
+	proxy            ProxyStatus
+	batteries        []BatteryStatus
+	thermal          ThermalStatus
+	sensors          []SensorReading
+	bluetooth        []BluetoothDevice
+	wifi             *WiFiStatus
+	topProcesses     []ProcessInfo
+	processAlerts    []ProcessAlert
+	watchedProcess   *WatchedProcess
+	processStates    *ProcessStateCounts
+	systemLimits     *SystemLimits
+	logErrors        *LogErrorRate
+	pendingUpdates   *PendingUpdates
+	publicIP         string
+	tcpConns         int
+	ping             *LatencyProbe
+	vpn              *VPNStatus
 }
 
 func NewCollector(options ProcessWatchOptions) *Collector {
@@ -287,11 +493,30 @@ func NewCollector(options ProcessWatchOptions) *Collector {
 		cachedNetIPs:   make(map[string]string),
 		processWatch:   options.SnapshotConfig(),
 		processWatcher: NewProcessWatcher(options),
+		healthWeights:  defaultHealthWeights,
+		noisePrefixes:  defaultNoiseInterfacePrefixes,
 	}
 	c.primeNetworkCounters(time.Now())
 	return c
 }
 
+// setTuning swaps in a profile's health weights and process CPU threshold.
+func (c *Collector) setTuning(weights healthWeights, cpuThreshold float64) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	c.healthWeights = weights
+	c.processWatch.CPUThreshold = cpuThreshold
+	if c.processWatcher != nil {
+		c.processWatcher.options.CPUThreshold = cpuThreshold
+	}
+}
+
+func (c *Collector) currentHealthWeights() healthWeights {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	return c.healthWeights
+}
+
 func collectHostInfo() *host.InfoStat {
 	hostInfo, _ := host.Info()
 	if hostInfo == nil {
@@ -351,17 +576,18 @@ func (c *Collector) collectFast(includeProcesses bool) (MetricsSnapshot, error)
 	var collected collectedMetrics
 
 	tasks := []func() error{
-		func() (err error) { collected.cpuStats, err = collectCPUFast(); return },
+		func() (err error) { collected.cpuStats, err = c.collectCPUResilient(false); return },
 		func() (err error) { collected.memStats, err = collectMemoryFast(); return },
-		func() (err error) { collected.diskStats, err = collectDisksFast(); return },
+		func() (err error) { collected.diskStats, err = collectDisksFast(c.diskLimit()); return },
 		func() (err error) { collected.diskIO = c.collectDiskIO(now); return nil },
 		func() (err error) { collected.netStats = c.collectNetwork(now); return nil },
 	}
 	if includeProcesses {
-		tasks = append(tasks, func() error { return collectProcessesInto(&collected) })
+		tasks = append(tasks, func() error { return c.collectProcessesInto(&collected) })
 	}
 
 	mergeErr := collectConcurrently(tasks...)
+	collected.talker = c.collectNetworkTalker(now, collected.netStats)
 
 	snapshot := c.snapshotFromMetrics(now, hostInfo, collected, false)
 	c.applyEnrichment(&snapshot, collected.hasProcesses)
@@ -382,25 +608,39 @@ func (c *Collector) collectFull() (MetricsSnapshot, error) {
 	// 100ms, so measuring while our own collection burst runs inflates the
 	// reading with Mole's own load (#1237).
 	var cpuErr error
-	collected.cpuStats, cpuErr = collectCPU()
+	collected.cpuStats, cpuErr = c.collectCPUResilient(true)
 
 	// Launch independent collection tasks.
 	tasks := []func() error{
 		func() error { return cpuErr },
-		func() (err error) { collected.memStats, err = collectMemory(); return },
-		func() (err error) { collected.diskStats, err = collectDisks(); return },
+		func() (err error) {
+			collected.memStats, err = collectMemory()
+			// Swap files and paging counters only touch memStats and their
+			// own Collector state, so they run with the memory probe.
+			c.annotateSwapFiles(now, &collected.memStats)
+			c.annotateSwapActivity(now, &collected.memStats)
+			return
+		},
+		func() (err error) { collected.diskStats, err = collectDisks(c.diskLimit()); return },
 		func() (err error) { collected.trashSize, collected.trashApprox = collectTrashSize(); return nil },
 		func() (err error) { collected.diskIO = c.collectDiskIO(now); return nil },
 		func() (err error) { collected.netStats = c.collectNetwork(now); return nil },
 		func() (err error) { collected.proxyStats = collectProxy(); return nil },
 		func() (err error) { collected.batteryStats, _ = collectBatteries(); return nil },
-		func() (err error) { collected.thermalStats = collectThermal(); return nil },
-		// Sensors disabled - CPU temp already shown in CPU card
-		// collect(func() (err error) { sensorStats, _ = collectSensors(); return nil })
+		func() (err error) {
+			collected.thermalStats = collectThermal()
+			pm := c.collectPowermetricsThermal(now)
+			collected.thermalStats.setFanSpeeds(pm.fans)
+			collected.thermalStats.CPUTemp = macCPUTemperature(pm)
+			c.annotateThrottling(&collected.thermalStats, pm.pressure)
+			return nil
+		},
+		// Sensors are Linux-only; macOS CPU temp is already shown in the CPU card.
+		func() (err error) { collected.sensorStats, _ = collectSensors(); return nil },
 		func() (err error) { collected.gpuStats, err = c.collectGPU(now); return },
 		func() (err error) {
 			// Bluetooth is slow; cache for 30s.
-			if now.Sub(c.lastBTAt) > 30*time.Second || len(c.lastBT) == 0 {
+			if now.Sub(c.lastBTAt) > bluetoothCacheTTL || len(c.lastBT) == 0 {
 				collected.btStats = c.collectBluetooth(now)
 				c.lastBT = collected.btStats
 				c.lastBTAt = now
@@ -409,9 +649,25 @@ func (c *Collector) collectFull() (MetricsSnapshot, error) {
 			}
 			return nil
 		},
-		func() error { return collectProcessesInto(&collected) },
+		func() (err error) { collected.wifi = c.collectWiFi(now); return nil },
+		func() error { return c.collectProcessesInto(&collected) },
+		func() (err error) { collected.procStates, _ = collectProcessStatesFunc(); return nil },
+		func() (err error) { collected.limits = collectSystemLimitsFunc(); return nil },
+		func() (err error) { collected.logErrors = c.collectLogErrors(now); return nil },
+		func() (err error) { collected.updates = c.collectPendingUpdates(now); return nil },
+		func() (err error) { collected.publicIP = c.collectPublicIP(now); return nil },
+		func() (err error) { collected.tcpConns = c.collectTCPConnections(now); return nil },
+		func() (err error) { collected.ping = c.collectPing(now); return nil },
+		func() (err error) { collected.vpnService = connectedVPNService(); return nil },
 	}
 	mergeErr := collectConcurrently(tasks...)
+	collected.thermalStats.GPUTemp = gpuTemperature(collected.gpuStats, collected.sensorStats)
+	if collected.thermalStats.CPUTemp == 0 {
+		collected.thermalStats.CPUTemp = cpuTemperature(collected.sensorStats)
+	}
+	annotateBatteryPower(collected.batteryStats, collected.thermalStats)
+	collected.talker = c.collectNetworkTalker(now, collected.netStats)
+	collected.vpn = c.collectVPN(now, collected.vpnService)
 
 	snapshot := c.snapshotFromMetrics(now, hostInfo, collected, true)
 	if mergeErr == nil {
@@ -420,17 +676,24 @@ func (c *Collector) collectFull() (MetricsSnapshot, error) {
 	return snapshot, mergeErr
 }
 
-func collectProcessesInto(collected *collectedMetrics) error {
+func (c *Collector) collectProcessesInto(collected *collectedMetrics) error {
 	procs, err := collectProcessesFunc()
 	if err != nil {
 		return err
 	}
+	c.nameRules.Apply(procs)
 	collected.allProcs = procs
 	collected.hasProcesses = true
 	return nil
 }
 
 func (c *Collector) snapshotFromMetrics(now time.Time, hostInfo *host.InfoStat, collected collectedMetrics, refreshHardware bool) MetricsSnapshot {
+	// Under a cgroup quota or memory limit (containers, Kubernetes pods) the
+	// host's totals are misleading; apply the limits before the hardware
+	// summary and health score read them.
+	virt := c.virtualization()
+	c.applyCgroupLimits(now, &collected)
+
 	// Dependent tasks (post-collect).
 	// Cache hardware info as it's expensive and rarely changes.
 	if refreshHardware && (!c.hasStatic || now.Sub(c.lastHWAt) > 10*time.Minute) {
@@ -440,22 +703,31 @@ func (c *Collector) snapshotFromMetrics(now time.Time, hostInfo *host.InfoStat,
 	}
 	hwInfo := c.hardwareForSnapshot()
 
-	score, scoreMsg := calculateHealthScore(
+	score, scoreMsg, breakdown := scoreHealth(
+		c.currentHealthWeights(),
 		collected.cpuStats,
 		collected.memStats,
 		collected.diskStats,
 		collected.diskIO,
 		collected.thermalStats,
+		collected.gpuStats,
 		collected.batteryStats,
 		hostInfo.Uptime,
 	)
 	var topProcs []ProcessInfo
+	var watched *WatchedProcess
 	if collected.hasProcesses {
-		topProcs = topProcesses(collected.allProcs, 5)
+		ranksBefore := processRanksBefore
+		if c.sortByMem {
+			ranksBefore = processRanksByMemory
+		}
+		topProcs = topProcessesBy(collected.allProcs, max(c.topProcs, minTopProcesses), ranksBefore)
+		watched = watchProcesses(c.watchName, collected.allProcs)
 	}
 
 	var processAlerts []ProcessAlert
 	c.watchMu.Lock()
+	processWatch := c.processWatch
 	if c.processWatcher != nil {
 		if collected.hasProcesses {
 			processAlerts = c.processWatcher.Update(now, collected.allProcs)
@@ -465,36 +737,53 @@ func (c *Collector) snapshotFromMetrics(now time.Time, hostInfo *host.InfoStat,
 	}
 	c.watchMu.Unlock()
 
+	collected.cpuStats.PerCoreTemp = perCoreTemps(collected.sensorStats, len(collected.cpuStats.PerCore))
+
 	return MetricsSnapshot{
-		CollectedAt:    now,
-		Host:           hostInfo.Hostname,
-		Platform:       fmt.Sprintf("%s %s", hostInfo.Platform, hostInfo.PlatformVersion),
-		Uptime:         formatUptime(hostInfo.Uptime),
-		UptimeSeconds:  hostInfo.Uptime,
-		Procs:          hostInfo.Procs,
-		Hardware:       hwInfo,
-		HealthScore:    score,
-		HealthScoreMsg: scoreMsg,
-		CPU:            collected.cpuStats,
-		GPU:            collected.gpuStats,
-		Memory:         collected.memStats,
-		Disks:          collected.diskStats,
-		TrashSize:      collected.trashSize,
-		TrashApprox:    collected.trashApprox,
-		DiskIO:         collected.diskIO,
-		Network:        collected.netStats,
+		CollectedAt:     now,
+		Host:            hostInfo.Hostname,
+		Platform:        fmt.Sprintf("%s %s", hostInfo.Platform, hostInfo.PlatformVersion),
+		Uptime:          formatUptime(hostInfo.Uptime),
+		UptimeSeconds:   hostInfo.Uptime,
+		Procs:           hostInfo.Procs,
+		Hardware:        hwInfo,
+		HealthScore:     score,
+		HealthScoreMsg:  scoreMsg,
+		HealthBreakdown: breakdown,
+		CPU:             collected.cpuStats,
+		GPU:             collected.gpuStats,
+		Memory:          collected.memStats,
+		Disks:           collected.diskStats,
+		TrashSize:       collected.trashSize,
+		TrashApprox:     collected.trashApprox,
+		DiskIO:          collected.diskIO,
+		Network:         collected.netStats,
 		NetworkHistory: NetworkHistory{
 			RxHistory: c.rxHistoryBuf.Slice(),
 			TxHistory: c.txHistoryBuf.Slice(),
 		},
-		Proxy:         collected.proxyStats,
-		Batteries:     collected.batteryStats,
-		Thermal:       collected.thermalStats,
-		Sensors:       collected.sensorStats,
-		Bluetooth:     collected.btStats,
-		TopProcesses:  topProcs,
-		ProcessWatch:  c.processWatch,
-		ProcessAlerts: processAlerts,
+		Proxy:          collected.proxyStats,
+		Batteries:      collected.batteryStats,
+		Thermal:        collected.thermalStats,
+		Sensors:        collected.sensorStats,
+		Bluetooth:      collected.btStats,
+		WiFi:           collected.wifi,
+		TopProcesses:   topProcs,
+		ProcessWatch:   processWatch,
+		ProcessAlerts:  processAlerts,
+		WatchedProcess: watched,
+		ProcessStates:  collected.procStates,
+		SystemLimits:   collected.limits,
+		LogErrors:      collected.logErrors,
+		PendingUpdates: collected.updates,
+		PublicIP:       collected.publicIP,
+		TCPConnections: collected.tcpConns,
+		Ping:           collected.ping,
+		VPN:            collected.vpn,
+		NetworkTalker:  collected.talker,
+		CollectErrors:  c.collectErrors(),
+		Virtualized:    virt != "",
+		Virtualization: virt,
 	}
 }
 
@@ -507,23 +796,40 @@ func (c *Collector) hardwareForSnapshot() HardwareInfo {
 
 func (c *Collector) cacheEnrichment(snapshot MetricsSnapshot) {
 	c.enrichment = snapshotEnrichment{
-		hardware:       snapshot.Hardware,
-		cpuPCores:      snapshot.CPU.PCoreCount,
-		cpuECores:      snapshot.CPU.ECoreCount,
-		memoryCached:   snapshot.Memory.Cached,
-		memoryPressure: snapshot.Memory.Pressure,
-		disks:          slices.Clone(snapshot.Disks),
-		hasDisks:       true,
-		gpu:            slices.Clone(snapshot.GPU),
-		trashSize:      snapshot.TrashSize,
-		trashApprox:    snapshot.TrashApprox,
-		proxy:          snapshot.Proxy,
-		batteries:      slices.Clone(snapshot.Batteries),
-		thermal:        snapshot.Thermal,
-		sensors:        slices.Clone(snapshot.Sensors),
-		bluetooth:      slices.Clone(snapshot.Bluetooth),
-		topProcesses:   slices.Clone(snapshot.TopProcesses),
-		processAlerts:  slices.Clone(snapshot.ProcessAlerts),
+		hardware:         snapshot.Hardware,
+		cpuPCores:        snapshot.CPU.PCoreCount,
+		cpuECores:        snapshot.CPU.ECoreCount,
+		memoryCached:     snapshot.Memory.Cached,
+		memoryCompressed: snapshot.Memory.Compressed,
+		memoryPressure:   snapshot.Memory.Pressure,
+		pressurePercent:  snapshot.Memory.PressurePercent,
+		swapFiles:        snapshot.Memory.SwapFiles,
+		swapFileBytes:    snapshot.Memory.SwapFileBytes,
+		swapGrowing:      snapshot.Memory.SwapGrowing,
+		swapInRate:       snapshot.Memory.SwapInRate,
+		swapOutRate:      snapshot.Memory.SwapOutRate,
+		disks:            slices.Clone(snapshot.Disks),
+		hasDisks:         true,
+		gpu:              slices.Clone(snapshot.GPU),
+		trashSize:        snapshot.TrashSize,
+		trashApprox:      snapshot.TrashApprox,
+		proxy:            snapshot.Proxy,
+		batteries:        slices.Clone(snapshot.Batteries),
+		thermal:          snapshot.Thermal,
+		sensors:          slices.Clone(snapshot.Sensors),
+		bluetooth:        slices.Clone(snapshot.Bluetooth),
+		wifi:             snapshot.WiFi,
+		topProcesses:     slices.Clone(snapshot.TopProcesses),
+		processAlerts:    slices.Clone(snapshot.ProcessAlerts),
+		watchedProcess:   snapshot.WatchedProcess,
+		processStates:    snapshot.ProcessStates,
+		systemLimits:     snapshot.SystemLimits,
+		logErrors:        snapshot.LogErrors,
+		pendingUpdates:   snapshot.PendingUpdates,
+		publicIP:         snapshot.PublicIP,
+		tcpConns:         snapshot.TCPConnections,
+		ping:             snapshot.Ping,
+		vpn:              snapshot.VPN,
 	}
 	c.hasEnrichment = true
 }
@@ -533,12 +839,14 @@ func (c *Collector) applyEnrichment(snapshot *MetricsSnapshot, preserveLiveProce
 		return
 	}
 	c.enrichment.apply(snapshot, preserveLiveProcesses)
-	snapshot.HealthScore, snapshot.HealthScoreMsg = calculateHealthScore(
+	snapshot.HealthScore, snapshot.HealthScoreMsg, snapshot.HealthBreakdown = scoreHealth(
+		c.currentHealthWeights(),
 		snapshot.CPU,
 		snapshot.Memory,
 		snapshot.Disks,
 		snapshot.DiskIO,
 		snapshot.Thermal,
+		snapshot.GPU,
 		snapshot.Batteries,
 		snapshot.UptimeSeconds,
 	)
@@ -549,7 +857,14 @@ func (e snapshotEnrichment) apply(snapshot *MetricsSnapshot, preserveLiveProcess
 	snapshot.CPU.PCoreCount = e.cpuPCores
 	snapshot.CPU.ECoreCount = e.cpuECores
 	snapshot.Memory.Cached = e.memoryCached
+	snapshot.Memory.Compressed = e.memoryCompressed
 	snapshot.Memory.Pressure = e.memoryPressure
+	snapshot.Memory.PressurePercent = e.pressurePercent
+	snapshot.Memory.SwapFiles = e.swapFiles
+	snapshot.Memory.SwapFileBytes = e.swapFileBytes
+	snapshot.Memory.SwapGrowing = e.swapGrowing
+	snapshot.Memory.SwapInRate = e.swapInRate
+	snapshot.Memory.SwapOutRate = e.swapOutRate
 	// Disk capacity is slow-changing and the corrections (APFS purgeable,
 	// diskutil, Finder) are expensive, so the fast path collects raw statfs
 	// values and we overwrite them with the last full-refresh corrected
@@ -565,10 +880,21 @@ func (e snapshotEnrichment) apply(snapshot *MetricsSnapshot, preserveLiveProcess
 	snapshot.Batteries = slices.Clone(e.batteries)
 	snapshot.Thermal = e.thermal
 	snapshot.Sensors = slices.Clone(e.sensors)
+	snapshot.CPU.PerCoreTemp = perCoreTemps(snapshot.Sensors, len(snapshot.CPU.PerCore))
 	snapshot.Bluetooth = slices.Clone(e.bluetooth)
+	snapshot.WiFi = e.wifi
+	snapshot.ProcessStates = e.processStates
+	snapshot.SystemLimits = e.systemLimits
+	snapshot.LogErrors = e.logErrors
+	snapshot.PendingUpdates = e.pendingUpdates
+	snapshot.PublicIP = e.publicIP
+	snapshot.TCPConnections = e.tcpConns
+	snapshot.Ping = e.ping
+	snapshot.VPN = e.vpn
 	if !preserveLiveProcesses {
 		snapshot.TopProcesses = slices.Clone(e.topProcesses)
 		snapshot.ProcessAlerts = slices.Clone(e.processAlerts)
+		snapshot.WatchedProcess = e.watchedProcess
 	}
 }
 
@@ -11,20 +11,9 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
-var (
-	// Cache for heavy system_profiler output.
-	powerCacheMu    sync.Mutex
-	lastPowerAt     time.Time
-	lastPowerJSONAt time.Time
-	cachedPower     string
-	cachedPowerJSON string
-	powerCacheTTL   = 30 * time.Second
-)
-
 func collectBatteries() (batts []BatteryStatus, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -60,8 +49,10 @@ func collectBatteries() (batts []BatteryStatus, err error) {
 			status = "Unknown"
 		}
 		batts = append(batts, BatteryStatus{
-			Percent: percent,
-			Status:  status,
+			Name:       filepath.Base(filepath.Dir(capFile)),
+			Percent:    percent,
+			Status:     status,
+			PowerWatts: readLinuxBatteryPower(filepath.Dir(capFile), status),
 		})
 	}
 	if len(batts) > 0 {
@@ -71,6 +62,40 @@ func collectBatteries() (batts []BatteryStatus, err error) {
 	return nil, errors.New("no battery data found")
 }
 
+// readLinuxBatteryPower reads power_now (µW), or current_now (µA) times
+// voltage_now (µV) on drivers without it. Drivers disagree on the sign, so
+// it comes from the status instead: negative while discharging.
+func readLinuxBatteryPower(dir, status string) float64 {
+	var watts float64
+	if uw, ok := readSysfsNumber(filepath.Join(dir, "power_now")); ok {
+		watts = uw / 1e6
+	} else {
+		ua, okA := readSysfsNumber(filepath.Join(dir, "current_now"))
+		uv, okV := readSysfsNumber(filepath.Join(dir, "voltage_now"))
+		if !okA || !okV {
+			return 0
+		}
+		watts = ua * uv / 1e12
+	}
+	watts = math.Abs(watts)
+	if strings.EqualFold(status, "Discharging") {
+		return -watts
+	}
+	return watts
+}
+
+// annotateBatteryPower fills the macOS battery wattage from the
+// AppleSmartBattery reading collectThermal already made. That reading covers
+// the internal battery only, so it is skipped when pmset lists several.
+func annotateBatteryPower(batts []BatteryStatus, thermal ThermalStatus) {
+	if len(batts) != 1 || batts[0].PowerWatts != 0 {
+		return
+	}
+	// ThermalStatus keeps discharge positive; BatteryStatus uses the
+	// charge-positive convention.
+	batts[0].PowerWatts = -thermal.BatteryPower
+}
+
 func parsePMSet(raw string, health string, cycles int, capacity int) []BatteryStatus {
 	var out []BatteryStatus
 	var timeLeft string
@@ -91,10 +116,15 @@ func parsePMSet(raw string, health string, cycles int, capacity int) []BatterySt
 		}
 		fields := strings.Fields(line)
 		var (
+			name    string
 			percent float64
 			found   bool
 			status  = "Unknown"
 		)
+		// Source lines start with " -InternalBattery-0 (id=...)".
+		if len(fields) > 0 && strings.HasPrefix(fields[0], "-") {
+			name = strings.TrimPrefix(fields[0], "-")
+		}
 		for i, f := range fields {
 			if strings.Contains(f, "%") {
 				value := strings.TrimSuffix(strings.TrimSuffix(f, ";"), "%")
@@ -113,6 +143,7 @@ func parsePMSet(raw string, health string, cycles int, capacity int) []BatterySt
 		}
 
 		out = append(out, BatteryStatus{
+			Name:       name,
 			Percent:    percent,
 			Status:     status,
 			TimeLeft:   timeLeft,
@@ -291,51 +322,11 @@ func batteryHealthPercent(design, nominal, rawMax int) int {
 }
 
 func getSystemPowerJSONOutput() string {
-	if runtime.GOOS != "darwin" {
-		return ""
-	}
-
-	powerCacheMu.Lock()
-	defer powerCacheMu.Unlock()
-
-	now := time.Now()
-	if cachedPowerJSON != "" && now.Sub(lastPowerJSONAt) < powerCacheTTL {
-		return cachedPowerJSON
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	out, err := runCmd(ctx, "system_profiler", "SPPowerDataType", "-json")
-	if err == nil {
-		cachedPowerJSON = out
-		lastPowerJSONAt = now
-	}
-	return cachedPowerJSON
+	return readSystemProfiler("SPPowerDataType", "-json")
 }
 
 func getSystemPowerOutput() string {
-	if runtime.GOOS != "darwin" {
-		return ""
-	}
-
-	powerCacheMu.Lock()
-	defer powerCacheMu.Unlock()
-
-	now := time.Now()
-	if cachedPower != "" && now.Sub(lastPowerAt) < powerCacheTTL {
-		return cachedPower
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	out, err := runCmd(ctx, "system_profiler", "SPPowerDataType")
-	if err == nil {
-		cachedPower = out
-		lastPowerAt = now
-	}
-	return cachedPower
+	return readSystemProfiler("SPPowerDataType")
 }
 
 func collectThermal() ThermalStatus {
@@ -345,21 +336,6 @@ func collectThermal() ThermalStatus {
 
 	var thermal ThermalStatus
 
-	// Fan info from cached system_profiler.
-	out := getSystemPowerOutput()
-	if out != "" {
-		for line := range strings.Lines(out) {
-			lower := strings.ToLower(line)
-			if strings.Contains(lower, "fan") && strings.Contains(lower, "speed") {
-				if _, after, found := strings.Cut(line, ":"); found {
-					numStr := strings.TrimSpace(after)
-					numStr, _, _ = strings.Cut(numStr, " ")
-					thermal.FanSpeed, _ = strconv.Atoi(numStr)
-				}
-			}
-		}
-	}
-
 	// Power metrics from ioreg (fast, real-time).
 	ctxPower, cancelPower := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancelPower()
@@ -373,6 +349,7 @@ func collectThermal() ThermalStatus {
 
 	// Do not synthesize CPU temperature from battery sensors or cpu_thermal_level.
 	// Those values are not CPU-package temperatures and produce false overheating data.
+	// Intel Macs get a real reading from the SMC; see macCPUTemperature.
 	return thermal
 }
 
@@ -2,6 +2,9 @@ package main
 
 import (
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -193,3 +196,45 @@ func TestParseAppleSmartBatteryThermalIgnoresRawAdapterWatts(t *testing.T) {
 		t.Fatalf("expected normalized adapter power 96W, got %v", thermal.AdapterPower)
 	}
 }
+
+func TestReadLinuxBatteryPowerSignsByStatus(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, value string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("current_now", "1500000")
+	write("voltage_now", "12000000")
+	if got := readLinuxBatteryPower(dir, "Charging"); math.Abs(got-18) > 0.001 {
+		t.Fatalf("current x voltage = %v W, want 18", got)
+	}
+
+	write("power_now", "12300000")
+	if got := readLinuxBatteryPower(dir, "Discharging"); math.Abs(got+12.3) > 0.001 {
+		t.Fatalf("power_now while discharging = %v W, want -12.3", got)
+	}
+	if got := readLinuxBatteryPower(t.TempDir(), "Discharging"); got != 0 {
+		t.Fatalf("missing counters = %v W, want 0", got)
+	}
+}
+
+func TestAnnotateBatteryPowerUsesSmartBatteryReading(t *testing.T) {
+	batts := []BatteryStatus{{Status: "discharging"}}
+	annotateBatteryPower(batts, ThermalStatus{BatteryPower: 9.5})
+	if batts[0].PowerWatts != -9.5 {
+		t.Fatalf("PowerWatts = %v, want -9.5", batts[0].PowerWatts)
+	}
+	if line := stripANSI(renderBatteryCard(batts, ThermalStatus{}).lines[1]); !strings.HasPrefix(line, "Discharging · −9.5 W") {
+		t.Fatalf("summary line = %q", line)
+	}
+
+	two := []BatteryStatus{{}, {}}
+	annotateBatteryPower(two, ThermalStatus{BatteryPower: 9.5})
+	if two[0].PowerWatts != 0 {
+		t.Fatal("internal battery reading applied when several batteries are present")
+	}
+	if got := formatBatteryPower(8); got != "+8.0 W" {
+		t.Fatalf("charging power = %q", got)
+	}
+}
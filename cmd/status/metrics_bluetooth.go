@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"errors"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -67,11 +69,26 @@ func readBluetoothCTLDevices() ([]BluetoothDevice, error) {
 	return parseBluetoothctl(out), nil
 }
 
+// bluetoothctlBatteryRe matches "Battery Percentage: 0x50 (80)".
+var bluetoothctlBatteryRe = regexp.MustCompile(`\((\d+)\)`)
+
+// setBatteryLevel records a reported level, keeping the lowest when a device
+// reports several (AirPods: left, right and case).
+func (d *BluetoothDevice) setBatteryLevel(percent int) {
+	if percent <= 0 || percent > 100 {
+		return
+	}
+	if d.BatteryPercent == 0 || percent < d.BatteryPercent {
+		d.BatteryPercent = percent
+		d.Battery = strconv.Itoa(percent) + "%"
+	}
+}
+
 func parseSPBluetooth(raw string) []BluetoothDevice {
 	var devices []BluetoothDevice
 	var currentName string
 	var connected bool
-	var battery string
+	var battery BluetoothDevice
 
 	for line := range strings.Lines(raw) {
 		trim := strings.TrimSpace(line)
@@ -82,27 +99,29 @@ func parseSPBluetooth(raw string) []BluetoothDevice {
 			// Reset at top-level sections.
 			currentName = ""
 			connected = false
-			battery = ""
+			battery = BluetoothDevice{}
 			continue
 		}
 		if strings.HasPrefix(line, "        ") && strings.HasSuffix(trim, ":") {
 			if currentName != "" {
-				devices = append(devices, BluetoothDevice{Name: currentName, Connected: connected, Battery: battery})
+				devices = append(devices, BluetoothDevice{Name: currentName, Connected: connected, Battery: battery.Battery, BatteryPercent: battery.BatteryPercent})
 			}
 			currentName = strings.TrimSuffix(trim, ":")
 			connected = false
-			battery = ""
+			battery = BluetoothDevice{}
 			continue
 		}
 		if strings.Contains(trim, "Connected:") {
 			connected = strings.Contains(trim, "Yes")
 		}
-		if strings.Contains(trim, "Battery Level:") {
-			battery = strings.TrimSpace(strings.TrimPrefix(trim, "Battery Level:"))
+		// "Battery Level: 80%", or "Left Battery Level: 80%" and friends.
+		if _, value, ok := strings.Cut(trim, "Battery Level:"); ok {
+			percent, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+			battery.setBatteryLevel(percent)
 		}
 	}
 	if currentName != "" {
-		devices = append(devices, BluetoothDevice{Name: currentName, Connected: connected, Battery: battery})
+		devices = append(devices, BluetoothDevice{Name: currentName, Connected: connected, Battery: battery.Battery, BatteryPercent: battery.BatteryPercent})
 	}
 	if len(devices) == 0 {
 		return []BluetoothDevice{{Name: "No devices", Connected: false}}
@@ -127,6 +146,12 @@ func parseBluetoothctl(raw string) []BluetoothDevice {
 		if strings.HasPrefix(trim, "Connected:") {
 			current.Connected = strings.Contains(trim, "yes")
 		}
+		if strings.HasPrefix(trim, "Battery Percentage:") {
+			if m := bluetoothctlBatteryRe.FindStringSubmatch(trim); m != nil {
+				percent, _ := strconv.Atoi(m[1])
+				current.setBatteryLevel(percent)
+			}
+		}
 	}
 	if current.Name != "" {
 		devices = append(devices, current)
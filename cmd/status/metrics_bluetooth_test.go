@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestParseSPBluetoothNormalizesBatteryLevels(t *testing.T) {
+	raw := `Bluetooth:
+
+      Bluetooth Controller:
+          Address: AA:BB:CC:DD:EE:FF
+      Connected:
+          AirPods Pro:
+              Address: 11:22:33:44:55:66
+              Connected: Yes
+              Left Battery Level: 80%
+              Right Battery Level: 12%
+              Case Battery Level: 55%
+          Magic Keyboard:
+              Address: 11:22:33:44:55:77
+              Connected: Yes
+              Battery Level: 64%
+          Old Speaker:
+              Connected: No
+`
+	devices := parseSPBluetooth(raw)
+	want := map[string]int{"AirPods Pro": 12, "Magic Keyboard": 64, "Old Speaker": 0}
+	if len(devices) != len(want) {
+		t.Fatalf("parseSPBluetooth() = %+v", devices)
+	}
+	for _, d := range devices {
+		if d.BatteryPercent != want[d.Name] {
+			t.Errorf("%s battery = %d, want %d", d.Name, d.BatteryPercent, want[d.Name])
+		}
+	}
+	if devices[0].Battery != "12%" || !devices[0].Connected || devices[2].Connected {
+		t.Fatalf("unexpected devices %+v", devices)
+	}
+}
+
+func TestParseBluetoothctlBatteryPercentage(t *testing.T) {
+	raw := `Device 11:22:33:44:55:66 (public)
+	Name: MX Master 3
+	Connected: yes
+	Battery Percentage: 0x50 (80)
+`
+	devices := parseBluetoothctl(raw)
+	if len(devices) != 1 || devices[0].Name != "MX Master 3" || devices[0].BatteryPercent != 80 || devices[0].Battery != "80%" {
+		t.Fatalf("parseBluetoothctl() = %+v", devices)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+const (
+	tcpConnsTTL     = 15 * time.Second // Enumerating sockets walks every process (lsof on macOS)
+	tcpConnsTimeout = 2 * time.Second
+)
+
+var connectionsFunc = net.ConnectionsWithContext
+
+// collectTCPConnections counts ESTABLISHED TCP connections (IPv4 and IPv6),
+// at most every tcpConnsTTL. The last count is kept when a query fails or
+// times out.
+func (c *Collector) collectTCPConnections(now time.Time) int {
+	if !c.lastTCPConnsAt.IsZero() && now.Sub(c.lastTCPConnsAt) < tcpConnsTTL {
+		return c.tcpConns
+	}
+	c.lastTCPConnsAt = now
+	ctx, cancel := context.WithTimeout(context.Background(), tcpConnsTimeout)
+	defer cancel()
+	conns, err := connectionsFunc(ctx, "tcp")
+	if err != nil {
+		return c.tcpConns
+	}
+	c.tcpConns = countEstablished(conns)
+	return c.tcpConns
+}
+
+func countEstablished(conns []net.ConnectionStat) int {
+	n := 0
+	for _, conn := range conns {
+		if conn.Status == "ESTABLISHED" {
+			n++
+		}
+	}
+	return n
+}
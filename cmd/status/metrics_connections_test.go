@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gopsutilnet "github.com/shirou/gopsutil/v4/net"
+)
+
+func TestCollectTCPConnectionsCountsEstablishedAndThrottles(t *testing.T) {
+	calls := 0
+	fail := false
+	orig := connectionsFunc
+	connectionsFunc = func(_ context.Context, kind string) ([]gopsutilnet.ConnectionStat, error) {
+		calls++
+		if kind != "tcp" {
+			t.Fatalf("kind = %q, want tcp", kind)
+		}
+		if fail {
+			return nil, errors.New("lsof timed out")
+		}
+		return []gopsutilnet.ConnectionStat{
+			{Status: "ESTABLISHED"}, {Status: "LISTEN"}, {Status: "ESTABLISHED"}, {Status: "TIME_WAIT"},
+		}, nil
+	}
+	defer func() { connectionsFunc = orig }()
+
+	c := &Collector{}
+	now := time.Now()
+	if got := c.collectTCPConnections(now); got != 2 {
+		t.Fatalf("established = %d, want 2", got)
+	}
+	c.collectTCPConnections(now.Add(time.Second))
+	if calls != 1 {
+		t.Fatalf("query ran %d times within the TTL", calls)
+	}
+	fail = true
+	if got := c.collectTCPConnections(now.Add(tcpConnsTTL)); got != 2 || calls != 2 {
+		t.Fatalf("failed query = %d after %d calls, want the last count", got, calls)
+	}
+
+	card := renderNetworkExtras(cardData{}, MetricsSnapshot{TCPConnections: 42}, 60)
+	if got := strings.Join(card.lines, ""); got != "Conns  42" {
+		t.Fatalf("connections line = %q", got)
+	}
+}
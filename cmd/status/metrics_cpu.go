@@ -17,15 +17,9 @@ const (
 	cpuSampleInterval = 100 * time.Millisecond
 )
 
-func collectCPU() (CPUStatus, error) {
-	return collectCPUWithOptions(true)
-}
-
-func collectCPUFast() (CPUStatus, error) {
-	return collectCPUWithOptions(false)
-}
+var cpuPercentFunc = cpu.Percent
 
-func collectCPUWithOptions(includeSlowFallbacks bool) (CPUStatus, error) {
+func collectCPUWithOptions(includeSlowFallbacks bool, procStat *procStatSampler) (CPUStatus, error) {
 	counts, countsErr := cpu.Counts(false)
 	if countsErr != nil || counts == 0 {
 		counts = runtime.NumCPU()
@@ -56,15 +50,24 @@ func collectCPUWithOptions(includeSlowFallbacks bool) (CPUStatus, error) {
 		sampled = err == nil && len(percents) > 0
 	}
 	if !sampled {
-		percents, err = cpu.Percent(0, true)
+		percents, err = cpuPercentFunc(0, true)
+	}
+	if !sampled && (err != nil || len(percents) == 0) && procStat != nil {
+		// Constrained Linux environments can break cpu.Percent; read the
+		// jiffy counters directly before falling back to estimates.
+		if usage, perCore, ok := procStat.sample(); ok && len(perCore) > 0 {
+			percents, totalPercent, sampled = perCore, usage, true
+		}
 	}
 	perCoreEstimated := false
+	var sampleErr error
 	if !sampled && (err != nil || len(percents) == 0) {
 		if !includeSlowFallbacks {
 			// Fast path: skip the expensive secondary sampling and just
 			// estimate zeroed per-core usage. The next full refresh corrects it.
 			percents = make([]float64, logical)
 			perCoreEstimated = true
+			sampleErr = errCPUSampleUnavailable
 		} else {
 			fallbackUsage, fallbackPerCore, fallbackErr := fallbackCPUUtilization(logical)
 			if fallbackErr != nil {
@@ -103,6 +106,7 @@ func collectCPUWithOptions(includeSlowFallbacks bool) (CPUStatus, error) {
 
 	return CPUStatus{
 		Usage:            totalPercent,
+		BootUsage:        bootCPUUsage(),
 		PerCore:          percents,
 		PerCoreEstimated: perCoreEstimated,
 		Load1:            loadAvg.Load1,
@@ -112,7 +116,7 @@ func collectCPUWithOptions(includeSlowFallbacks bool) (CPUStatus, error) {
 		LogicalCPU:       logical,
 		PCoreCount:       pCores,
 		ECoreCount:       eCores,
-	}, nil
+	}, sampleErr
 }
 
 func isZeroLoad(avg load.AvgStat) bool {
@@ -289,7 +293,7 @@ func fallbackCPUUtilization(logical int) (float64, []float64, error) {
 }
 
 func warmUpCPU() {
-	cpu.Percent(0, true) //nolint:errcheck
+	cpuPercentFunc(0, true) //nolint:errcheck
 }
 
 // sampleCPUPercents measures per-core and total CPU usage over one wall-clock
@@ -360,6 +364,25 @@ func perCoreUsageFromTimes(before, after []cpu.TimesStat, elapsed float64) ([]fl
 	return percents, total, nil
 }
 
+// bootCPUUsage returns the average utilization since boot from the cumulative
+// aggregate tick counters.
+func bootCPUUsage() float64 {
+	times, err := cpu.Times(false)
+	if err != nil || len(times) == 0 {
+		return 0
+	}
+	return cpuUsageFromTotals(times[0])
+}
+
+func cpuUsageFromTotals(t cpu.TimesStat) float64 {
+	busy := cpuBusyTime(t)
+	total := busy + t.Idle + t.Iowait
+	if total <= 0 {
+		return 0
+	}
+	return min(max(busy/total*100, 0), 100)
+}
+
 func cpuBusyTime(t cpu.TimesStat) float64 {
 	return t.User + t.System + t.Nice + t.Irq + t.Softirq + t.Steal
 }
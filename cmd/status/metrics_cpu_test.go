@@ -109,3 +109,13 @@ func TestPerCoreUsageClampsAndErrors(t *testing.T) {
 		t.Fatalf("negative busy delta should clamp to 0, got %.2f", percents[0])
 	}
 }
+
+func TestCPUUsageFromTotals(t *testing.T) {
+	got := cpuUsageFromTotals(cpu.TimesStat{User: 20, System: 5, Idle: 70, Iowait: 5})
+	if !almostEqual(got, 25) {
+		t.Fatalf("cpuUsageFromTotals() = %v, want 25", got)
+	}
+	if got := cpuUsageFromTotals(cpu.TimesStat{}); got != 0 {
+		t.Fatalf("cpuUsageFromTotals(zero) = %v, want 0", got)
+	}
+}
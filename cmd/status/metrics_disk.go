@@ -44,20 +44,36 @@ var skipDiskFSTypes = map[string]bool{
 	"webdav":  true,
 }
 
+// maxShownDisks is how many volumes the disk card keeps unless --all-disks.
+const maxShownDisks = 3
+
 var (
 	diskPartitionsFunc = disk.Partitions
 	diskUsageFunc      = disk.Usage
+	diskIOCountersFunc = disk.IOCounters
+
+	// sysBlockRoot is the Linux block device directory, overridable in tests.
+	sysBlockRoot = "/sys/block"
 )
 
-func collectDisks() ([]DiskStatus, error) {
-	return collectDisksWithCorrections(true)
+func (c *Collector) diskLimit() int {
+	if c.allDisks {
+		return 0
+	}
+	return maxShownDisks
+}
+
+// collectDisks returns up to limit volumes, internal disks first and then
+// by size; a limit of 0 keeps them all.
+func collectDisks(limit int) ([]DiskStatus, error) {
+	return collectDisksWithCorrections(true, limit)
 }
 
-func collectDisksFast() ([]DiskStatus, error) {
-	return collectDisksWithCorrections(false)
+func collectDisksFast(limit int) ([]DiskStatus, error) {
+	return collectDisksWithCorrections(false, limit)
 }
 
-func collectDisksWithCorrections(useCorrections bool) ([]DiskStatus, error) {
+func collectDisksWithCorrections(useCorrections bool, limit int) ([]DiskStatus, error) {
 	partitions, err := diskPartitionsFunc(false)
 	if err != nil {
 		return nil, err
@@ -91,8 +107,11 @@ func collectDisksWithCorrections(useCorrections bool) ([]DiskStatus, error) {
 		if total < 1<<30 {
 			continue
 		}
-		// Use size-based dedupe key for shared pools.
-		volKey := fmt.Sprintf("%s:%d", part.Fstype, total)
+		// Volumes in a shared pool (APFS containers, btrfs subvolumes) report
+		// the pool's size and free space, so equal size alone is not enough:
+		// two distinct disks of the same capacity almost never have the same
+		// free bytes.
+		volKey := fmt.Sprintf("%s:%d:%d", part.Fstype, total, usage.Free)
 		if seenVolume[volKey] {
 			continue
 		}
@@ -103,22 +122,19 @@ func collectDisksWithCorrections(useCorrections bool) ([]DiskStatus, error) {
 		}
 
 		disks = append(disks, DiskStatus{
-			Mount:       part.Mountpoint,
-			Device:      part.Device,
-			Used:        used,
-			Total:       total,
-			UsedPercent: usedPercent,
-			Fstype:      part.Fstype,
-			External:    !useCorrections && strings.HasPrefix(part.Mountpoint, "/Volumes/"),
+			Mount:             part.Mountpoint,
+			Device:            part.Device,
+			Used:              used,
+			Total:             total,
+			UsedPercent:       usedPercent,
+			InodesUsedPercent: usage.InodesUsedPercent,
+			Fstype:            part.Fstype,
+			External:          !useCorrections && strings.HasPrefix(part.Mountpoint, "/Volumes/"),
 		})
 		seenDevice[baseDevice] = true
 		seenVolume[volKey] = true
 	}
 
-	if useCorrections {
-		annotateDiskTypes(disks)
-	}
-
 	sort.Slice(disks, func(i, j int) bool {
 		// First, prefer internal disks over external
 		if disks[i].External != disks[j].External {
@@ -128,8 +144,14 @@ func collectDisksWithCorrections(useCorrections bool) ([]DiskStatus, error) {
 		return disks[i].Total > disks[j].Total
 	})
 
-	if len(disks) > 3 {
-		disks = disks[:3]
+	if limit > 0 && len(disks) > limit {
+		disks = disks[:limit]
+	}
+
+	if useCorrections {
+		annotateDiskTypes(disks)
+		annotateDiskMedia(disks)
+		annotateSmartStatus(disks, time.Now())
 	}
 
 	return disks, nil
@@ -217,6 +239,32 @@ func annotateDiskTypes(disks []DiskStatus) {
 	}
 }
 
+// annotateDiskMedia sets Rotational from the block device's
+// queue/rotational flag on Linux. Internal storage on Apple Silicon Macs is
+// always flash; other Macs and disks behind device-mapper stay unknown.
+func annotateDiskMedia(disks []DiskStatus) {
+	for i := range disks {
+		switch runtime.GOOS {
+		case "linux":
+			device := physicalDevice(disks[i].Device)
+			if device == "" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(sysBlockRoot, strings.TrimPrefix(device, "/dev/"), "queue", "rotational"))
+			if err != nil {
+				continue
+			}
+			rotational := strings.TrimSpace(string(data)) == "1"
+			disks[i].Rotational = &rotational
+		case "darwin":
+			if runtime.GOARCH == "arm64" && !disks[i].External {
+				rotational := false
+				disks[i].Rotational = &rotational
+			}
+		}
+	}
+}
+
 func baseDeviceName(device string) string {
 	device = strings.TrimPrefix(device, "/dev/")
 	if !strings.HasPrefix(device, "disk") {
@@ -420,9 +468,18 @@ func uint64AbsDiff(a, b uint64) uint64 {
 }
 
 func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
-	counters, err := disk.IOCounters()
-	if err != nil || len(counters) == 0 {
-		return DiskIOStatus{}
+	status, err := c.sampleDiskIO(now)
+	status, _ = c.diskIOGood.resolve(status, err)
+	return status
+}
+
+func (c *Collector) sampleDiskIO(now time.Time) (DiskIOStatus, error) {
+	counters, err := diskIOCountersFunc()
+	if err != nil {
+		return DiskIOStatus{}, err
+	}
+	if len(counters) == 0 {
+		return DiskIOStatus{}, errors.New("no disk IO counters")
 	}
 
 	var total disk.IOCountersStat
@@ -431,10 +488,13 @@ func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
 		total.WriteBytes += v.WriteBytes
 	}
 
+	sample := counterSample{at: now, in: total.ReadBytes, out: total.WriteBytes}
 	if c.lastDiskAt.IsZero() {
 		c.prevDiskIO = total
 		c.lastDiskAt = now
-		return DiskIOStatus{}
+		c.windowedDiskRate(sample)
+		c.deviceIORates(counters, 1)
+		return DiskIOStatus{}, nil
 	}
 
 	elapsed := now.Sub(c.lastDiskAt).Seconds()
@@ -444,7 +504,11 @@ func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
 
 	readRate := float64(counterDelta(total.ReadBytes, c.prevDiskIO.ReadBytes)) / 1024 / 1024 / elapsed
 	writeRate := float64(counterDelta(total.WriteBytes, c.prevDiskIO.WriteBytes)) / 1024 / 1024 / elapsed
+	if wr, ww, ok := c.windowedDiskRate(sample); ok {
+		readRate, writeRate = wr, ww
+	}
 
+	devices := c.deviceIORates(counters, elapsed)
 	c.prevDiskIO = total
 	c.lastDiskAt = now
 
@@ -455,7 +519,48 @@ func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
 		writeRate = 0
 	}
 
-	return DiskIOStatus{ReadRate: readRate, WriteRate: writeRate}
+	return DiskIOStatus{ReadRate: readRate, WriteRate: writeRate, Devices: devices}, nil
+}
+
+// deviceIORates computes per-disk rates over elapsed seconds, busiest first.
+// Like the total, a device's first sample only primes its counters.
+// Partitions are skipped; their whole disk already counts their IO.
+func (c *Collector) deviceIORates(counters map[string]disk.IOCountersStat, elapsed float64) []DeviceIOStatus {
+	prev := c.prevDiskDevs
+	c.prevDiskDevs = make(map[string]disk.IOCountersStat, len(counters))
+	var devices []DeviceIOStatus
+	for name, cur := range counters {
+		if isDiskPartition(name) {
+			continue
+		}
+		c.prevDiskDevs[name] = cur
+		before, ok := prev[name]
+		if !ok {
+			continue
+		}
+		devices = append(devices, DeviceIOStatus{
+			Name:      name,
+			ReadRate:  float64(counterDelta(cur.ReadBytes, before.ReadBytes)) / 1024 / 1024 / elapsed,
+			WriteRate: float64(counterDelta(cur.WriteBytes, before.WriteBytes)) / 1024 / 1024 / elapsed,
+		})
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		a, b := devices[i].ReadRate+devices[i].WriteRate, devices[j].ReadRate+devices[j].WriteRate
+		if a != b {
+			return a > b
+		}
+		return devices[i].Name < devices[j].Name
+	})
+	return devices
+}
+
+// isDiskPartition reports IO counter names that are partitions: disk0s2 on
+// macOS, sda1 or nvme0n1p2 on Linux.
+func isDiskPartition(name string) bool {
+	if strings.HasPrefix(name, "disk") {
+		return baseDeviceName(name) != name
+	}
+	return linuxPartitionRe.MatchString(name)
 }
 
 func counterDelta(current, previous uint64) uint64 {
@@ -141,7 +141,7 @@ func TestCollectDisksFastSkipsSlowCorrections(t *testing.T) {
 		return "", errors.New("unexpected command")
 	}
 
-	got, err := collectDisksFast()
+	got, err := collectDisksFast(maxShownDisks)
 	if err != nil {
 		t.Fatalf("collectDisksFast() error = %v", err)
 	}
@@ -212,3 +212,40 @@ func TestCounterDeltaClampsCounterReset(t *testing.T) {
 		t.Fatalf("counterDelta reset = %d, want 0", got)
 	}
 }
+
+func TestCollectDisksKeepsEqualSizedVolumesAndHonorsLimit(t *testing.T) {
+	origPartitions := diskPartitionsFunc
+	origUsage := diskUsageFunc
+	t.Cleanup(func() {
+		diskPartitionsFunc = origPartitions
+		diskUsageFunc = origUsage
+	})
+
+	const size = uint64(500 << 30)
+	diskPartitionsFunc = func(bool) ([]disk.PartitionStat, error) {
+		return []disk.PartitionStat{
+			{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+			{Device: "/dev/sdb1", Mountpoint: "/srv/a", Fstype: "ext4"},
+			{Device: "/dev/sdc1", Mountpoint: "/srv/b", Fstype: "ext4"},
+			{Device: "/dev/sdd1", Mountpoint: "/srv/c", Fstype: "ext4"},
+			// A second subvolume of /srv/c's pool: same size and free space.
+			{Device: "/dev/sde1", Mountpoint: "/srv/c-snap", Fstype: "ext4"},
+		}, nil
+	}
+	free := map[string]uint64{"/": 100 << 30, "/srv/a": 200 << 30, "/srv/b": 300 << 30, "/srv/c": 400 << 30, "/srv/c-snap": 400 << 30}
+	diskUsageFunc = func(path string) (*disk.UsageStat, error) {
+		return &disk.UsageStat{Path: path, Total: size, Free: free[path], Used: size - free[path]}, nil
+	}
+
+	all, err := collectDisksFast(0)
+	if err != nil {
+		t.Fatalf("collectDisksFast(0) error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("expected 4 distinct equal-sized volumes, got %d: %#v", len(all), all)
+	}
+	limited, _ := collectDisksFast(maxShownDisks)
+	if len(limited) != maxShownDisks {
+		t.Fatalf("collectDisksFast(%d) returned %d disks", maxShownDisks, len(limited))
+	}
+}
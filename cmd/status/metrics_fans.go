@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	powermetricsThermalTTL     = 5 * time.Second
+	powermetricsThermalTimeout = 2 * time.Second
+)
+
+// fanSpeedRe matches the SMC sampler's fan lines: "Fan: 1812.45 rpm" on
+// single-fan machines, "Fan 0: ..." style lines on models with several.
+var fanSpeedRe = regexp.MustCompile(`(?mi)^\s*Fan(?:\s*\d+)?:\s*([\d.]+)\s*rpm`)
+
+// cpuDieTempRe matches the SMC sampler's "CPU die temperature: 52.31 C".
+var cpuDieTempRe = regexp.MustCompile(`CPU die temperature:\s+([\d.]+)\s*C`)
+
+// intelSMCCPUKeys are the SMC keys read for the CPU temperature, best
+// first: TC0D is the die, TC0P the proximity sensor next to it. Not every
+// Intel Mac has both.
+var intelSMCCPUKeys = []string{"TC0D", "TC0P"}
+
+// powermetricsThermal is what one powermetrics run reports about cooling.
+type powermetricsThermal struct {
+	fans     []int
+	pressure string
+	cpuTemp  float64 // Intel only, from the SMC sampler
+}
+
+// collectPowermetricsThermal reads the thermal pressure level and, on Intel
+// Macs, live fan RPM from powermetrics. system_profiler does not report live
+// fan speed, so the SMC sampler is the only command-line source; Apple
+// Silicon has no SMC sampler and asking for it fails the whole run.
+// powermetrics needs root; without it there are no readings.
+func (c *Collector) collectPowermetricsThermal(now time.Time) powermetricsThermal {
+	if runtime.GOOS != "darwin" {
+		return powermetricsThermal{}
+	}
+	if !c.lastPowermetricsThermalAt.IsZero() && now.Sub(c.lastPowermetricsThermalAt) < powermetricsThermalTTL {
+		return c.cachedPowermetricsThermal
+	}
+	c.lastPowermetricsThermalAt = now
+	c.cachedPowermetricsThermal = powermetricsThermal{}
+
+	samplers := "thermal"
+	if runtime.GOARCH == "amd64" {
+		samplers += ",smc"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), powermetricsThermalTimeout)
+	defer cancel()
+	out, err := runCmd(ctx, "powermetrics", "--samplers", samplers, "-i", "200", "-n", "1")
+	if err == nil {
+		c.cachedPowermetricsThermal = powermetricsThermal{
+			fans:     parsePowermetricsFans(out),
+			pressure: parseThermalPressure(out),
+			cpuTemp:  parsePowermetricsCPUTemp(out),
+		}
+	}
+	return c.cachedPowermetricsThermal
+}
+
+// parsePowermetricsFans returns one RPM reading per fan, in report order.
+func parsePowermetricsFans(out string) []int {
+	var speeds []int
+	for _, m := range fanSpeedRe.FindAllStringSubmatch(out, -1) {
+		rpm, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			continue
+		}
+		speeds = append(speeds, int(rpm+0.5))
+	}
+	return speeds
+}
+
+func parsePowermetricsCPUTemp(out string) float64 {
+	if m := cpuDieTempRe.FindStringSubmatch(out); m != nil {
+		if temp, err := strconv.ParseFloat(m[1], 64); err == nil && validCPUTemp(temp) {
+			return temp
+		}
+	}
+	return 0
+}
+
+// macCPUTemperature picks the CPU temperature on macOS. Intel Macs prefer
+// the die reading from powermetrics, which needs root, and otherwise read
+// the SMC keys directly through the smc tool from smcFanControl or
+// osx-cpu-temp, which do not. Apple Silicon has no SMC CPU key, so it stays
+// empty there rather than being estimated from unrelated sensors.
+func macCPUTemperature(pm powermetricsThermal) float64 {
+	if runtime.GOOS != "darwin" || runtime.GOARCH != "amd64" {
+		return 0
+	}
+	if pm.cpuTemp > 0 {
+		return pm.cpuTemp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if commandExists("smc") {
+		for _, key := range intelSMCCPUKeys {
+			out, _ := runCmd(ctx, "smc", "-k", key, "-r")
+			if temp := parseSMCKeyTemp(out); temp > 0 {
+				return temp
+			}
+		}
+	}
+	if commandExists("osx-cpu-temp") {
+		out, _ := runCmd(ctx, "osx-cpu-temp")
+		return parseOSXCPUTemp(out)
+	}
+	return 0
+}
+
+// parseSMCKeyTemp reads `smc -k TC0P -r` output such as
+// "  TC0P  [sp78]  52.5 (bytes 34 80)". A missing key prints no value.
+func parseSMCKeyTemp(out string) float64 {
+	_, rest, ok := strings.Cut(out, "]")
+	if !ok {
+		return 0
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return 0
+	}
+	temp, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || !validCPUTemp(temp) {
+		return 0
+	}
+	return temp
+}
+
+// parseOSXCPUTemp reads osx-cpu-temp's "61.8°C", which is TC0P.
+func parseOSXCPUTemp(out string) float64 {
+	temp, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(out), "°C"), 64)
+	if err != nil || !validCPUTemp(temp) {
+		return 0
+	}
+	return temp
+}
+
+// validCPUTemp rejects the 0 and negative placeholders absent sensors
+// report, and readings no CPU survives.
+func validCPUTemp(temp float64) bool {
+	return temp > 0 && temp < 130
+}
+
+// setFanSpeeds records per-fan readings; FanSpeed keeps the fastest fan for
+// consumers that only read one number.
+func (t *ThermalStatus) setFanSpeeds(speeds []int) {
+	t.FanSpeeds = speeds
+	t.FanCount = len(speeds)
+	t.FanSpeed = 0
+	for _, rpm := range speeds {
+		t.FanSpeed = max(t.FanSpeed, rpm)
+	}
+}
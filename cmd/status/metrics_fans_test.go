@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePowermetricsFans(t *testing.T) {
+	out := `**** SMC sensors ****
+
+CPU Thermal level: 0
+GPU Thermal level: 0
+IO Thermal level: 0
+Fan 0: 1812.45 rpm
+Fan 1: 2398.6 rpm
+CPU die temperature: 52.31 C
+`
+	if got := parsePowermetricsFans(out); !reflect.DeepEqual(got, []int{1812, 2399}) {
+		t.Fatalf("parsePowermetricsFans() = %v", got)
+	}
+	if got := parsePowermetricsFans("Fan: 1200.00 rpm\n"); !reflect.DeepEqual(got, []int{1200}) {
+		t.Fatalf("parsePowermetricsFans(single) = %v", got)
+	}
+	if got := parsePowermetricsFans("CPU die temperature: 52.31 C\n"); got != nil {
+		t.Fatalf("parsePowermetricsFans(no fans) = %v", got)
+	}
+}
+
+func TestParseIntelCPUTemperature(t *testing.T) {
+	if got := parsePowermetricsCPUTemp("Fan 0: 1812.45 rpm\nCPU die temperature: 52.31 C\n"); got != 52.31 {
+		t.Fatalf("parsePowermetricsCPUTemp() = %v", got)
+	}
+	if got := parseSMCKeyTemp("  TC0P  [sp78]  52.5 (bytes 34 80)\n"); got != 52.5 {
+		t.Fatalf("parseSMCKeyTemp() = %v", got)
+	}
+	// Keys a model lacks read back as zero or as no value at all.
+	for _, out := range []string{"  TC0D  [sp78]  0 (bytes 00 00)\n", "  TC0D  [    ]  no data\n", ""} {
+		if got := parseSMCKeyTemp(out); got != 0 {
+			t.Errorf("parseSMCKeyTemp(%q) = %v, want 0", out, got)
+		}
+	}
+	if got := parseOSXCPUTemp("61.8°C\n"); got != 61.8 {
+		t.Fatalf("parseOSXCPUTemp() = %v", got)
+	}
+}
+
+func TestSetFanSpeedsKeepsFastestFan(t *testing.T) {
+	var thermal ThermalStatus
+	thermal.setFanSpeeds([]int{1812, 2399})
+	if thermal.FanCount != 2 || thermal.FanSpeed != 2399 {
+		t.Fatalf("setFanSpeeds() = %+v", thermal)
+	}
+	if got := formatFanSpeeds(thermal); got != "1812/2399 RPM" {
+		t.Fatalf("formatFanSpeeds() = %q", got)
+	}
+
+	thermal.setFanSpeeds(nil)
+	if thermal.FanCount != 0 || thermal.FanSpeed != 0 {
+		t.Fatalf("setFanSpeeds(nil) = %+v", thermal)
+	}
+	if got := formatFanSpeeds(ThermalStatus{FanSpeed: 1200}); !strings.HasPrefix(got, "1200 ") {
+		t.Fatalf("formatFanSpeeds(single) = %q", got)
+	}
+}
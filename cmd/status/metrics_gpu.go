@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"strconv"
@@ -14,20 +17,34 @@ import (
 const (
 	systemProfilerTimeout = 4 * time.Second
 	macGPUInfoTTL         = 10 * time.Minute
+	macEGPUInfoTTL        = time.Minute // Re-check sooner so eGPU hot-plugs show up and drop out.
 	macGPUUsageTTL        = 5 * time.Second
 	powermetricsTimeout   = 2 * time.Second
+	intelGPUUsageTTL      = 5 * time.Second
+	intelGPUTopTimeout    = 1500 * time.Millisecond // One 1000ms sample plus startup
 )
 
+// gpuSharedMemoryNote marks integrated GPUs that have live usage but no VRAM.
+const gpuSharedMemoryNote = "Shared memory"
+
 // Regex for GPU usage parsing.
 var (
-	gpuActiveResidencyRe = regexp.MustCompile(`GPU HW active residency:\s+([\d.]+)%`)
+	gpuActiveResidencyRe = regexp.MustCompile(`GPU (?:HW )?active residency:\s+([\d.]+)%`) // "HW" since macOS 13
 	gpuIdleResidencyRe   = regexp.MustCompile(`GPU idle residency:\s+([\d.]+)%`)
+	gpuDieTempRe         = regexp.MustCompile(`GPU die temperature:\s+([\d.]+)\s*C`)
 )
 
+// gpuSensorChips are Linux hwmon chips that belong to a GPU.
+var gpuSensorChips = []string{"amdgpu_", "radeon_", "nouveau_", "nvidia_"}
+
+// drmRoot is the DRM class directory in sysfs, overridable in tests.
+var drmRoot = "/sys/class/drm"
+
 func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
 	if runtime.GOOS == "darwin" {
-		// Static GPU info (cached 10 min).
-		if len(c.cachedGPU) == 0 || c.lastGPUAt.IsZero() || now.Sub(c.lastGPUAt) >= macGPUInfoTTL {
+		// Static GPU info (cached 10 min, or 1 min where an eGPU can come and go).
+		infoTTL := macGPUInfoTTLFor(c.cachedGPU, runtime.GOARCH)
+		if len(c.cachedGPU) == 0 || c.lastGPUAt.IsZero() || now.Sub(c.lastGPUAt) >= infoTTL {
 			if gpus, err := readMacGPUInfo(); err == nil && len(gpus) > 0 {
 				c.cachedGPU = gpus
 				c.lastGPUAt = now
@@ -36,59 +53,199 @@ func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
 
 		// Real-time GPU usage.
 		if len(c.cachedGPU) > 0 {
-			usage := c.getMacGPUUsage(now)
+			usage, temp := c.getMacGPUUsage(now)
 			result := make([]GPUStatus, len(c.cachedGPU))
 			copy(result, c.cachedGPU)
 			// Apply usage to first GPU (Apple Silicon).
 			if len(result) > 0 {
 				result[0].Usage = usage
+				result[0].Temperature = temp
 			}
 			return result, nil
 		}
 	}
 
+	// amdgpu exposes usage in sysfs and Intel iGPUs through intel_gpu_top;
+	// keep going so an NVIDIA card in the same machine is listed too.
+	var linuxGPUs []GPUStatus
+	if runtime.GOOS == "linux" {
+		linuxGPUs = readAMDGPUs(drmRoot)
+		if intel, ok := c.getIntelGPU(now); ok {
+			linuxGPUs = append(linuxGPUs, intel)
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
 	defer cancel()
 
 	if !commandExists("nvidia-smi") {
+		if len(linuxGPUs) > 0 {
+			return linuxGPUs, nil
+		}
 		return []GPUStatus{{
 			Name: "No GPU metrics available",
 			Note: "Install nvidia-smi or use platform-specific metrics",
 		}}, nil
 	}
 
-	out, err := runCmd(ctx, "nvidia-smi", "--query-gpu=utilization.gpu,memory.used,memory.total,name", "--format=csv,noheader,nounits")
+	out, err := runCmd(ctx, "nvidia-smi", "--query-gpu=utilization.gpu,memory.used,memory.total,temperature.gpu,power.draw,name", "--format=csv,noheader,nounits")
 	if err != nil {
+		if len(linuxGPUs) > 0 {
+			return linuxGPUs, nil
+		}
 		return nil, err
 	}
 
+	gpus := append(linuxGPUs, parseNvidiaSMI(out)...)
+	if len(gpus) == 0 {
+		return []GPUStatus{{
+			Name: "GPU read failed",
+			Note: "Verify nvidia-smi availability",
+		}}, nil
+	}
+
+	return gpus, nil
+}
+
+// parseNvidiaSMI reads "utilization, memory used, memory total, temperature,
+// power draw, name" rows. Unsupported fields come back as "[N/A]" and parse as zero; the
+// name is last because it may itself contain commas.
+func parseNvidiaSMI(out string) []GPUStatus {
 	var gpus []GPUStatus
 	for line := range strings.Lines(strings.TrimSpace(out)) {
 		fields := strings.Split(line, ",")
-		if len(fields) < 4 {
+		if len(fields) < 6 {
 			continue
 		}
 		util, _ := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
 		memUsed, _ := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
 		memTotal, _ := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
-		name := strings.TrimSpace(fields[3])
+		temp, _ := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+		power, _ := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64)
+		name := strings.TrimSpace(strings.Join(fields[5:], ","))
 
 		gpus = append(gpus, GPUStatus{
 			Name:        name,
 			Usage:       util,
 			MemoryUsed:  memUsed,
 			MemoryTotal: memTotal,
+			Temperature: temp,
+			PowerWatts:  power,
 		})
 	}
+	return gpus
+}
 
-	if len(gpus) == 0 {
-		return []GPUStatus{{
-			Name: "GPU read failed",
-			Note: "Verify nvidia-smi availability",
-		}}, nil
+// readAMDGPUs reads amdgpu's sysfs counters for every card that exposes
+// gpu_busy_percent. VRAM is reported in MiB to match nvidia-smi.
+func readAMDGPUs(root string) []GPUStatus {
+	paths, _ := filepath.Glob(filepath.Join(root, "card*", "device", "gpu_busy_percent"))
+	var gpus []GPUStatus
+	for _, busyPath := range paths {
+		device := filepath.Dir(busyPath)
+		usage, ok := readSysfsNumber(busyPath)
+		if !ok {
+			continue
+		}
+		name := "AMD GPU (" + filepath.Base(filepath.Dir(device)) + ")"
+		if product, err := os.ReadFile(filepath.Join(device, "product_name")); err == nil && strings.TrimSpace(string(product)) != "" {
+			name = strings.TrimSpace(string(product))
+		}
+		used, _ := readSysfsNumber(filepath.Join(device, "mem_info_vram_used"))
+		total, _ := readSysfsNumber(filepath.Join(device, "mem_info_vram_total"))
+		gpus = append(gpus, GPUStatus{
+			Name:        name,
+			Usage:       usage,
+			MemoryUsed:  used / 1024 / 1024,
+			MemoryTotal: total / 1024 / 1024,
+		})
 	}
+	return gpus
+}
 
-	return gpus, nil
+func readSysfsNumber(path string) (float64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	return v, err == nil
+}
+
+// getIntelGPU samples intel_gpu_top at most every intelGPUUsageTTL, since
+// each run blocks for a full sample period. It usually needs root (perf).
+func (c *Collector) getIntelGPU(now time.Time) (GPUStatus, bool) {
+	if !commandExists("intel_gpu_top") {
+		return GPUStatus{}, false
+	}
+	if c.lastGPUUsageAt.IsZero() || now.Sub(c.lastGPUUsageAt) >= intelGPUUsageTTL {
+		c.cachedGPUUsage = readIntelGPUUsage()
+		c.lastGPUUsageAt = now
+	}
+	if c.cachedGPUUsage < 0 {
+		return GPUStatus{}, false
+	}
+	return GPUStatus{Name: "Intel Graphics", Usage: c.cachedGPUUsage, Note: gpuSharedMemoryNote}, true
+}
+
+// readIntelGPUUsage returns -1 when intel_gpu_top yields no sample.
+func readIntelGPUUsage() float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), intelGPUTopTimeout)
+	defer cancel()
+	// intel_gpu_top keeps streaming until killed, so the timeout error is
+	// expected; Output still returns what was written before it.
+	out, _ := exec.CommandContext(ctx, "intel_gpu_top", "-J", "-s", "1000").Output()
+	usage, ok := parseIntelGPUTop(string(out))
+	if !ok {
+		return -1
+	}
+	return usage
+}
+
+// parseIntelGPUTop reads the first sample of `intel_gpu_top -J`. Engines run
+// in parallel, so the busiest one is the GPU's overall load. Newer releases
+// wrap the stream in a JSON array.
+func parseIntelGPUTop(out string) (float64, bool) {
+	out = strings.TrimSpace(out)
+	dec := json.NewDecoder(strings.NewReader(out))
+	if strings.HasPrefix(out, "[") {
+		if _, err := dec.Token(); err != nil {
+			return 0, false
+		}
+	}
+	var sample struct {
+		Engines map[string]struct {
+			Busy float64 `json:"busy"`
+		} `json:"engines"`
+	}
+	if err := dec.Decode(&sample); err != nil || len(sample.Engines) == 0 {
+		return 0, false
+	}
+	busiest := 0.0
+	for _, engine := range sample.Engines {
+		busiest = max(busiest, engine.Busy)
+	}
+	return min(busiest, 100), true
+}
+
+// gpuTemperature is the hottest GPU reading: nvidia-smi or powermetrics
+// first, then Linux hwmon chips belonging to a GPU (amdgpu, nouveau).
+func gpuTemperature(gpus []GPUStatus, sensors []SensorReading) float64 {
+	hottest := 0.0
+	for _, g := range gpus {
+		hottest = max(hottest, g.Temperature)
+	}
+	if hottest > 0 {
+		return hottest
+	}
+	for _, s := range sensors {
+		for _, chip := range gpuSensorChips {
+			if strings.HasPrefix(s.Key, chip) {
+				hottest = max(hottest, s.Value)
+			}
+		}
+	}
+	return hottest
 }
 
 func readMacGPUInfo() ([]GPUStatus, error) {
@@ -103,16 +260,34 @@ func readMacGPUInfo() ([]GPUStatus, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseMacGPUInfo(out)
+}
 
-	var data struct {
-		Displays []struct {
-			Name   string `json:"_name"`
-			VRAM   string `json:"spdisplays_vram"`
-			Vendor string `json:"spdisplays_vendor"`
-			Metal  string `json:"spdisplays_metal"`
-			Cores  string `json:"sppci_cores"`
-		} `json:"SPDisplaysDataType"`
-	}
+type macDisplaysJSON struct {
+	Displays []struct {
+		Name      string           `json:"_name"`
+		VRAM      string           `json:"spdisplays_vram"`
+		Vendor    string           `json:"spdisplays_vendor"`
+		Metal     string           `json:"spdisplays_metal"`
+		Cores     string           `json:"sppci_cores"`
+		Bus       string           `json:"sppci_bus"`
+		Removable string           `json:"spdisplays_gpu_removable"`
+		Monitors  []macMonitorJSON `json:"spdisplays_ndrvs"`
+	} `json:"SPDisplaysDataType"`
+}
+
+type macMonitorJSON struct {
+	Name           string `json:"_name"`
+	Pixels         string `json:"_spdisplays_pixels"`
+	Resolution     string `json:"_spdisplays_resolution"`
+	LegacyRes      string `json:"spdisplays_resolution"`
+	ConnectionType string `json:"spdisplays_connection_type"`
+	DisplayType    string `json:"spdisplays_display_type"`
+	Main           string `json:"spdisplays_main"`
+}
+
+func parseMacGPUInfo(out string) ([]GPUStatus, error) {
+	var data macDisplaysJSON
 	if err := json.Unmarshal([]byte(out), &data); err != nil {
 		return nil, err
 	}
@@ -134,11 +309,17 @@ func readMacGPUInfo() ([]GPUStatus, error) {
 		}
 		note := strings.Join(noteParts, " · ")
 		coreCount, _ := strconv.Atoi(d.Cores)
+		var displays []DisplayInfo
+		for _, monitor := range d.Monitors {
+			displays = append(displays, parseMacMonitor(monitor))
+		}
 		gpus = append(gpus, GPUStatus{
 			Name:      d.Name,
 			Usage:     -1, // Will be updated with real-time data
 			CoreCount: coreCount,
 			Note:      note,
+			External:  d.Removable == "spdisplays_yes" || strings.Contains(strings.ToLower(d.Bus), "thunderbolt"),
+			Displays:  displays,
 		})
 	}
 
@@ -152,34 +333,97 @@ func readMacGPUInfo() ([]GPUStatus, error) {
 	return gpus, nil
 }
 
-func (c *Collector) getMacGPUUsage(now time.Time) float64 {
+// macGPUInfoTTLFor uses the short eGPU TTL on Intel Macs, which take
+// Thunderbolt eGPUs, so one plugged in mid-session appears within a minute
+// and one unplugged drops out as quickly. Apple Silicon has no eGPU support
+// and keeps the long TTL unless one is somehow reported.
+func macGPUInfoTTLFor(cached []GPUStatus, arch string) time.Duration {
+	if arch == "amd64" || hasExternalGPU(cached) {
+		return macEGPUInfoTTL
+	}
+	return macGPUInfoTTL
+}
+
+func hasExternalGPU(gpus []GPUStatus) bool {
+	for _, g := range gpus {
+		if g.External {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMacMonitor maps one spdisplays_ndrvs entry. The pixel size is the
+// panel's native resolution; the "resolution" field is the scaled UI size
+// followed by the refresh rate ("1512 x 982 @ 120.00Hz").
+func parseMacMonitor(m macMonitorJSON) DisplayInfo {
+	resolution := m.Resolution
+	if resolution == "" {
+		resolution = m.LegacyRes
+	}
+	scaled, refresh, _ := strings.Cut(resolution, "@")
+	pixels := m.Pixels
+	if pixels == "" {
+		pixels = scaled
+	}
+
+	info := DisplayInfo{
+		Name:       m.Name,
+		Resolution: strings.ReplaceAll(strings.TrimSpace(pixels), " ", ""),
+		Main:       m.Main == "spdisplays_yes",
+	}
+	if hz := parseInt(refresh); hz > 0 {
+		info.RefreshRate = hz
+	}
+	info.BuiltIn = m.ConnectionType == "spdisplays_internal" ||
+		strings.Contains(m.DisplayType, "built-in") ||
+		strings.Contains(strings.ToLower(m.Name), "built-in")
+	return info
+}
+
+func (c *Collector) getMacGPUUsage(now time.Time) (float64, float64) {
 	if !c.lastGPUUsageAt.IsZero() && now.Sub(c.lastGPUUsageAt) < macGPUUsageTTL {
-		return c.cachedGPUUsage
+		return c.cachedGPUUsage, c.cachedGPUTemp
 	}
 
-	usage := getMacGPUUsage()
+	usage, temp := getMacGPUUsage()
 	c.cachedGPUUsage = usage
+	c.cachedGPUTemp = temp
 	c.lastGPUUsageAt = now
-	return usage
+	return usage, temp
 }
 
-// getMacGPUUsage reads GPU active residency from powermetrics.
-func getMacGPUUsage() float64 {
+// getMacGPUUsage reads GPU active residency, and the GPU die temperature
+// where the SMC sampler reports one, from powermetrics.
+func getMacGPUUsage() (float64, float64) {
 	ctx, cancel := context.WithTimeout(context.Background(), powermetricsTimeout)
 	defer cancel()
 
-	// powermetrics may require root.
-	out, err := runCmd(ctx, "powermetrics", "--samplers", "gpu_power", "-i", "500", "-n", "1")
+	// powermetrics may require root. Only Intel Macs have the smc sampler;
+	// asking for it on Apple Silicon fails the whole run.
+	samplers := "gpu_power"
+	if runtime.GOARCH == "amd64" {
+		samplers += ",smc"
+	}
+	out, err := runCmd(ctx, "powermetrics", "--samplers", samplers, "-i", "500", "-n", "1")
 	if err != nil {
-		return -1
+		return -1, 0
+	}
+	return parsePowermetricsGPU(out)
+}
+
+func parsePowermetricsGPU(out string) (float64, float64) {
+	temp := 0.0
+	if matches := gpuDieTempRe.FindStringSubmatch(out); len(matches) >= 2 {
+		temp, _ = strconv.ParseFloat(matches[1], 64)
 	}
 
-	// Parse "GPU HW active residency: X.XX%".
+	// Parse "GPU HW active residency: X.XX%" ("GPU active residency" before macOS 13).
 	matches := gpuActiveResidencyRe.FindStringSubmatch(out)
 	if len(matches) >= 2 {
 		usage, err := strconv.ParseFloat(matches[1], 64)
 		if err == nil {
-			return usage
+			return usage, temp
 		}
 	}
 
@@ -188,9 +432,9 @@ func getMacGPUUsage() float64 {
 	if len(matchesIdle) >= 2 {
 		idle, err := strconv.ParseFloat(matchesIdle[1], 64)
 		if err == nil {
-			return 100.0 - idle
+			return 100.0 - idle, temp
 		}
 	}
 
-	return -1
+	return -1, temp
 }
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const spDisplaysFixture = `{
+  "SPDisplaysDataType" : [
+    {
+      "_name" : "Apple M3 Pro",
+      "sppci_cores" : "18",
+      "spdisplays_vendor" : "sppci_vendor_Apple",
+      "spdisplays_ndrvs" : [
+        {
+          "_name" : "Color LCD",
+          "_spdisplays_pixels" : "3024 x 1964",
+          "_spdisplays_resolution" : "1512 x 982 @ 120.00Hz",
+          "spdisplays_connection_type" : "spdisplays_internal",
+          "spdisplays_display_type" : "spdisplays_built-in-liquid-retina-xdr",
+          "spdisplays_main" : "spdisplays_yes"
+        },
+        {
+          "_name" : "DELL U2720Q",
+          "_spdisplays_pixels" : "3840 x 2160",
+          "_spdisplays_resolution" : "1920 x 1080 @ 60.00Hz"
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseMacGPUInfoReadsConnectedDisplays(t *testing.T) {
+	gpus, err := parseMacGPUInfo(spDisplaysFixture)
+	if err != nil {
+		t.Fatalf("parseMacGPUInfo() error = %v", err)
+	}
+	if len(gpus) != 1 || gpus[0].Name != "Apple M3 Pro" || gpus[0].CoreCount != 18 {
+		t.Fatalf("unexpected GPUs %#v", gpus)
+	}
+	displays := gpus[0].Displays
+	if len(displays) != 2 {
+		t.Fatalf("expected two displays, got %#v", displays)
+	}
+	builtIn := displays[0]
+	if !builtIn.BuiltIn || !builtIn.Main || builtIn.Resolution != "3024x1964" || builtIn.RefreshRate != 120 {
+		t.Fatalf("unexpected built-in display %#v", builtIn)
+	}
+	external := displays[1]
+	if external.BuiltIn || external.Name != "DELL U2720Q" || external.Resolution != "3840x2160" || external.RefreshRate != 60 {
+		t.Fatalf("unexpected external display %#v", external)
+	}
+}
+
+func TestParseMacMonitorFallsBackToScaledResolution(t *testing.T) {
+	got := parseMacMonitor(macMonitorJSON{Name: "LG", LegacyRes: "2560 x 1440 @ 75Hz"})
+	if got.Resolution != "2560x1440" || got.RefreshRate != 75 || got.BuiltIn {
+		t.Fatalf("parseMacMonitor() = %#v", got)
+	}
+}
+
+func TestParseMacGPUInfoTagsExternalGPU(t *testing.T) {
+	const fixture = `{
+  "SPDisplaysDataType" : [
+    {"_name" : "Intel Iris Plus Graphics", "sppci_bus" : "spdisplays_builtin"},
+    {
+      "_name" : "AMD Radeon RX 6800 XT",
+      "sppci_bus" : "spdisplays_pcie_device",
+      "spdisplays_gpu_removable" : "spdisplays_yes",
+      "spdisplays_vram" : "16 GB"
+    }
+  ]
+}`
+	gpus, err := parseMacGPUInfo(fixture)
+	if err != nil {
+		t.Fatalf("parseMacGPUInfo() error = %v", err)
+	}
+	if len(gpus) != 2 {
+		t.Fatalf("expected two GPUs, got %#v", gpus)
+	}
+	if gpus[0].External || !gpus[1].External {
+		t.Fatalf("expected only the removable GPU to be external, got %#v", gpus)
+	}
+	if !hasExternalGPU(gpus) || hasExternalGPU(gpus[:1]) {
+		t.Fatal("hasExternalGPU() mismatch")
+	}
+
+	// Intel Macs re-check quickly even before an eGPU is seen, so a hot-plug
+	// in either direction shows up within macEGPUInfoTTL.
+	if got := macGPUInfoTTLFor(gpus[:1], "amd64"); got != macEGPUInfoTTL {
+		t.Fatalf("Intel Mac without eGPU TTL = %v, want %v", got, macEGPUInfoTTL)
+	}
+	if got := macGPUInfoTTLFor(gpus[:1], "arm64"); got != macGPUInfoTTL {
+		t.Fatalf("Apple Silicon TTL = %v, want %v", got, macGPUInfoTTL)
+	}
+	if got := macGPUInfoTTLFor(gpus, "arm64"); got != macEGPUInfoTTL {
+		t.Fatalf("TTL with eGPU cached = %v, want %v", got, macEGPUInfoTTL)
+	}
+}
+
+func TestParseNvidiaSMIReadsTemperatureAndPower(t *testing.T) {
+	out := "37, 1024, 8192, 64, 182.45, NVIDIA GeForce RTX 3070\n0, 12, 4096, [N/A], [N/A], Tesla T4, rev B\n"
+	gpus := parseNvidiaSMI(out)
+	if len(gpus) != 2 {
+		t.Fatalf("parseNvidiaSMI() returned %d GPUs", len(gpus))
+	}
+	if g := gpus[0]; g.Name != "NVIDIA GeForce RTX 3070" || g.Usage != 37 || g.Temperature != 64 || g.PowerWatts != 182.45 {
+		t.Fatalf("first GPU = %+v", g)
+	}
+	if g := gpus[1]; g.Name != "Tesla T4, rev B" || g.Temperature != 0 || g.PowerWatts != 0 {
+		t.Fatalf("second GPU = %+v", g)
+	}
+}
+
+func TestParsePowermetricsGPUReadsDieTemperature(t *testing.T) {
+	usage, temp := parsePowermetricsGPU("GPU HW active residency:  22.50%\nGPU die temperature: 61.25 C\n")
+	if usage != 22.5 || temp != 61.25 {
+		t.Fatalf("parsePowermetricsGPU() = %v, %v", usage, temp)
+	}
+	if usage, _ := parsePowermetricsGPU("GPU active residency:   7.25% (389 MHz: 7.25%)\n"); usage != 7.25 {
+		t.Fatalf("pre-macOS 13 active residency = %v, want 7.25", usage)
+	}
+	if _, temp := parsePowermetricsGPU("GPU idle residency: 90.00%\n"); temp != 0 {
+		t.Fatalf("temperature without smc sampler = %v", temp)
+	}
+}
+
+func TestGPUTemperaturePrefersGPUReadingsOverSensors(t *testing.T) {
+	sensors := []SensorReading{{Key: "coretemp_core_0", Value: 90}, {Key: "amdgpu_edge", Value: 58}}
+	if got := gpuTemperature(nil, sensors); got != 58 {
+		t.Fatalf("sensor GPU temperature = %v, want 58", got)
+	}
+	if got := gpuTemperature([]GPUStatus{{Temperature: 70}, {Temperature: 74}}, sensors); got != 74 {
+		t.Fatalf("GPU temperature = %v, want 74", got)
+	}
+}
+
+func TestPowerCardShowsGPUTemperature(t *testing.T) {
+	card := renderBatteryCard(nil, ThermalStatus{GPUTemp: 72})
+	last := stripANSI(card.lines[len(card.lines)-1])
+	if !strings.HasPrefix(last, "GPU") || !strings.Contains(last, "72.0°C") {
+		t.Fatalf("power card GPU line = %q", last)
+	}
+}
+
+func TestReadAMDGPUs(t *testing.T) {
+	root := t.TempDir()
+	device := filepath.Join(root, "card1", "device")
+	if err := os.MkdirAll(device, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// A connector entry has no device counters and must be skipped.
+	if err := os.MkdirAll(filepath.Join(root, "card1-DP-1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{
+		"gpu_busy_percent":    "37\n",
+		"mem_info_vram_used":  "2147483648\n",
+		"mem_info_vram_total": "8589934592\n",
+	} {
+		if err := os.WriteFile(filepath.Join(device, name), []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gpus := readAMDGPUs(root)
+	if len(gpus) != 1 {
+		t.Fatalf("readAMDGPUs() = %+v, want one card", gpus)
+	}
+	g := gpus[0]
+	if g.Name != "AMD GPU (card1)" || g.Usage != 37 || g.MemoryUsed != 2048 || g.MemoryTotal != 8192 {
+		t.Fatalf("readAMDGPUs() = %+v", g)
+	}
+	if !gpuHasLiveUsage(g) {
+		t.Fatal("AMD usage should count as live for the GPU card")
+	}
+	if gpus := readAMDGPUs(t.TempDir()); len(gpus) != 0 {
+		t.Fatalf("no amdgpu cards: %+v", gpus)
+	}
+}
+
+func TestParseIntelGPUTop(t *testing.T) {
+	stream := `[
+{
+	"period": {"duration": 1000.2, "unit": "ms"},
+	"engines": {
+		"Render/3D/0": {"busy": 42.5, "sem": 0.0, "wait": 0.0, "unit": "%"},
+		"Blitter/0": {"busy": 0.0, "sem": 0.0, "wait": 0.0, "unit": "%"},
+		"Video/0": {"busy": 12.0, "sem": 0.0, "wait": 0.0, "unit": "%"}
+	}
+},
+{
+	"period": {"duration": 1000.1, "unit": "ms"},
+	"engines": {`
+	usage, ok := parseIntelGPUTop(stream)
+	if !ok || usage != 42.5 {
+		t.Fatalf("parseIntelGPUTop() = %v, %v; want the busiest engine of the first sample", usage, ok)
+	}
+	if _, ok := parseIntelGPUTop(`{"engines": {"Render/3D/0": {"busy": 3.0}}}`); !ok {
+		t.Fatal("older releases without the array wrapper should parse")
+	}
+	if _, ok := parseIntelGPUTop(""); ok {
+		t.Fatal("empty output should not parse")
+	}
+	if !gpuHasLiveUsage(GPUStatus{Name: "Intel Graphics", Usage: 5, Note: gpuSharedMemoryNote}) {
+		t.Fatal("shared-memory iGPU usage should count as live")
+	}
+}
@@ -3,85 +3,252 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 )
 
 func collectHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
+	if runtime.GOOS == "windows" {
+		return collectWindowsHardware(totalRAM, disks)
+	}
+	if runtime.GOOS == "linux" {
+		return collectLinuxHardware(totalRAM, disks)
+	}
 	if runtime.GOOS != "darwin" {
-		return HardwareInfo{
-			Model:       "Unknown",
-			CPUModel:    runtime.GOARCH,
-			TotalRAM:    humanBytes(totalRAM),
-			DiskSize:    "Unknown",
-			OSVersion:   runtime.GOOS,
-			RefreshRate: "",
-		}
+		return unknownHardware(totalRAM)
 	}
 
-	// Model and CPU from system_profiler.
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
 	var model, cpuModel, osVersion, refreshRate string
 
-	out, err := runCmd(ctx, "system_profiler", "SPHardwareDataType")
-	if err == nil {
-		for line := range strings.Lines(out) {
-			lower := strings.ToLower(strings.TrimSpace(line))
-			// Prefer "Model Name" over "Model Identifier".
-			if strings.Contains(lower, "model name:") {
-				parts := strings.Split(line, ":")
-				if len(parts) == 2 {
-					model = strings.TrimSpace(parts[1])
-				}
+	// The three probes are independent; run them together so a cold start
+	// waits for the slowest one instead of their sum.
+	_ = collectConcurrently(
+		func() error {
+			// Model and CPU from the shared system_profiler cache.
+			model, cpuModel = parseMacHardwareModel(readSystemProfiler("SPHardwareDataType"))
+			return nil
+		},
+		func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			if out, err := runCmd(ctx, "sw_vers", "-productVersion"); err == nil {
+				osVersion = "macOS " + strings.TrimSpace(out)
 			}
-			if strings.Contains(lower, "chip:") {
-				parts := strings.Split(line, ":")
-				if len(parts) == 2 {
-					cpuModel = strings.TrimSpace(parts[1])
-				}
-			}
-			if strings.Contains(lower, "processor name:") && cpuModel == "" {
-				parts := strings.Split(line, ":")
-				if len(parts) == 2 {
-					cpuModel = strings.TrimSpace(parts[1])
-				}
+			return nil
+		},
+		func() error {
+			// Get refresh rate from display info (use mini detail to keep it fast).
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if out, err := runCmd(ctx, "system_profiler", "-detailLevel", "mini", "SPDisplaysDataType"); err == nil {
+				refreshRate = parseRefreshRate(out)
 			}
-		}
-	}
-
-	ctx2, cancel2 := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel2()
-	out2, err := runCmd(ctx2, "sw_vers", "-productVersion")
-	if err == nil {
-		osVersion = "macOS " + strings.TrimSpace(out2)
-	}
-
-	// Get refresh rate from display info (use mini detail to keep it fast).
-	ctx3, cancel3 := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel3()
-	out3, err := runCmd(ctx3, "system_profiler", "-detailLevel", "mini", "SPDisplaysDataType")
-	if err == nil {
-		refreshRate = parseRefreshRate(out3)
-	}
+			return nil
+		},
+	)
 
 	diskSize := "Unknown"
 	if len(disks) > 0 {
-		diskSize = humanBytes(disks[0].Total)
+		diskSize = formatBytes(disks[0].Total)
 	}
 
 	return HardwareInfo{
 		Model:       model,
 		CPUModel:    cpuModel,
-		TotalRAM:    humanBytes(totalRAM),
+		TotalRAM:    formatBytes(totalRAM),
 		DiskSize:    diskSize,
 		OSVersion:   osVersion,
 		RefreshRate: refreshRate,
 	}
 }
 
+// parseMacHardwareModel reads the model name and chip (or Intel processor
+// name) from system_profiler SPHardwareDataType.
+func parseMacHardwareModel(out string) (model, cpuModel string) {
+	for line := range strings.Lines(out) {
+		lower := strings.ToLower(strings.TrimSpace(line))
+		// Prefer "Model Name" over "Model Identifier".
+		if strings.Contains(lower, "model name:") {
+			parts := strings.Split(line, ":")
+			if len(parts) == 2 {
+				model = strings.TrimSpace(parts[1])
+			}
+		}
+		if strings.Contains(lower, "chip:") {
+			parts := strings.Split(line, ":")
+			if len(parts) == 2 {
+				cpuModel = strings.TrimSpace(parts[1])
+			}
+		}
+		if strings.Contains(lower, "processor name:") && cpuModel == "" {
+			parts := strings.Split(line, ":")
+			if len(parts) == 2 {
+				cpuModel = strings.TrimSpace(parts[1])
+			}
+		}
+	}
+	return model, cpuModel
+}
+
+func unknownHardware(totalRAM uint64) HardwareInfo {
+	return HardwareInfo{
+		Model:       "Unknown",
+		CPUModel:    runtime.GOARCH,
+		TotalRAM:    formatBytes(totalRAM),
+		DiskSize:    "Unknown",
+		OSVersion:   runtime.GOOS,
+		RefreshRate: "",
+	}
+}
+
+// Linux sources, overridable in tests.
+var (
+	dmiRoot       = "/sys/class/dmi/id"
+	osReleasePath = "/etc/os-release"
+)
+
+// dmiPlaceholders are firmware defaults that board vendors leave unfilled.
+var dmiPlaceholders = []string{"to be filled by o.e.m.", "system product name", "system manufacturer", "default string", "not specified"}
+
+// collectLinuxHardware reads DMI for the model, /proc/cpuinfo for the CPU
+// and os-release for the distribution, keeping the unknown defaults for
+// anything missing (DMI is absent on most ARM boards and in containers).
+func collectLinuxHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
+	info := unknownHardware(totalRAM)
+	if len(disks) > 0 {
+		info.DiskSize = formatBytes(disks[0].Total)
+	}
+	if model := linuxHardwareModel(readDMIField("sys_vendor"), readDMIField("product_name")); model != "" {
+		info.Model = model
+	}
+	if data, err := os.ReadFile(procRoot + "/cpuinfo"); err == nil {
+		if cpu := parseCPUInfoModel(string(data)); cpu != "" {
+			info.CPUModel = cpu
+		}
+	}
+	if data, err := os.ReadFile(osReleasePath); err == nil {
+		if name := parseOSReleasePrettyName(string(data)); name != "" {
+			info.OSVersion = name
+		}
+	}
+	return info
+}
+
+func readDMIField(name string) string {
+	data, err := os.ReadFile(filepath.Join(dmiRoot, name))
+	if err != nil {
+		return ""
+	}
+	value := strings.TrimSpace(string(data))
+	for _, placeholder := range dmiPlaceholders {
+		if strings.EqualFold(value, placeholder) {
+			return ""
+		}
+	}
+	return value
+}
+
+// linuxHardwareModel joins vendor and product unless the product already
+// names the vendor (e.g. "HP" and "HP EliteBook 840 G8").
+func linuxHardwareModel(vendor, product string) string {
+	switch {
+	case product == "":
+		return vendor
+	case vendor == "" || strings.HasPrefix(strings.ToLower(product), strings.ToLower(vendor)):
+		return product
+	}
+	return vendor + " " + product
+}
+
+// parseCPUInfoModel returns the first "model name" from /proc/cpuinfo. ARM
+// kernels omit it, so "Hardware" or "Model" lines are used as a fallback.
+func parseCPUInfoModel(data string) string {
+	fallback := ""
+	for line := range strings.Lines(data) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.Join(strings.Fields(value), " ")
+		switch {
+		case value == "":
+		case key == "model name":
+			return value
+		case fallback == "" && (key == "Hardware" || key == "Model"):
+			fallback = value
+		}
+	}
+	return fallback
+}
+
+// parseOSReleasePrettyName reads PRETTY_NAME from os-release, unquoting it.
+func parseOSReleasePrettyName(data string) string {
+	for line := range strings.Lines(data) {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "PRETTY_NAME=")
+		if ok {
+			return strings.Trim(value, `"'`)
+		}
+	}
+	return ""
+}
+
+// collectWindowsHardware queries WMI through wmic, keeping the unknown
+// defaults for anything wmic does not answer (it is missing on recent
+// Windows 11 builds unless installed as an optional feature).
+func collectWindowsHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
+	info := unknownHardware(totalRAM)
+	if len(disks) > 0 {
+		info.DiskSize = formatBytes(disks[0].Total)
+	}
+	if !commandExists("wmic") {
+		return info
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if out, err := runCmd(ctx, "wmic", "cpu", "get", "Name", "/value"); err == nil {
+		if name := parseWMICValues(out)["Name"]; name != "" {
+			info.CPUModel = name
+		}
+	}
+	if out, err := runCmd(ctx, "wmic", "computersystem", "get", "Model", "/value"); err == nil {
+		if model := parseWMICValues(out)["Model"]; model != "" {
+			info.Model = model
+		}
+	}
+	if out, err := runCmd(ctx, "wmic", "os", "get", "Caption,Version", "/value"); err == nil {
+		values := parseWMICValues(out)
+		caption := strings.TrimPrefix(values["Caption"], "Microsoft ")
+		switch {
+		case caption != "" && values["Version"] != "":
+			info.OSVersion = caption + " " + values["Version"]
+		case caption != "":
+			info.OSVersion = caption
+		}
+	}
+	return info
+}
+
+// parseWMICValues reads `wmic ... /value` output: Key=Value lines separated
+// by blank CRLF lines. The first instance wins (e.g. the first CPU socket).
+func parseWMICValues(out string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.Lines(out) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		if _, seen := values[key]; !seen {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
 // parseRefreshRate extracts the highest refresh rate from system_profiler display output.
 func parseRefreshRate(output string) string {
 	maxHz := 0
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWMICValues(t *testing.T) {
+	out := "\r\r\nCaption=Microsoft Windows 11 Pro\r\r\nVersion=10.0.22631\r\r\n\r\r\nName=Intel(R) Core(TM) i7-9700K CPU @ 3.60GHz \r\r\nName=Second Socket\r\r\n"
+	values := parseWMICValues(out)
+	if got := values["Caption"]; got != "Microsoft Windows 11 Pro" {
+		t.Fatalf("Caption = %q", got)
+	}
+	if got := values["Version"]; got != "10.0.22631" {
+		t.Fatalf("Version = %q", got)
+	}
+	if got := values["Name"]; got != "Intel(R) Core(TM) i7-9700K CPU @ 3.60GHz" {
+		t.Fatalf("Name = %q, want the first instance trimmed", got)
+	}
+	if len(parseWMICValues("No Instance(s) Available.\r\n")) != 0 {
+		t.Fatal("non key=value output produced values")
+	}
+}
+
+func TestLinuxHardwareParsers(t *testing.T) {
+	cpuinfo := "processor\t: 0\nvendor_id\t: AuthenticAMD\nmodel name\t: AMD Ryzen 9  7950X 16-Core Processor\n\nprocessor\t: 1\nmodel name\t: ignored\n"
+	if got := parseCPUInfoModel(cpuinfo); got != "AMD Ryzen 9 7950X 16-Core Processor" {
+		t.Fatalf("parseCPUInfoModel() = %q", got)
+	}
+	if got := parseCPUInfoModel("processor\t: 0\nBogoMIPS\t: 108.00\n\nModel\t\t: Raspberry Pi 4 Model B Rev 1.4\n"); got != "Raspberry Pi 4 Model B Rev 1.4" {
+		t.Fatalf("parseCPUInfoModel() ARM fallback = %q", got)
+	}
+
+	osRelease := "NAME=\"Ubuntu\"\nVERSION_ID=\"24.04\"\nPRETTY_NAME=\"Ubuntu 24.04.1 LTS\"\n"
+	if got := parseOSReleasePrettyName(osRelease); got != "Ubuntu 24.04.1 LTS" {
+		t.Fatalf("parseOSReleasePrettyName() = %q", got)
+	}
+
+	tests := []struct{ vendor, product, want string }{
+		{"LENOVO", "ThinkPad X1 Carbon Gen 11", "LENOVO ThinkPad X1 Carbon Gen 11"},
+		{"HP", "HP EliteBook 840 G8", "HP EliteBook 840 G8"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := linuxHardwareModel(tt.vendor, tt.product); got != tt.want {
+			t.Fatalf("linuxHardwareModel(%q, %q) = %q, want %q", tt.vendor, tt.product, got, tt.want)
+		}
+	}
+}
+
+func TestReadDMIFieldSkipsPlaceholders(t *testing.T) {
+	orig := dmiRoot
+	dmiRoot = t.TempDir()
+	defer func() { dmiRoot = orig }()
+
+	if err := os.WriteFile(filepath.Join(dmiRoot, "product_name"), []byte("To Be Filled By O.E.M.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dmiRoot, "sys_vendor"), []byte("Dell Inc.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDMIField("product_name"); got != "" {
+		t.Fatalf("placeholder product = %q", got)
+	}
+	if got := readDMIField("sys_vendor"); got != "Dell Inc." {
+		t.Fatalf("sys_vendor = %q", got)
+	}
+}
+
+func TestParseMacHardwareModel(t *testing.T) {
+	out := `Hardware:
+
+    Hardware Overview:
+
+      Model Name: MacBook Pro
+      Model Identifier: Mac15,7
+      Chip: Apple M3 Pro
+      Total Number of Cores: 12 (6 performance and 6 efficiency)
+`
+	if model, cpu := parseMacHardwareModel(out); model != "MacBook Pro" || cpu != "Apple M3 Pro" {
+		t.Fatalf("parseMacHardwareModel() = %q, %q", model, cpu)
+	}
+	intel := "      Model Name: iMac\n      Processor Name: 8-Core Intel Core i7\n"
+	if _, cpu := parseMacHardwareModel(intel); cpu != "8-Core Intel Core i7" {
+		t.Fatalf("parseMacHardwareModel(intel) cpu = %q", cpu)
+	}
+}
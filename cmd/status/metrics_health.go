@@ -5,19 +5,58 @@ import (
 	"strings"
 )
 
-// Health score weights and thresholds.
-const (
-	// Weights.
-	healthCPUWeight     = 30.0
-	healthMemWeight     = 25.0
-	healthDiskWeight    = 20.0
-	healthThermalWeight = 15.0
-	healthIOWeight      = 10.0
+// healthWeights caps the penalty each component can take off the score.
+// The weights sum to 100; the config's health_weights overrides them. GPU
+// is off by default and meant for GPU-heavy workstations.
+type healthWeights struct {
+	CPU     float64
+	Memory  float64
+	Disk    float64
+	Thermal float64
+	IO      float64
+	GPU     float64
+}
+
+var defaultHealthWeights = healthWeights{CPU: 30, Memory: 25, Disk: 20, Thermal: 15, IO: 10}
+
+// healthWeightKeys are the config keys for the weights, in struct order.
+var healthWeightKeys = []string{"cpu", "memory", "disk", "thermal", "io", "gpu"}
+
+// resolveHealthWeights applies config overrides (e.g. {"cpu": 50, "disk": 5})
+// to the defaults and rescales the result to sum to 100, so the score keeps
+// its 0-100 range whatever numbers the user picks.
+func resolveHealthWeights(overrides map[string]float64) (healthWeights, error) {
+	w := defaultHealthWeights
+	fields := map[string]*float64{"cpu": &w.CPU, "memory": &w.Memory, "disk": &w.Disk, "thermal": &w.Thermal, "io": &w.IO, "gpu": &w.GPU}
+	for key, value := range overrides {
+		field, ok := fields[key]
+		if !ok {
+			return defaultHealthWeights, fmt.Errorf("health_weights: unknown component %q (want one of %s)", key, strings.Join(healthWeightKeys, ", "))
+		}
+		if value < 0 {
+			return defaultHealthWeights, fmt.Errorf("health_weights.%s must be >= 0", key)
+		}
+		*field = value
+	}
+	total := w.CPU + w.Memory + w.Disk + w.Thermal + w.IO + w.GPU
+	if total <= 0 {
+		return defaultHealthWeights, fmt.Errorf("health_weights: at least one weight must be positive")
+	}
+	scale := 100 / total
+	return healthWeights{CPU: w.CPU * scale, Memory: w.Memory * scale, Disk: w.Disk * scale, Thermal: w.Thermal * scale, IO: w.IO * scale, GPU: w.GPU * scale}, nil
+}
 
+// Health score thresholds.
+const (
 	// CPU.
 	cpuNormalThreshold = 50.0
 	cpuHighThreshold   = 85.0
 
+	// Load average per logical CPU: above 1 work is queueing for a core.
+	loadNormalPerCore = 1.0
+	loadHighPerCore   = 2.0
+	loadMaxPerCore    = 4.0 // Load at which the penalty takes the full CPU weight
+
 	// Memory.
 	memNormalThreshold     = 70.0
 	memHighThreshold       = 88.0
@@ -31,6 +70,12 @@ const (
 	// Thermal.
 	thermalNormalThreshold = 65.0
 	thermalHighThreshold   = 85.0
+	gpuTempNormalThreshold = 75.0 // GPUs run hotter than CPUs under normal load
+	gpuTempHighThreshold   = 90.0
+
+	// GPU utilization, only scored when the gpu weight is set.
+	gpuUsageNormalThreshold = 80.0
+	gpuUsageHighThreshold   = 95.0
 
 	// Disk IO (MB/s).
 	ioNormalThreshold = 50.0
@@ -54,9 +99,26 @@ const (
 	scoreFairThreshold      = 45
 )
 
-func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, diskIO DiskIOStatus, thermal ThermalStatus, batteries []BatteryStatus, uptimeSecs uint64) (int, string) {
+// HealthBreakdown is what the health score lost and why. The penalties are
+// points off 100; Memory includes pressure and swap, Other covers battery
+// wear and uptime.
+type HealthBreakdown struct {
+	CPUPenalty     float64  `json:"cpu_penalty"`
+	MemoryPenalty  float64  `json:"memory_penalty"`
+	DiskPenalty    float64  `json:"disk_penalty"`
+	ThermalPenalty float64  `json:"thermal_penalty"`
+	IOPenalty      float64  `json:"io_penalty"`
+	GPUPenalty     float64  `json:"gpu_penalty"`
+	OtherPenalty   float64  `json:"other_penalty"`
+	Issues         []string `json:"issues"`
+}
+
+// scoreHealth returns the 0-100 health score, its summary message, and the
+// per-component breakdown.
+func scoreHealth(w healthWeights, cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, diskIO DiskIOStatus, thermal ThermalStatus, gpus []GPUStatus, batteries []BatteryStatus, uptimeSecs uint64) (int, string, HealthBreakdown) {
 	score := 100.0
 	issues := []string{}
+	otherPenalty := 0.0
 
 	// CPU penalty.
 	cpuPenalty := 0.0
@@ -66,15 +128,38 @@ func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, d
 			// growing with usage (matches the disk branch). Dividing by the raw
 			// high threshold instead made the penalty drop past 85%, letting the
 			// score rise as CPU load got worse.
-			cpuPenalty = healthCPUWeight * (cpu.Usage - cpuNormalThreshold) / (100 - cpuNormalThreshold)
+			cpuPenalty = w.CPU * (cpu.Usage - cpuNormalThreshold) / (100 - cpuNormalThreshold)
+		} else {
+			cpuPenalty = (w.CPU / 2) * (cpu.Usage - cpuNormalThreshold) / (cpuHighThreshold - cpuNormalThreshold)
+		}
+	}
+	// Sustained load shares the CPU weight with instantaneous usage: a short
+	// sample can miss a run queue that has been saturated for a minute.
+	loadPenalty := 0.0
+	loadPerCore := 0.0
+	if cpu.LogicalCPU > 0 {
+		loadPerCore = cpu.Load1 / float64(cpu.LogicalCPU)
+	}
+	if loadPerCore > loadNormalPerCore {
+		if loadPerCore > loadHighPerCore {
+			over := min((loadPerCore-loadHighPerCore)/(loadMaxPerCore-loadHighPerCore), 1)
+			loadPenalty = w.CPU/2 + (w.CPU/2)*over
 		} else {
-			cpuPenalty = (healthCPUWeight / 2) * (cpu.Usage - cpuNormalThreshold) / (cpuHighThreshold - cpuNormalThreshold)
+			loadPenalty = (w.CPU / 2) * (loadPerCore - loadNormalPerCore) / (loadHighPerCore - loadNormalPerCore)
 		}
 	}
+	cpuPenalty = max(cpuPenalty, loadPenalty)
 	score -= cpuPenalty
 	if cpu.Usage > cpuHighThreshold {
 		issues = append(issues, "High CPU")
 	}
+	// Every load penalty names its reason, so a score that drops for load
+	// between one and two runnable tasks per core is not left unexplained.
+	if loadPerCore > loadHighPerCore {
+		issues = append(issues, "High Load")
+	} else if loadPenalty > 0 {
+		issues = append(issues, "Elevated Load")
+	}
 
 	// Memory penalty.
 	memPenalty := 0.0
@@ -84,9 +169,9 @@ func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, d
 			// growing with usage (matches the disk branch). Dividing by the raw
 			// normal threshold instead made the penalty drop past 88%, letting
 			// the score rise as memory pressure got worse.
-			memPenalty = healthMemWeight * (mem.UsedPercent - memNormalThreshold) / (100 - memNormalThreshold)
+			memPenalty = w.Memory * (mem.UsedPercent - memNormalThreshold) / (100 - memNormalThreshold)
 		} else {
-			memPenalty = (healthMemWeight / 2) * (mem.UsedPercent - memNormalThreshold) / (memHighThreshold - memNormalThreshold)
+			memPenalty = (w.Memory / 2) * (mem.UsedPercent - memNormalThreshold) / (memHighThreshold - memNormalThreshold)
 		}
 	}
 	score -= memPenalty
@@ -97,75 +182,142 @@ func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, d
 	// Memory pressure penalty.
 	switch mem.Pressure {
 	case "warn":
+		memPenalty += memPressureWarnPenalty
 		score -= memPressureWarnPenalty
 		issues = append(issues, "Memory Pressure")
 	case "critical":
+		memPenalty += memPressureCritPenalty
 		score -= memPressureCritPenalty
 		issues = append(issues, "Critical Memory")
 	}
 
-	// Disk penalty.
+	// Rapidly growing swap files mean the system is paging hard even when
+	// the used percentage still looks moderate.
+	if mem.SwapGrowing {
+		memPenalty += swapGrowthPenalty
+		score -= swapGrowthPenalty
+		issues = append(issues, "Swap Growing")
+	}
+
+	// Sustained swap-outs are the clearest sign of thrashing: occupancy can
+	// sit high for hours after a spike, but pages only move under pressure.
+	if mem.SwapOutRate >= swapOutThrashRate {
+		memPenalty += swapOutPenalty
+		score -= swapOutPenalty
+		issues = append(issues, "Swapping")
+	}
+
+	// Disk penalty. Running out of inodes fails writes just like running
+	// out of space, so the fuller of the two sets the penalty.
 	diskPenalty := 0.0
 	if len(disks) > 0 {
-		diskUsage := disks[0].UsedPercent
+		diskUsage := max(disks[0].UsedPercent, disks[0].InodesUsedPercent)
 		if diskUsage > diskWarnThreshold {
 			if diskUsage > diskCritThreshold {
-				diskPenalty = healthDiskWeight * (diskUsage - diskWarnThreshold) / (100 - diskWarnThreshold)
+				diskPenalty = w.Disk * (diskUsage - diskWarnThreshold) / (100 - diskWarnThreshold)
 			} else {
-				diskPenalty = (healthDiskWeight / 2) * (diskUsage - diskWarnThreshold) / (diskCritThreshold - diskWarnThreshold)
+				diskPenalty = (w.Disk / 2) * (diskUsage - diskWarnThreshold) / (diskCritThreshold - diskWarnThreshold)
 			}
 		}
 		score -= diskPenalty
-		if diskUsage > diskCritThreshold {
+		if disks[0].UsedPercent > diskCritThreshold {
 			issues = append(issues, "Disk Almost Full")
 		}
+		if disks[0].InodesUsedPercent > diskCritThreshold {
+			issues = append(issues, "Inodes Almost Full")
+		}
 	}
 
-	// Thermal penalty.
+	// Thermal penalty. CPU and GPU share the thermal weight; the hotter of
+	// the two (relative to its own thresholds) sets the penalty.
 	thermalPenalty := 0.0
 	if thermal.CPUTemp > 0 {
 		if thermal.CPUTemp > thermalNormalThreshold {
 			if thermal.CPUTemp > thermalHighThreshold {
-				thermalPenalty = healthThermalWeight
+				thermalPenalty = w.Thermal
 				issues = append(issues, "Overheating")
 			} else {
-				thermalPenalty = healthThermalWeight * (thermal.CPUTemp - thermalNormalThreshold) / (thermalHighThreshold - thermalNormalThreshold)
+				thermalPenalty = w.Thermal * (thermal.CPUTemp - thermalNormalThreshold) / (thermalHighThreshold - thermalNormalThreshold)
 			}
 		}
-		score -= thermalPenalty
 	}
+	if thermal.GPUTemp > gpuTempNormalThreshold {
+		if thermal.GPUTemp > gpuTempHighThreshold {
+			thermalPenalty = w.Thermal
+			issues = append(issues, "GPU Overheating")
+		} else {
+			thermalPenalty = max(thermalPenalty, w.Thermal*(thermal.GPUTemp-gpuTempNormalThreshold)/(gpuTempHighThreshold-gpuTempNormalThreshold))
+		}
+	}
+	// Throttling is the thermal problem actually costing performance, so it
+	// takes the whole thermal weight whatever the temperature reads.
+	if thermal.Throttling {
+		thermalPenalty = w.Thermal
+		issues = append(issues, "Throttling")
+	}
+	score -= thermalPenalty
 
 	// Disk IO penalty.
 	ioPenalty := 0.0
 	totalIO := diskIO.ReadRate + diskIO.WriteRate
 	if totalIO > ioNormalThreshold {
 		if totalIO > ioHighThreshold {
-			ioPenalty = healthIOWeight
+			ioPenalty = w.IO
 			issues = append(issues, "Heavy Disk IO")
 		} else {
-			ioPenalty = healthIOWeight * (totalIO - ioNormalThreshold) / (ioHighThreshold - ioNormalThreshold)
+			ioPenalty = w.IO * (totalIO - ioNormalThreshold) / (ioHighThreshold - ioNormalThreshold)
 		}
 	}
 	score -= ioPenalty
 
+	// GPU penalty: the busiest or hottest card, whichever is worse. Usage
+	// only takes half the weight, since a saturated GPU is often the point
+	// of a render or training box; heat is the real problem. Overheating is
+	// already reported by the thermal check.
+	gpuPenalty := 0.0
+	if w.GPU > 0 {
+		busiest := 0.0
+		for _, g := range gpus {
+			if gpuHasLiveUsage(g) {
+				busiest = max(busiest, g.Usage)
+			}
+			if g.Temperature > gpuTempNormalThreshold {
+				over := min((g.Temperature-gpuTempNormalThreshold)/(gpuTempHighThreshold-gpuTempNormalThreshold), 1)
+				gpuPenalty = max(gpuPenalty, w.GPU*over)
+			}
+		}
+		if busiest > gpuUsageNormalThreshold {
+			over := min((busiest-gpuUsageNormalThreshold)/(gpuUsageHighThreshold-gpuUsageNormalThreshold), 1)
+			gpuPenalty = max(gpuPenalty, (w.GPU/2)*over)
+		}
+		if busiest > gpuUsageHighThreshold {
+			issues = append(issues, "GPU Saturated")
+		}
+	}
+	score -= gpuPenalty
+
 	// Battery health penalty (only when battery present).
 	if len(batteries) > 0 {
 		b := batteries[0]
 		_, sev := batteryHealthLabel(b.CycleCount, b.Capacity)
 		switch sev {
 		case "danger":
+			otherPenalty += 5
 			score -= 5
 			issues = append(issues, "Battery Service Soon")
 		case "warn":
+			otherPenalty += 2
 			score -= 2
 		}
 	}
 
 	// Uptime penalty (long uptime without restart).
 	if uptimeSecs > uptimeDangerSecs {
+		otherPenalty += 3
 		score -= 3
 		issues = append(issues, "Restart Recommended")
 	} else if uptimeSecs > uptimeWarnSecs {
+		otherPenalty++
 		score -= 1
 	}
 
@@ -194,7 +346,16 @@ func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, d
 		msg = msg + ": " + strings.Join(issues, ", ")
 	}
 
-	return int(score), msg
+	return int(score), msg, HealthBreakdown{
+		CPUPenalty:     cpuPenalty,
+		MemoryPenalty:  memPenalty,
+		DiskPenalty:    diskPenalty,
+		ThermalPenalty: thermalPenalty,
+		IOPenalty:      ioPenalty,
+		GPUPenalty:     gpuPenalty,
+		OtherPenalty:   otherPenalty,
+		Issues:         issues,
+	}
 }
 
 // batteryHealthLabel returns a human-readable health label and severity based on cycle count and capacity.
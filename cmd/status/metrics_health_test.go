@@ -1,18 +1,20 @@
 package main
 
 import (
+	"math"
 	"strings"
 	"testing"
 )
 
 func TestCalculateHealthScorePerfect(t *testing.T) {
-	score, msg := calculateHealthScore(
+	score, msg, _ := scoreHealth(
+		defaultHealthWeights,
 		CPUStatus{Usage: 10},
 		MemoryStatus{UsedPercent: 20, Pressure: "normal"},
 		[]DiskStatus{{UsedPercent: 30}},
 		DiskIOStatus{ReadRate: 5, WriteRate: 5},
 		ThermalStatus{CPUTemp: 40},
-		nil, 0,
+		nil, nil, 0,
 	)
 
 	if score != 100 {
@@ -24,13 +26,14 @@ func TestCalculateHealthScorePerfect(t *testing.T) {
 }
 
 func TestCalculateHealthScoreDetectsIssues(t *testing.T) {
-	score, msg := calculateHealthScore(
+	score, msg, _ := scoreHealth(
+		defaultHealthWeights,
 		CPUStatus{Usage: 95},
 		MemoryStatus{UsedPercent: 95, Pressure: "critical"},
 		[]DiskStatus{{UsedPercent: 98}},
 		DiskIOStatus{ReadRate: 120, WriteRate: 80},
 		ThermalStatus{CPUTemp: 90},
-		nil, 0,
+		nil, nil, 0,
 	)
 
 	if score >= 60 {
@@ -52,13 +55,14 @@ func TestCalculateHealthScoreMonotonicInCPU(t *testing.T) {
 	// across the high-usage threshold at 85%.
 	prev := 101
 	for usage := 40.0; usage <= 100.0; usage += 0.5 {
-		score, _ := calculateHealthScore(
+		score, _, _ := scoreHealth(
+			defaultHealthWeights,
 			CPUStatus{Usage: usage},
 			MemoryStatus{UsedPercent: 20, Pressure: "normal"},
 			[]DiskStatus{{UsedPercent: 30}},
 			DiskIOStatus{ReadRate: 5, WriteRate: 5},
 			ThermalStatus{CPUTemp: 40},
-			nil, 0,
+			nil, nil, 0,
 		)
 		if score > prev {
 			t.Fatalf("health score rose from %d to %d as CPU usage increased to %.1f%%", prev, score, usage)
@@ -67,18 +71,72 @@ func TestCalculateHealthScoreMonotonicInCPU(t *testing.T) {
 	}
 }
 
+func TestCalculateHealthScorePenalizesHotGPU(t *testing.T) {
+	score := func(gpuTemp float64) (int, string) {
+		got, msg, _ := scoreHealth(
+			defaultHealthWeights,
+			CPUStatus{Usage: 10},
+			MemoryStatus{UsedPercent: 20, Pressure: "normal"},
+			[]DiskStatus{{UsedPercent: 30}},
+			DiskIOStatus{},
+			ThermalStatus{CPUTemp: 40, GPUTemp: gpuTemp},
+			nil, nil, 0,
+		)
+		return got, msg
+	}
+	if got, _ := score(gpuTempNormalThreshold); got != 100 {
+		t.Fatalf("GPU at its normal threshold scored %d, want 100", got)
+	}
+	warm, _ := score((gpuTempNormalThreshold + gpuTempHighThreshold) / 2)
+	hot, msg := score(gpuTempHighThreshold + 5)
+	if !(hot < warm && warm < 100) {
+		t.Fatalf("GPU penalty not increasing: warm=%d hot=%d", warm, hot)
+	}
+	if hot != 100-int(defaultHealthWeights.Thermal) || !strings.Contains(msg, "GPU Overheating") {
+		t.Fatalf("hot GPU = %d %q", hot, msg)
+	}
+}
+
+func TestCalculateHealthScorePenalizesInodeExhaustion(t *testing.T) {
+	score := func(inodes float64) (int, string) {
+		got, msg, _ := scoreHealth(
+			defaultHealthWeights,
+			CPUStatus{Usage: 10},
+			MemoryStatus{UsedPercent: 20, Pressure: "normal"},
+			[]DiskStatus{{UsedPercent: 30, InodesUsedPercent: inodes}},
+			DiskIOStatus{},
+			ThermalStatus{CPUTemp: 40},
+			nil, nil, 0,
+		)
+		return got, msg
+	}
+	if got, _ := score(50); got != 100 {
+		t.Fatalf("half-used inodes scored %d, want 100", got)
+	}
+	full, msg := score(98)
+	if full >= 100 || !strings.Contains(msg, "Inodes Almost Full") || strings.Contains(msg, "Disk Almost Full") {
+		t.Fatalf("exhausted inodes = %d %q", full, msg)
+	}
+
+	card := renderDiskCard([]DiskStatus{{Mount: "/", Used: 1 << 30, Total: 4 << 30, UsedPercent: 25, InodesUsedPercent: 92}}, DiskIOStatus{}, 0, false, false)
+	if got := stripANSI(card.lines[1]); got != "Inodes 92%" {
+		t.Fatalf("inode warning line = %q", got)
+	}
+}
+
 func TestCalculateHealthScoreMonotonicInMemory(t *testing.T) {
 	// Rising memory usage must never improve (raise) the health score, including
 	// across the high-usage threshold at 88%.
 	prev := 101
 	for usage := 60.0; usage <= 100.0; usage += 0.5 {
-		score, _ := calculateHealthScore(
+		score, _, _ := scoreHealth(
+			defaultHealthWeights,
 			CPUStatus{Usage: 10},
 			MemoryStatus{UsedPercent: usage, Pressure: "normal"},
 			[]DiskStatus{{UsedPercent: 30}},
 			DiskIOStatus{ReadRate: 5, WriteRate: 5},
 			ThermalStatus{CPUTemp: 40},
-			nil, 0,
+			nil, nil, 0,
 		)
 		if score > prev {
 			t.Fatalf("health score rose from %d to %d as memory usage increased to %.1f%%", prev, score, usage)
@@ -202,9 +260,9 @@ func TestCalculateHealthScoreEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score, _ := calculateHealthScore(tt.cpu, tt.mem, tt.disks, tt.diskIO, tt.thermal, nil, 0)
+			score, _, _ := scoreHealth(defaultHealthWeights, tt.cpu, tt.mem, tt.disks, tt.diskIO, tt.thermal, nil, nil, 0)
 			if score < tt.wantMin || score > tt.wantMax {
-				t.Errorf("calculateHealthScore() = %d, want range [%d, %d]", score, tt.wantMin, tt.wantMax)
+				t.Errorf("scoreHealth() = %d, want range [%d, %d]", score, tt.wantMin, tt.wantMax)
 			}
 		})
 	}
@@ -261,10 +319,11 @@ func TestUptimeSeverity(t *testing.T) {
 
 func TestHealthScoreBatteryPenalty(t *testing.T) {
 	base := func(batts []BatteryStatus, uptime uint64) int {
-		s, _ := calculateHealthScore(
+		s, _, _ := scoreHealth(
+			defaultHealthWeights,
 			CPUStatus{Usage: 10}, MemoryStatus{UsedPercent: 20},
 			[]DiskStatus{{UsedPercent: 30}}, DiskIOStatus{ReadRate: 5, WriteRate: 5},
-			ThermalStatus{CPUTemp: 40}, batts, uptime,
+			ThermalStatus{CPUTemp: 40}, nil, batts, uptime,
 		)
 		return s
 	}
@@ -307,3 +366,100 @@ func TestFormatUptimeEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveHealthWeightsNormalizesOverrides(t *testing.T) {
+	if w, err := resolveHealthWeights(nil); err != nil || w != defaultHealthWeights {
+		t.Fatalf("no overrides = %+v, %v; want defaults", w, err)
+	}
+
+	// CPU 70 + 25 + 20 + 15 + 10 = 140, rescaled to 100.
+	w, err := resolveHealthWeights(map[string]float64{"cpu": 70})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := w.CPU + w.Memory + w.Disk + w.Thermal + w.IO; math.Abs(sum-100) > 1e-9 || math.Abs(w.CPU-50) > 1e-9 {
+		t.Fatalf("weights = %+v (sum %v), want CPU 50 and sum 100", w, sum)
+	}
+
+	hot := ThermalStatus{CPUTemp: 95}
+	base, _, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, hot, nil, nil, 0)
+	heavy, _, _ := scoreHealth(healthWeights{Thermal: 60, CPU: 40}, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, hot, nil, nil, 0)
+	if base != 85 || heavy != 40 {
+		t.Fatalf("overheating scores = %d default, %d thermal-heavy; want 85 and 40", base, heavy)
+	}
+
+	for _, bad := range []map[string]float64{{"network": 10}, {"cpu": -1}, {"cpu": 0, "memory": 0, "disk": 0, "thermal": 0, "io": 0}} {
+		if _, err := resolveHealthWeights(bad); err == nil {
+			t.Errorf("resolveHealthWeights(%v) should fail", bad)
+		}
+	}
+}
+
+func TestScoreHealthBreakdownAccountsForEveryPoint(t *testing.T) {
+	score, msg, b := scoreHealth(defaultHealthWeights,
+		CPUStatus{Usage: 95},
+		MemoryStatus{UsedPercent: 80, Pressure: "warn", SwapOutRate: swapOutThrashRate},
+		[]DiskStatus{{UsedPercent: 90}},
+		DiskIOStatus{ReadRate: 100},
+		ThermalStatus{CPUTemp: 75},
+		nil, nil,
+		uptimeDangerSecs+1,
+	)
+	total := b.CPUPenalty + b.MemoryPenalty + b.DiskPenalty + b.ThermalPenalty + b.IOPenalty + b.GPUPenalty + b.OtherPenalty
+	if diff := 100 - total - float64(score); diff < 0 || diff >= 1 {
+		t.Fatalf("penalties sum to %.2f but score is %d", total, score)
+	}
+	if b.MemoryPenalty <= memPressureWarnPenalty+swapOutPenalty || b.OtherPenalty != 3 {
+		t.Fatalf("unexpected breakdown %+v", b)
+	}
+	if want := "High CPU, Memory Pressure, Swapping, Restart Recommended"; strings.Join(b.Issues, ", ") != want || !strings.HasSuffix(msg, want) {
+		t.Fatalf("issues = %v, msg = %q", b.Issues, msg)
+	}
+}
+
+func TestCalculateHealthScoreGPUWeightIsOptIn(t *testing.T) {
+	gpus := []GPUStatus{{Name: "RTX 4090", Usage: 40}, {Name: "RTX 4090", Usage: 99, Temperature: 70}}
+	if score, _, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, gpus, nil, 0); score != 100 {
+		t.Fatalf("GPU scored %d with the default weights, want 100", score)
+	}
+
+	w, err := resolveHealthWeights(map[string]float64{"gpu": 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A saturated but cool GPU costs half of the gpu weight (20 after rescaling).
+	score, msg, b := scoreHealth(w, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, gpus, nil, 0)
+	if b.GPUPenalty != w.GPU/2 || score != 90 || !strings.Contains(msg, "GPU Saturated") {
+		t.Fatalf("busy GPU = %d %q, breakdown %+v", score, msg, b)
+	}
+	gpus[0].Temperature = gpuTempHighThreshold + 1
+	if _, _, b := scoreHealth(w, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, gpus, nil, 0); b.GPUPenalty != w.GPU {
+		t.Fatalf("hot GPU penalty = %v, want the full %v", b.GPUPenalty, w.GPU)
+	}
+}
+
+func TestCalculateHealthScorePenalizesSustainedLoad(t *testing.T) {
+	score := func(load1 float64) (int, string) {
+		got, msg, _ := scoreHealth(defaultHealthWeights, CPUStatus{Usage: 20, Load1: load1, LogicalCPU: 8}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil, 0)
+		return got, msg
+	}
+	idle, _ := score(4)
+	busy, busyMsg := score(12)
+	saturated, saturatedMsg := score(24)
+	if idle != 100 || !(busy < idle && saturated < busy) {
+		t.Fatalf("scores for load 4/12/24 on 8 CPUs = %d/%d/%d, want strictly falling from 100", idle, busy, saturated)
+	}
+	if strings.Contains(busyMsg, "High Load") || !strings.Contains(saturatedMsg, "High Load") {
+		t.Fatalf("High Load should appear only above 2 per core: %q / %q", busyMsg, saturatedMsg)
+	}
+	// 1.5 per core costs points, so it must say why.
+	if busy == 100 || !strings.Contains(busyMsg, "Elevated Load") || strings.Contains(saturatedMsg, "Elevated Load") {
+		t.Fatalf("load of 1.5 per core = %d %q, want a penalty with Elevated Load", busy, busyMsg)
+	}
+	if _, idleMsg := score(4); strings.Contains(idleMsg, "Load") {
+		t.Fatalf("load under 1 per core should not be flagged: %q", idleMsg)
+	}
+	if floor, _ := score(1000); floor != 100-int(defaultHealthWeights.CPU) {
+		t.Fatalf("load penalty should cap at the CPU weight, got score %d", floor)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fdWarnPercent  = 80.0 // System-wide open files as a share of the limit
+	entropyLowBits = 200  // Below this, blocking readers of /dev/random may stall
+)
+
+// SystemLimits holds two often-overlooked server health signals: the
+// system-wide open file count against its limit, and (Linux only) the
+// kernel's available entropy. Zero values mean the source was unavailable.
+type SystemLimits struct {
+	OpenFiles      uint64 `json:"open_files"`
+	MaxFiles       uint64 `json:"max_files"`
+	EntropyAvail   int    `json:"entropy_avail"`
+	EntropyPresent bool   `json:"entropy_present"`
+}
+
+var collectSystemLimitsFunc = collectSystemLimits
+
+// collectSystemLimits returns nil where neither signal is readable.
+func collectSystemLimits() *SystemLimits {
+	var limits SystemLimits
+	switch runtime.GOOS {
+	case "linux":
+		sysRoot := filepath.Join(procRoot, "sys")
+		if data, err := os.ReadFile(filepath.Join(sysRoot, "fs", "file-nr")); err == nil {
+			limits.OpenFiles, limits.MaxFiles, _ = parseFileNr(string(data))
+		}
+		if data, err := os.ReadFile(filepath.Join(sysRoot, "kernel", "random", "entropy_avail")); err == nil {
+			if n, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+				limits.EntropyAvail = n
+				limits.EntropyPresent = true
+			}
+		}
+	case "darwin":
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if out, err := runCmd(ctx, "sysctl", "-n", "kern.num_files", "kern.maxfiles"); err == nil {
+			limits.OpenFiles, limits.MaxFiles, _ = parseSysctlFiles(out)
+		}
+	}
+	if limits.MaxFiles == 0 && !limits.EntropyPresent {
+		return nil
+	}
+	return &limits
+}
+
+// parseFileNr reads /proc/sys/fs/file-nr: allocated handles, allocated but
+// unused handles (always 0 on modern kernels), and the maximum.
+func parseFileNr(raw string) (open, limit uint64, ok bool) {
+	fields := strings.Fields(raw)
+	if len(fields) < 3 {
+		return 0, 0, false
+	}
+	allocated, err1 := strconv.ParseUint(fields[0], 10, 64)
+	unused, err2 := strconv.ParseUint(fields[1], 10, 64)
+	limit, err3 := strconv.ParseUint(fields[2], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || unused > allocated {
+		return 0, 0, false
+	}
+	return allocated - unused, limit, true
+}
+
+// parseSysctlFiles reads `sysctl -n kern.num_files kern.maxfiles`, one value
+// per line.
+func parseSysctlFiles(out string) (open, limit uint64, ok bool) {
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return 0, 0, false
+	}
+	open, err1 := strconv.ParseUint(fields[0], 10, 64)
+	limit, err2 := strconv.ParseUint(fields[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return open, limit, true
+}
+
+// FilePercent is open files as a percentage of the limit, or 0 if unknown.
+func (l *SystemLimits) FilePercent() float64 {
+	if l == nil || l.MaxFiles == 0 {
+		return 0
+	}
+	return float64(l.OpenFiles) / float64(l.MaxFiles) * 100
+}
+
+// FilesNearLimit reports whether system-wide open files approach the limit.
+func (l *SystemLimits) FilesNearLimit() bool {
+	return l.FilePercent() >= fdWarnPercent
+}
+
+// EntropyLow reports a starved random pool. Kernels from 5.18 on always
+// report 256, so this only fires on older systems.
+func (l *SystemLimits) EntropyLow() bool {
+	return l != nil && l.EntropyPresent && l.EntropyAvail < entropyLowBits
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFileNr(t *testing.T) {
+	open, limit, ok := parseFileNr("12480\t0\t9223372036854775807\n")
+	if !ok || open != 12480 || limit != 9223372036854775807 {
+		t.Fatalf("parseFileNr() = %d, %d, %v", open, limit, ok)
+	}
+	// Older kernels report freed-but-allocated handles in the second column.
+	if open, _, _ := parseFileNr("5000 1000 100000"); open != 4000 {
+		t.Fatalf("parseFileNr() open = %d, want 4000", open)
+	}
+	if _, _, ok := parseFileNr("garbage"); ok {
+		t.Fatal("parseFileNr() accepted malformed input")
+	}
+}
+
+func TestParseSysctlFiles(t *testing.T) {
+	open, limit, ok := parseSysctlFiles("8342\n122880\n")
+	if !ok || open != 8342 || limit != 122880 {
+		t.Fatalf("parseSysctlFiles() = %d, %d, %v", open, limit, ok)
+	}
+	if _, _, ok := parseSysctlFiles("8342\n"); ok {
+		t.Fatal("parseSysctlFiles() accepted a single value")
+	}
+}
+
+func TestSystemLimitsSurfaceInCardAndDiagnosis(t *testing.T) {
+	if line := formatSystemLimitsLine(nil); line != "" {
+		t.Fatalf("nil limits rendered %q", line)
+	}
+
+	line := stripANSI(formatSystemLimitsLine(&SystemLimits{OpenFiles: 1200, MaxFiles: 100000, EntropyAvail: 256, EntropyPresent: true}))
+	if !strings.HasPrefix(line, "FDs ") || !strings.Contains(line, "1.2K / 100K") || !strings.Contains(line, "entropy 256") {
+		t.Fatalf("limits line = %q", line)
+	}
+	if strings.Contains(line, "near limit") {
+		t.Fatalf("low fd usage flagged: %q", line)
+	}
+
+	near := &SystemLimits{OpenFiles: 90000, MaxFiles: 100000}
+	if line := stripANSI(formatSystemLimitsLine(near)); !strings.Contains(line, "near limit") {
+		t.Fatalf("near-limit line = %q", line)
+	}
+	if got := statusDiagnosisLine(MetricsSnapshot{SystemLimits: near}); got != "Open files at 90% of limit" {
+		t.Fatalf("diagnosis = %q", got)
+	}
+
+	starved := &SystemLimits{EntropyAvail: 40, EntropyPresent: true}
+	if line := stripANSI(formatSystemLimitsLine(starved)); line != "Rand   entropy 40 low" {
+		t.Fatalf("entropy-only line = %q", line)
+	}
+	if got := statusDiagnosisLine(MetricsSnapshot{SystemLimits: starved}); got != "Entropy pool low" {
+		t.Fatalf("diagnosis = %q", got)
+	}
+}
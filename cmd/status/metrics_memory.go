@@ -29,86 +29,126 @@ func collectMemoryWithOptions(includeSlowAnnotations bool) (MemoryStatus, error)
 		swap = &mem.SwapMemoryStat{}
 	}
 	var pressure string
+	var pressurePercent float64
 	if includeSlowAnnotations {
-		pressure = getMemoryPressure()
+		pressure, pressurePercent = getMemoryPressure()
 	}
 
-	// On macOS, vm.Cached is 0, so we calculate from file-backed pages.
+	// On macOS, vm.Cached is 0, so we calculate from file-backed pages, and
+	// compressed memory only shows in vm_stat.
 	cached := vm.Cached
-	if includeSlowAnnotations && runtime.GOOS == "darwin" && cached == 0 {
-		cached = getFileBackedMemory()
+	var compressed uint64
+	if includeSlowAnnotations && runtime.GOOS == "darwin" {
+		if stat, ok := readVMStat(); ok {
+			if cached == 0 {
+				cached = stat.bytes("File-backed pages")
+			}
+			compressed = stat.bytes("Pages occupied by compressor")
+		}
 	}
 
 	return MemoryStatus{
-		Used:        vm.Used,
-		Total:       vm.Total,
-		Available:   vm.Available,
-		UsedPercent: vm.UsedPercent,
-		SwapUsed:    swap.Used,
-		SwapTotal:   swap.Total,
-		Cached:      cached,
-		Pressure:    pressure,
+		Used:            vm.Used,
+		Total:           vm.Total,
+		Available:       vm.Available,
+		UsedPercent:     vm.UsedPercent,
+		SwapUsed:        swap.Used,
+		SwapTotal:       swap.Total,
+		Cached:          cached,
+		Wired:           vm.Wired,
+		Compressed:      compressed,
+		Active:          vm.Active,
+		Pressure:        pressure,
+		PressurePercent: pressurePercent,
 	}, nil
 }
 
-func getFileBackedMemory() uint64 {
+// vmStat holds vm_stat's page counters keyed by label, e.g.
+// "Pages occupied by compressor".
+type vmStat struct {
+	pageSize uint64
+	pages    map[string]uint64
+}
+
+func (v vmStat) bytes(label string) uint64 {
+	return v.pages[label] * v.pageSize
+}
+
+// readVMStat runs vm_stat (macOS); ok is false when it is unavailable.
+func readVMStat() (vmStat, bool) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 	out, err := runCmd(ctx, "vm_stat")
 	if err != nil {
-		return 0
+		return vmStat{}, false
 	}
+	return parseVMStat(out), true
+}
 
-	// Parse page size from first line: "Mach Virtual Memory Statistics: (page size of 16384 bytes)"
-	var pageSize uint64 = 4096 // Default
-	firstLine := true
+func parseVMStat(out string) vmStat {
+	v := vmStat{pageSize: 4096, pages: make(map[string]uint64)}
 	for line := range strings.Lines(out) {
-		if firstLine {
-			firstLine = false
-			if strings.Contains(line, "page size of") {
-				if _, after, found := strings.Cut(line, "page size of "); found {
-					if before, _, found := strings.Cut(after, " bytes"); found {
-						if size, err := strconv.ParseUint(strings.TrimSpace(before), 10, 64); err == nil {
-							pageSize = size
-						}
-					}
+		// Header: "Mach Virtual Memory Statistics: (page size of 16384 bytes)"
+		if _, after, found := strings.Cut(line, "page size of "); found {
+			if before, _, found := strings.Cut(after, " bytes"); found {
+				if size, err := strconv.ParseUint(strings.TrimSpace(before), 10, 64); err == nil {
+					v.pageSize = size
 				}
 			}
+			continue
 		}
-
-		// Parse "File-backed pages: 388975."
-		if strings.Contains(line, "File-backed pages:") {
-			if _, after, found := strings.Cut(line, ":"); found {
-				numStr := strings.TrimSpace(after)
-				numStr = strings.TrimSuffix(numStr, ".")
-				if pages, err := strconv.ParseUint(numStr, 10, 64); err == nil {
-					return pages * pageSize
-				}
-			}
+		// Counters: "File-backed pages:                      388975."
+		label, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if pages, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64); err == nil {
+			v.pages[strings.TrimSpace(label)] = pages
 		}
 	}
-	return 0
+	return v
 }
 
-func getMemoryPressure() string {
+// getMemoryPressure runs memory_pressure (macOS) and returns its level and
+// the pressure percentage; both are empty elsewhere.
+func getMemoryPressure() (string, float64) {
 	if runtime.GOOS != "darwin" {
-		return ""
+		return "", 0
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 	out, err := runCmd(ctx, "memory_pressure")
 	if err != nil {
-		return ""
-	}
-	lower := strings.ToLower(out)
-	if strings.Contains(lower, "critical") {
-		return "critical"
+		return "", 0
 	}
-	if strings.Contains(lower, "warn") {
-		return "warn"
+	return parseMemoryPressure(out)
+}
+
+// parseMemoryPressure classifies memory_pressure output as normal, warn or
+// critical and converts "System-wide memory free percentage: 62%" into a
+// pressure percentage (38). The percentage is 0 when the line is missing.
+func parseMemoryPressure(out string) (string, float64) {
+	var percent float64
+	for line := range strings.Lines(out) {
+		_, value, found := strings.Cut(line, "System-wide memory free percentage:")
+		if !found {
+			continue
+		}
+		free, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+		if err == nil && free >= 0 && free <= 100 {
+			percent = 100 - free
+		}
+		break
 	}
-	if strings.Contains(lower, "normal") {
-		return "normal"
+
+	lower := strings.ToLower(out)
+	switch {
+	case strings.Contains(lower, "critical"):
+		return "critical", percent
+	case strings.Contains(lower, "warn"):
+		return "warn", percent
+	case strings.Contains(lower, "normal"):
+		return "normal", percent
 	}
-	return ""
+	return "", percent
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVMStat(t *testing.T) {
+	out := `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               12345.
+File-backed pages:                       388975.
+Pages occupied by compressor:             65536.
+`
+	stat := parseVMStat(out)
+	if stat.pageSize != 16384 {
+		t.Fatalf("page size = %d, want 16384", stat.pageSize)
+	}
+	if got := stat.bytes("File-backed pages"); got != 388975*16384 {
+		t.Fatalf("file-backed = %d", got)
+	}
+	if got := stat.bytes("Pages occupied by compressor"); got != 1<<30 {
+		t.Fatalf("compressed = %d, want 1 GiB", got)
+	}
+	if got := stat.bytes("Pages purgeable"); got != 0 {
+		t.Fatalf("missing counter = %d, want 0", got)
+	}
+}
+
+func TestParseMemoryPressure(t *testing.T) {
+	out := `The system has 17179869184 (1048576 pages with a page size of 16384).
+
+Stats:
+Pages free: 12345
+Pages purgeable: 678
+
+System-wide memory free percentage: 62%
+`
+	level, percent := parseMemoryPressure(out)
+	if level != "" || percent != 38 {
+		t.Fatalf("parseMemoryPressure() = %q, %v; want \"\", 38", level, percent)
+	}
+
+	level, percent = parseMemoryPressure("The system memory pressure is warn\nSystem-wide memory free percentage: 14%\n")
+	if level != "warn" || percent != 86 {
+		t.Fatalf("parseMemoryPressure(warn) = %q, %v", level, percent)
+	}
+	if level, percent := parseMemoryPressure("normal"); level != "normal" || percent != 0 {
+		t.Fatalf("parseMemoryPressure(no percentage) = %q, %v", level, percent)
+	}
+}
+
+func TestRenderMemoryCardShowsPressurePercent(t *testing.T) {
+	card := renderMemoryCard(MemoryStatus{Total: 16 << 30, Pressure: "normal", PressurePercent: 38}, 60, false)
+	last := stripANSI(card.lines[len(card.lines)-1])
+	if last != "Status normal · 38% pressure" {
+		t.Fatalf("pressure line = %q", last)
+	}
+}
+
+func TestFormatMemoryBreakdownLine(t *testing.T) {
+	mem := MemoryStatus{Wired: 3 << 30, Compressed: 1 << 30, Active: 6 << 30}
+	line := stripANSI(formatMemoryBreakdownLine(mem, 60))
+	if !strings.HasPrefix(line, "Wired ") || !strings.Contains(line, "Comp ") || !strings.Contains(line, "Active ") {
+		t.Fatalf("breakdown = %q", line)
+	}
+	narrow := stripANSI(formatMemoryBreakdownLine(mem, 20))
+	if strings.Contains(narrow, "Active") || !strings.HasPrefix(narrow, "Wired ") {
+		t.Fatalf("narrow breakdown = %q", narrow)
+	}
+	if got := formatMemoryBreakdownLine(MemoryStatus{Wired: 3 << 30}, 60); !strings.Contains(stripANSI(got), "Wired") || strings.Contains(got, "Comp") {
+		t.Fatalf("zero parts not skipped: %q", got)
+	}
+	if got := formatMemoryBreakdownLine(MemoryStatus{}, 60); got != "" {
+		t.Fatalf("empty breakdown = %q", got)
+	}
+}
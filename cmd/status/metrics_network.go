@@ -3,25 +3,33 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/shirou/gopsutil/v4/net"
 )
 
-var ioCountersFunc = net.IOCounters
+var (
+	ioCountersFunc     = net.IOCounters
+	defaultRouteIfFunc = defaultRouteInterface
+)
 
 const (
 	minNetworkSampleInterval = 100 * time.Millisecond
 	networkIPCacheTTL        = 10 * time.Second
 )
 
-var noiseInterfacePrefixes = [...]string{"lo", "awdl", "utun", "llw", "bridge", "gif", "stf", "xhc", "anpi", "ap"}
+// defaultNoiseInterfacePrefixes name loopback, tunnel, and Apple-internal
+// interfaces the network card hides; --hide-interfaces changes the list.
+var defaultNoiseInterfacePrefixes = []string{"lo", "awdl", "utun", "llw", "bridge", "gif", "stf", "xhc", "anpi", "ap"}
 
 func collectIOCountersSafely() (stats []net.IOCountersStat, err error) {
 	defer func() {
@@ -41,6 +49,20 @@ func (c *Collector) primeNetworkCounters(now time.Time) {
 	for _, s := range stats {
 		c.prevNet[s.Name] = s
 	}
+	c.recordNetBaseline(stats)
+}
+
+// recordNetBaseline keeps the first counters seen for each interface, so
+// session totals start at zero, including for interfaces that appear later.
+func (c *Collector) recordNetBaseline(stats []net.IOCountersStat) {
+	if c.netBaseline == nil {
+		c.netBaseline = make(map[string]net.IOCountersStat)
+	}
+	for _, s := range stats {
+		if _, ok := c.netBaseline[s.Name]; !ok {
+			c.netBaseline[s.Name] = s
+		}
+	}
 }
 
 func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
@@ -64,7 +86,8 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 	}
 
 	// Map interface IPs.
-	ifAddrs := c.getInterfaceIPsCached(now)
+	ifAddrs, ifAddrs6 := c.getInterfaceIPsCached(now)
+	primary := c.primaryInterfaceCached(now)
 
 	if c.lastNetAt.IsZero() {
 		c.lastNetAt = now
@@ -72,6 +95,8 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 			c.prevNet[s.Name] = s
 		}
 	}
+	c.recordNetBaseline(stats)
+	c.noteTunnelTraffic(now, stats)
 
 	elapsed := now.Sub(c.lastNetAt).Seconds()
 	if elapsed < minNetworkSampleInterval.Seconds() {
@@ -80,7 +105,7 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 
 	var result []NetworkStatus
 	for _, cur := range stats {
-		if isNoiseInterface(cur.Name) {
+		if isNoiseInterface(cur.Name, c.noisePrefixes) && cur.Name != primary {
 			continue
 		}
 		prev, ok := c.prevNet[cur.Name]
@@ -89,11 +114,21 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 		}
 		rx := float64(counterDelta(cur.BytesRecv, prev.BytesRecv)) / 1024.0 / 1024.0 / elapsed
 		tx := float64(counterDelta(cur.BytesSent, prev.BytesSent)) / 1024.0 / 1024.0 / elapsed
+		if wrx, wtx, ok := c.windowedNetRate(cur.Name, counterSample{at: now, in: cur.BytesRecv, out: cur.BytesSent}); ok {
+			rx, tx = wrx, wtx
+		}
+		base := c.netBaseline[cur.Name]
 		result = append(result, NetworkStatus{
-			Name:      cur.Name,
-			RxRateMBs: rx,
-			TxRateMBs: tx,
-			IP:        ifAddrs[cur.Name],
+			Name:         cur.Name,
+			RxRateMBs:    rx,
+			TxRateMBs:    tx,
+			RxBootBytes:  cur.BytesRecv,
+			TxBootBytes:  cur.BytesSent,
+			RxTotalBytes: counterDelta(cur.BytesRecv, base.BytesRecv),
+			TxTotalBytes: counterDelta(cur.BytesSent, base.BytesSent),
+			IP:           ifAddrs[cur.Name],
+			IPv6:         ifAddrs6[cur.Name],
+			Primary:      cur.Name == primary,
 		})
 	}
 
@@ -102,7 +137,12 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 		c.prevNet[s.Name] = s
 	}
 
+	// The primary interface always leads (and survives the cut to three);
+	// the rest are ordered by traffic.
 	sort.Slice(result, func(i, j int) bool {
+		if result[i].Primary != result[j].Primary {
+			return result[i].Primary
+		}
 		return result[i].RxRateMBs+result[i].TxRateMBs > result[j].RxRateMBs+result[j].TxRateMBs
 	})
 	if len(result) > 3 {
@@ -122,37 +162,123 @@ func (c *Collector) collectNetwork(now time.Time) []NetworkStatus {
 	return result
 }
 
-func (c *Collector) getInterfaceIPsCached(now time.Time) map[string]string {
+func (c *Collector) getInterfaceIPsCached(now time.Time) (v4, v6 map[string]string) {
 	if c.cachedNetIPs != nil && now.Sub(c.lastNetIPAt) < networkIPCacheTTL {
-		return c.cachedNetIPs
+		return c.cachedNetIPs, c.cachedNetIPv6
 	}
-	c.cachedNetIPs = getInterfaceIPs()
+	c.cachedNetIPs, c.cachedNetIPv6 = getInterfaceIPs()
 	c.lastNetIPAt = now
-	return c.cachedNetIPs
+	return c.cachedNetIPs, c.cachedNetIPv6
+}
+
+// primaryInterfaceCached returns the config's primary_interface when set,
+// otherwise the default-route interface, re-detected with the IP cache.
+func (c *Collector) primaryInterfaceCached(now time.Time) string {
+	if c.primaryInterface != "" {
+		return c.primaryInterface
+	}
+	if !c.lastPrimaryAt.IsZero() && now.Sub(c.lastPrimaryAt) < networkIPCacheTTL {
+		return c.cachedPrimary
+	}
+	c.cachedPrimary = defaultRouteIfFunc()
+	c.lastPrimaryAt = now
+	return c.cachedPrimary
+}
+
+// defaultRouteInterface names the interface carrying the IPv4 default route,
+// or "" when it cannot be determined.
+func defaultRouteInterface() string {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile(procRoot + "/net/route")
+		if err != nil {
+			return ""
+		}
+		return parseProcNetRoute(string(data))
+	case "darwin":
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		out, err := runCmd(ctx, "route", "-n", "get", "default")
+		if err != nil {
+			return ""
+		}
+		return parseRouteGetInterface(out)
+	}
+	return ""
 }
 
-func getInterfaceIPs() map[string]string {
-	result := make(map[string]string)
+// parseProcNetRoute finds the default (destination 00000000) route with the
+// lowest metric in /proc/net/route.
+func parseProcNetRoute(raw string) string {
+	best, bestMetric := "", -1
+	for line := range strings.Lines(raw) {
+		fields := strings.Fields(line)
+		if len(fields) < 7 || fields[1] != "00000000" {
+			continue
+		}
+		metric, err := strconv.Atoi(fields[6])
+		if err != nil {
+			continue
+		}
+		if bestMetric < 0 || metric < bestMetric {
+			best, bestMetric = fields[0], metric
+		}
+	}
+	return best
+}
+
+// parseRouteGetInterface reads the "interface: en0" line of `route -n get default`.
+func parseRouteGetInterface(out string) string {
+	for line := range strings.Lines(out) {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "interface:"); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func getInterfaceIPs() (v4, v6 map[string]string) {
 	ifaces, err := net.Interfaces()
 	if err != nil {
-		return result
+		return make(map[string]string), make(map[string]string)
 	}
+	return interfaceIPs(ifaces)
+}
+
+// interfaceIPs picks each interface's first non-loopback IPv4 address and
+// first global IPv6 address. Link-local (fe80::), loopback and unique-local
+// (fc00::/7) IPv6 addresses are skipped as they are not reachable from
+// outside the LAN.
+func interfaceIPs(ifaces net.InterfaceStatList) (v4, v6 map[string]string) {
+	v4 = make(map[string]string)
+	v6 = make(map[string]string)
 	for _, iface := range ifaces {
 		for _, addr := range iface.Addrs {
-			// IPv4 only.
-			if strings.Contains(addr.Addr, ".") && !strings.HasPrefix(addr.Addr, "127.") {
-				ip := strings.Split(addr.Addr, "/")[0]
-				result[iface.Name] = ip
-				break
+			a, err := netip.ParseAddr(addr.Addr)
+			if prefix, perr := netip.ParsePrefix(addr.Addr); perr == nil {
+				a, err = prefix.Addr(), nil
+			}
+			if err != nil {
+				continue
+			}
+			switch {
+			case a.Is4():
+				if _, ok := v4[iface.Name]; !ok && !a.IsLoopback() {
+					v4[iface.Name] = a.String()
+				}
+			case a.IsGlobalUnicast() && !a.IsPrivate():
+				if _, ok := v6[iface.Name]; !ok {
+					v6[iface.Name] = a.String()
+				}
 			}
 		}
 	}
-	return result
+	return v4, v6
 }
 
-func isNoiseInterface(name string) bool {
+func isNoiseInterface(name string, prefixes []string) bool {
 	lower := strings.ToLower(name)
-	for _, prefix := range noiseInterfacePrefixes {
+	for _, prefix := range prefixes {
 		if strings.HasPrefix(lower, prefix) {
 			return true
 		}
@@ -160,6 +286,41 @@ func isNoiseInterface(name string) bool {
 	return false
 }
 
+// parseNoiseInterfaces applies a --hide-interfaces list to the defaults.
+// Bare names replace the defaults, "+name" adds a prefix and "-name" drops
+// one, so "-utun" shows VPN tunnels and "+veth" hides container links.
+func parseNoiseInterfaces(spec, source string) ([]string, error) {
+	var bare, added, removed []string
+	for field := range strings.SplitSeq(spec, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		list := &bare
+		switch field[0] {
+		case '+':
+			list, field = &added, field[1:]
+		case '-':
+			list, field = &removed, field[1:]
+		}
+		if field == "" {
+			return nil, fmt.Errorf("invalid %s %q: empty interface prefix", source, spec)
+		}
+		*list = append(*list, field)
+	}
+
+	prefixes := slices.Clone(defaultNoiseInterfacePrefixes)
+	if len(bare) > 0 {
+		prefixes = bare
+	}
+	for _, p := range added {
+		if !slices.Contains(prefixes, p) {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return slices.DeleteFunc(prefixes, func(p string) bool { return slices.Contains(removed, p) }), nil
+}
+
 func collectProxy() ProxyStatus {
 	if proxy := collectProxyFromEnv(os.Getenv); proxy.Enabled {
 		return proxy
@@ -175,7 +336,13 @@ func collectProxy() ProxyStatus {
 				return proxy
 			}
 		}
+	}
 
+	// A WireGuard tunnel also creates a utun device, so check it first.
+	if proxy := collectProxyFromWireGuard(); proxy.Enabled {
+		return proxy
+	}
+	if runtime.GOOS == "darwin" {
 		if proxy := collectProxyFromTunInterfaces(); proxy.Enabled {
 			return proxy
 		}
@@ -184,15 +351,44 @@ func collectProxy() ProxyStatus {
 	return ProxyStatus{Enabled: false}
 }
 
+// collectProxyFromWireGuard asks wireguard-tools for the active tunnels'
+// peer endpoints. wg needs root to read interfaces, so without it this
+// finds nothing.
+func collectProxyFromWireGuard() ProxyStatus {
+	if !commandExists("wg") {
+		return ProxyStatus{Enabled: false}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	out, err := runCmd(ctx, "wg", "show", "all", "endpoints")
+	if err != nil {
+		return ProxyStatus{Enabled: false}
+	}
+	return parseWireGuardEndpoints(out)
+}
+
+// parseWireGuardEndpoints reads `wg show all endpoints`, one peer per line:
+// "wg0<TAB>public key<TAB>203.0.113.5:51820". Peers that have never
+// connected show "(none)" and are skipped.
+func parseWireGuardEndpoints(out string) ProxyStatus {
+	for line := range strings.Lines(out) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[2] == "(none)" {
+			continue
+		}
+		return ProxyStatus{Enabled: true, Type: "WireGuard", Host: fields[2], Interface: fields[0]}
+	}
+	return ProxyStatus{Enabled: false}
+}
+
+// collectProxyFromEnv reads https_proxy, http_proxy and ALL_PROXY (the
+// lowercase spelling wins, as in curl) plus NO_PROXY. Type and Host describe
+// the first proxy found, HTTPS first; Routes keeps every scheme so a setup
+// that proxies only HTTPS, or sends HTTP elsewhere, is not flattened.
 func collectProxyFromEnv(getenv func(string) string) ProxyStatus {
-	// Include ALL_PROXY for users running proxy tools that only export a single variable.
-	envKeys := []string{
-		"https_proxy", "HTTPS_PROXY",
-		"http_proxy", "HTTP_PROXY",
-		"all_proxy", "ALL_PROXY",
-	}
-	for _, key := range envKeys {
-		val := strings.TrimSpace(getenv(key))
+	var proxy ProxyStatus
+	for _, scheme := range []string{"https", "http", "all"} {
+		val := proxyEnv(getenv, scheme+"_proxy")
 		if val == "" {
 			continue
 		}
@@ -207,10 +403,34 @@ func collectProxyFromEnv(getenv func(string) string) ProxyStatus {
 		if host == "" {
 			host = val
 		}
-		return ProxyStatus{Enabled: true, Type: proxyType, Host: host}
+		proxy.Routes = append(proxy.Routes, ProxyRoute{Scheme: scheme, Type: proxyType, Host: host})
+	}
+	if len(proxy.Routes) == 0 {
+		return ProxyStatus{Enabled: false}
 	}
 
-	return ProxyStatus{Enabled: false}
+	proxy.Enabled = true
+	proxy.Type, proxy.Host = proxy.Routes[0].Type, proxy.Routes[0].Host
+	proxy.NoProxy = strings.FieldsFunc(proxyEnv(getenv, "no_proxy"), func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	return proxy
+}
+
+func proxyEnv(getenv func(string) string, key string) string {
+	if val := strings.TrimSpace(getenv(key)); val != "" {
+		return val
+	}
+	return strings.TrimSpace(getenv(strings.ToUpper(key)))
+}
+
+// proxySplit reports whether the environment sends schemes to different
+// proxies.
+func proxySplit(routes []ProxyRoute) bool {
+	for _, r := range routes[min(1, len(routes)):] {
+		if r.Host != routes[0].Host {
+			return true
+		}
+	}
+	return false
 }
 
 func collectProxyFromScutilOutput(out string) ProxyStatus {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -28,6 +29,38 @@ func TestCollectProxyFromEnvSupportsAllProxy(t *testing.T) {
 	}
 }
 
+func TestCollectProxyFromEnvKeepsEachScheme(t *testing.T) {
+	env := map[string]string{
+		"HTTPS_PROXY": "http://10.0.0.1:3128",
+		"http_proxy":  "http://10.0.0.2:8080",
+		"HTTP_PROXY":  "http://ignored:1",
+		"NO_PROXY":    "localhost, 127.0.0.1,.corp.example",
+	}
+	got := collectProxyFromEnv(func(key string) string { return env[key] })
+	if got.Type != "HTTP" || got.Host != "10.0.0.1:3128" {
+		t.Fatalf("primary proxy = %s %s, want the HTTPS one", got.Type, got.Host)
+	}
+	wantRoutes := []ProxyRoute{
+		{Scheme: "https", Type: "HTTP", Host: "10.0.0.1:3128"},
+		{Scheme: "http", Type: "HTTP", Host: "10.0.0.2:8080"},
+	}
+	if !slices.Equal(got.Routes, wantRoutes) {
+		t.Fatalf("Routes = %+v", got.Routes)
+	}
+	if want := []string{"localhost", "127.0.0.1", ".corp.example"}; !slices.Equal(got.NoProxy, want) {
+		t.Fatalf("NoProxy = %q, want %q", got.NoProxy, want)
+	}
+	if badge := formatProxyBadge(got); badge != "Proxy split" {
+		t.Fatalf("badge = %q", badge)
+	}
+
+	delete(env, "http_proxy")
+	delete(env, "HTTP_PROXY")
+	if badge := formatProxyBadge(collectProxyFromEnv(func(key string) string { return env[key] })); badge != "Proxy https only" {
+		t.Fatalf("HTTPS-only badge = %q", badge)
+	}
+}
+
 func TestCollectProxyFromScutilOutputPAC(t *testing.T) {
 	out := `
 <dictionary> {
@@ -65,6 +98,21 @@ func TestCollectProxyFromScutilOutputHTTPHostPort(t *testing.T) {
 	}
 }
 
+func TestParseWireGuardEndpoints(t *testing.T) {
+	out := "wg0\tq1Z8mR3Xw0kB5uN7yT2sLp9Vd4Hc6Ej0aGfK1oIbWx8=\t(none)\n" +
+		"wg1\tx2Y7nP4Qv1jC6tM8zS3rKo0Ue5Gb7Fi1bHgL2pJcVw9=\t203.0.113.5:51820\n"
+	got := parseWireGuardEndpoints(out)
+	if !got.Enabled || got.Type != "WireGuard" || got.Host != "203.0.113.5:51820" || got.Interface != "wg1" {
+		t.Fatalf("parseWireGuardEndpoints() = %+v", got)
+	}
+	if badge := formatProxyBadge(got); badge != "VPN WireGuard" {
+		t.Fatalf("badge = %q", badge)
+	}
+	if got := parseWireGuardEndpoints(""); got.Enabled {
+		t.Fatalf("no tunnels reported as %+v", got)
+	}
+}
+
 func TestCollectIOCountersSafelyRecoversPanic(t *testing.T) {
 	original := ioCountersFunc
 	ioCountersFunc = func(bool) ([]gopsutilnet.IOCountersStat, error) {
@@ -153,6 +201,9 @@ func TestCollectNetworkUsesPrimedCountersForInitialRates(t *testing.T) {
 	if got[0].TxRateMBs != 0.5 {
 		t.Fatalf("expected 0.5 MB/s up, got %v", got[0].TxRateMBs)
 	}
+	if got[0].RxTotalBytes != 1024*1024 || got[0].TxTotalBytes != 512*1024 {
+		t.Fatalf("session totals = %d/%d, want the change since the priming sample", got[0].RxTotalBytes, got[0].TxTotalBytes)
+	}
 }
 
 func TestCollectNetworkClampsCounterReset(t *testing.T) {
@@ -182,3 +233,99 @@ func TestCollectNetworkClampsCounterReset(t *testing.T) {
 		t.Fatalf("expected reset counters to clamp to zero, got %+v", got[0])
 	}
 }
+
+func TestParseDefaultRouteInterface(t *testing.T) {
+	procRoute := `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+wlan0	00000000	0101A8C0	0003	0	0	600	00000000	0	0	0
+eth0	00000000	0100000A	0003	0	0	100	00000000	0	0	0
+eth0	0000000A	00000000	0001	0	0	100	00FFFFFF	0	0	0
+`
+	if got := parseProcNetRoute(procRoute); got != "eth0" {
+		t.Fatalf("parseProcNetRoute() = %q, want eth0 (lowest metric)", got)
+	}
+	routeGet := "   route to: default\ndestination: default\n    gateway: 192.168.1.1\n  interface: en7\n"
+	if got := parseRouteGetInterface(routeGet); got != "en7" {
+		t.Fatalf("parseRouteGetInterface() = %q, want en7", got)
+	}
+}
+
+func TestCollectNetworkPutsPrimaryInterfaceFirst(t *testing.T) {
+	original := ioCountersFunc
+	ioCountersFunc = func(bool) ([]gopsutilnet.IOCountersStat, error) {
+		return []gopsutilnet.IOCountersStat{
+			{Name: "en0", BytesRecv: 1000},
+			{Name: "en1", BytesRecv: 2000},
+			{Name: "en2", BytesRecv: 3000},
+			{Name: "utun4", BytesRecv: 4000},
+		}, nil
+	}
+	originalRoute := defaultRouteIfFunc
+	defaultRouteIfFunc = func() string { return "en2" }
+	t.Cleanup(func() {
+		ioCountersFunc = original
+		defaultRouteIfFunc = originalRoute
+	})
+
+	c := &Collector{}
+	got := c.collectNetwork(time.Now())
+	if len(got) == 0 || got[0].Name != "en2" || !got[0].Primary {
+		t.Fatalf("default-route interface not first: %+v", got)
+	}
+
+	// A pinned interface wins over the default route, even one normally
+	// filtered as noise (a VPN tunnel).
+	c = &Collector{primaryInterface: "utun4"}
+	got = c.collectNetwork(time.Now())
+	if len(got) == 0 || got[0].Name != "utun4" || !got[0].Primary {
+		t.Fatalf("pinned interface not first: %+v", got)
+	}
+}
+
+func TestInterfaceIPsPrefersIPv4AndGlobalIPv6(t *testing.T) {
+	ifaces := gopsutilnet.InterfaceStatList{
+		{Name: "lo0", Addrs: gopsutilnet.InterfaceAddrList{{Addr: "127.0.0.1/8"}, {Addr: "::1/128"}}},
+		{Name: "en0", Addrs: gopsutilnet.InterfaceAddrList{
+			{Addr: "fe80::1c2b:3a4d:5e6f:7a8b/64"},
+			{Addr: "fd12:3456:789a::1/64"},
+			{Addr: "2001:db8:1::42/64"},
+			{Addr: "192.168.1.20/24"},
+		}},
+		{Name: "en7", Addrs: gopsutilnet.InterfaceAddrList{{Addr: "2001:db8:2::7/64"}}},
+	}
+	v4, v6 := interfaceIPs(ifaces)
+	if len(v4) != 1 || v4["en0"] != "192.168.1.20" {
+		t.Fatalf("IPv4 = %v", v4)
+	}
+	if len(v6) != 2 || v6["en0"] != "2001:db8:1::42" || v6["en7"] != "2001:db8:2::7" {
+		t.Fatalf("IPv6 = %v, want global addresses only", v6)
+	}
+
+	card := renderNetworkCard([]NetworkStatus{{Name: "en7", IPv6: v6["en7"], Primary: true}}, NetworkHistory{}, ProxyStatus{}, nil, 60, false)
+	if last := stripANSI(card.lines[len(card.lines)-1]); last != "2001:db8:2::7" {
+		t.Fatalf("IPv6-only IP line = %q", last)
+	}
+}
+
+func TestParseNoiseInterfaces(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"", defaultNoiseInterfacePrefixes},
+		{"lo, VETH", []string{"lo", "veth"}},
+		{"+veth,-utun", []string{"lo", "awdl", "llw", "bridge", "gif", "stf", "xhc", "anpi", "ap", "veth"}},
+		{"lo,+docker,-lo", []string{"docker"}},
+	}
+	for _, tt := range tests {
+		got, err := parseNoiseInterfaces(tt.spec, "--hide-interfaces")
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("parseNoiseInterfaces(%q) = %v, %v; want %v", tt.spec, got, err, tt.want)
+		}
+	}
+	if _, err := parseNoiseInterfaces("lo,+", "--hide-interfaces"); err == nil {
+		t.Error("a bare + should be rejected")
+	}
+	if got, _ := parseNoiseInterfaces("-utun", "--hide-interfaces"); isNoiseInterface("utun3", got) {
+		t.Error("-utun should show VPN tunnels")
+	}
+}
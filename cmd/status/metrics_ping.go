@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPingHost = "1.1.1.1"
+	pingInterval    = 10 * time.Second // Probe cadence, independent of the refresh tick
+	pingTimeout     = 2 * time.Second
+	pingPort        = "443"
+
+	pingGoodMs = 50.0
+	pingSlowMs = 150.0
+)
+
+// pingHostFromFlags resolves the probe target. The probe is opt-in because it
+// opens an outbound connection every pingInterval: --ping uses
+// defaultPingHost, and a --ping-host value enables it on its own.
+func pingHostFromFlags(enabled bool, host string) string {
+	if host = strings.TrimSpace(host); host != "" {
+		return host
+	}
+	if enabled {
+		return defaultPingHost
+	}
+	return ""
+}
+
+// LatencyProbe is the last round trip to the ping host, measured as a TCP
+// connect to port 443: raw ICMP needs root on most systems, while a
+// handshake works unprivileged and through most firewalls.
+type LatencyProbe struct {
+	Host string  `json:"host"`
+	RTT  float64 `json:"rtt_ms"` // Milliseconds; zero when the probe failed
+	OK   bool    `json:"ok"`
+}
+
+var dialPingFunc = func(addr string) (time.Duration, error) {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, pingTimeout)
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(start)
+	_ = conn.Close()
+	return elapsed, nil
+}
+
+// collectPing probes in the background at most every pingInterval so a slow
+// or unreachable host never delays a refresh; nil until the first probe
+// finishes or when the probe is off.
+func (c *Collector) collectPing(now time.Time) *LatencyProbe {
+	if c.pingHost == "" {
+		return nil
+	}
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+	if !c.pingRunning && (c.lastPingAt.IsZero() || now.Sub(c.lastPingAt) >= pingInterval) {
+		c.pingRunning = true
+		c.lastPingAt = now
+		host := c.pingHost
+		go func() {
+			probe := &LatencyProbe{Host: host}
+			if rtt, err := dialPingFunc(pingAddr(host)); err == nil {
+				probe.RTT = float64(rtt.Microseconds()) / 1000
+				probe.OK = true
+			}
+			c.pingMu.Lock()
+			defer c.pingMu.Unlock()
+			c.ping = probe
+			c.pingRunning = false
+		}()
+	}
+	return c.ping
+}
+
+// pingAddr adds port 443 unless host already names a port.
+func pingAddr(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, pingPort)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPingAddr(t *testing.T) {
+	tests := map[string]string{
+		"1.1.1.1":              "1.1.1.1:443",
+		"example.com:80":       "example.com:80",
+		"2606:4700:4700::1111": "[2606:4700:4700::1111]:443",
+	}
+	for host, want := range tests {
+		if got := pingAddr(host); got != want {
+			t.Fatalf("pingAddr(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestCollectPingRunsInBackground(t *testing.T) {
+	release := make(chan struct{})
+	fail := false
+	var dialed string
+	orig := dialPingFunc
+	dialPingFunc = func(addr string) (time.Duration, error) {
+		<-release
+		dialed = addr
+		if fail {
+			return 0, errors.New("i/o timeout")
+		}
+		return 18 * time.Millisecond, nil
+	}
+	defer func() { dialPingFunc = orig }()
+
+	c := &Collector{}
+	now := time.Now()
+	if got := c.collectPing(now); got != nil {
+		t.Fatalf("probed with the probe off: %+v", got)
+	}
+	c.pingHost = "1.1.1.1"
+	if got := c.collectPing(now); got != nil {
+		t.Fatalf("first call should not wait for the probe, got %+v", got)
+	}
+	close(release)
+	waitFor := func(at time.Time, ok bool) *LatencyProbe {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		got := c.collectPing(at)
+		for (got == nil || got.OK != ok) && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+			got = c.collectPing(at)
+		}
+		if got == nil || got.OK != ok {
+			t.Fatalf("probe = %+v, want ok=%v", got, ok)
+		}
+		return got
+	}
+	if got := waitFor(now.Add(time.Second), true); got.RTT != 18 || got.Host != "1.1.1.1" {
+		t.Fatalf("probe = %+v", got)
+	}
+	if line := stripANSI(formatPingLine(c.collectPing(now.Add(time.Second)))); line != "Ping   18ms" {
+		t.Fatalf("ping line = %q", line)
+	}
+
+	fail = true
+	c.collectPing(now.Add(pingInterval))
+	waitFor(now.Add(pingInterval+time.Second), false)
+	c.pingMu.Lock()
+	if dialed != "1.1.1.1:443" {
+		t.Fatalf("dialed %q", dialed)
+	}
+	c.pingMu.Unlock()
+	if line := stripANSI(formatPingLine(&LatencyProbe{Host: "1.1.1.1"})); line != "Ping   timeout" {
+		t.Fatalf("failed ping line = %q", line)
+	}
+}
+
+func TestPingHostFromFlagsIsOptIn(t *testing.T) {
+	cases := []struct {
+		enabled bool
+		host    string
+		want    string
+	}{
+		{false, "", ""},
+		{true, "", defaultPingHost},
+		{false, " 8.8.8.8:53 ", "8.8.8.8:53"},
+		{true, "example.com", "example.com"},
+	}
+	for _, tc := range cases {
+		if got := pingHostFromFlags(tc.enabled, tc.host); got != tc.want {
+			t.Errorf("pingHostFromFlags(%v, %q) = %q, want %q", tc.enabled, tc.host, got, tc.want)
+		}
+	}
+}
@@ -137,19 +137,39 @@ func processNameFromCommand(command string) string {
 	return name
 }
 
+// minTopProcesses is how many top processes a snapshot carries unless
+// --top-procs asks for more; diagnosis and the movers panel look past the
+// three the card shows by default.
+const minTopProcesses = 5
+
+// Process card sort keys for --sort.
+const (
+	processSortCPU = "cpu"
+	processSortMem = "mem"
+)
+
+func validProcessSort(key string) bool {
+	return key == processSortCPU || key == processSortMem
+}
+
 func topProcesses(processes []ProcessInfo, limit int) []ProcessInfo {
+	return topProcessesBy(processes, limit, processRanksBefore)
+}
+
+// topProcessesBy keeps the limit processes that rank first under ranksBefore.
+func topProcessesBy(processes []ProcessInfo, limit int, ranksBefore func(a, b ProcessInfo) bool) []ProcessInfo {
 	if limit <= 0 || len(processes) == 0 {
 		return nil
 	}
 
-	h := &processHeap{}
+	h := &processHeap{ranksBefore: ranksBefore}
 	heap.Init(h)
 	for _, proc := range processes {
 		if h.Len() < limit {
 			heap.Push(h, proc)
 			continue
 		}
-		if processRanksBefore(proc, (*h)[0]) {
+		if ranksBefore(proc, h.procs[0]) {
 			heap.Pop(h)
 			heap.Push(h, proc)
 		}
@@ -179,26 +199,43 @@ func processRanksBefore(a, b ProcessInfo) bool {
 	return a.PID < b.PID
 }
 
-type processHeap []ProcessInfo
+// processRanksByMemory orders by resident size where ps reported it, then
+// by memory percent, falling back to the CPU order.
+func processRanksByMemory(a, b ProcessInfo) bool {
+	if a.MemoryBytes != b.MemoryBytes && a.MemoryBytes > 0 && b.MemoryBytes > 0 {
+		return a.MemoryBytes > b.MemoryBytes
+	}
+	if a.Memory != b.Memory {
+		return a.Memory > b.Memory
+	}
+	return processRanksBefore(a, b)
+}
+
+// processHeap is a min-heap under ranksBefore, so the root is the entry to
+// evict first.
+type processHeap struct {
+	procs       []ProcessInfo
+	ranksBefore func(a, b ProcessInfo) bool
+}
 
-func (h processHeap) Len() int { return len(h) }
+func (h processHeap) Len() int { return len(h.procs) }
 
 func (h processHeap) Less(i, j int) bool {
-	return processRanksBefore(h[j], h[i])
+	return h.ranksBefore(h.procs[j], h.procs[i])
 }
 
 func (h processHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+	h.procs[i], h.procs[j] = h.procs[j], h.procs[i]
 }
 
 func (h *processHeap) Push(x any) {
-	*h = append(*h, x.(ProcessInfo))
+	h.procs = append(h.procs, x.(ProcessInfo))
 }
 
 func (h *processHeap) Pop() any {
-	old := *h
+	old := h.procs
 	n := len(old)
 	x := old[n-1]
-	*h = old[:n-1]
+	h.procs = old[:n-1]
 	return x
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var readProcStatFunc = func() (string, error) {
+	data, err := os.ReadFile("/proc/stat")
+	return string(data), err
+}
+
+// cpuJiffies is one /proc/stat cpu line reduced to busy and total ticks.
+type cpuJiffies struct {
+	busy  uint64
+	total uint64
+}
+
+// procStatSampler computes CPU usage straight from /proc/stat for Linux
+// environments where gopsutil's cpu.Percent errors or returns nothing.
+type procStatSampler struct {
+	prevTotal   cpuJiffies
+	prevPerCore []cpuJiffies
+	primed      bool
+}
+
+// parseProcStat reads the aggregate "cpu" line and the per-core "cpuN" lines.
+// Busy excludes idle and iowait; guest time is already folded into user.
+func parseProcStat(raw string) (cpuJiffies, []cpuJiffies, error) {
+	var total cpuJiffies
+	var perCore []cpuJiffies
+	found := false
+	for line := range strings.Lines(raw) {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		var ticks [8]uint64
+		for i := 0; i < len(ticks) && i+1 < len(fields); i++ {
+			v, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return cpuJiffies{}, nil, err
+			}
+			ticks[i] = v
+		}
+		var sum uint64
+		for _, v := range ticks {
+			sum += v
+		}
+		idle := ticks[3] + ticks[4]
+		j := cpuJiffies{busy: sum - idle, total: sum}
+		if fields[0] == "cpu" {
+			total = j
+			found = true
+		} else {
+			perCore = append(perCore, j)
+		}
+	}
+	if !found {
+		return cpuJiffies{}, nil, errors.New("no cpu line in /proc/stat")
+	}
+	return total, perCore, nil
+}
+
+// sample returns aggregate and per-core usage since the previous call. The
+// first call, and any core whose counters went backwards (wrap or reset),
+// uses the cumulative counters instead of a delta.
+func (s *procStatSampler) sample() (float64, []float64, bool) {
+	raw, err := readProcStatFunc()
+	if err != nil {
+		return 0, nil, false
+	}
+	total, perCore, err := parseProcStat(raw)
+	if err != nil {
+		return 0, nil, false
+	}
+
+	usage := jiffyUsage(s.prevTotal, total, s.primed)
+	percents := make([]float64, len(perCore))
+	for i, cur := range perCore {
+		var prev cpuJiffies
+		hasPrev := s.primed && i < len(s.prevPerCore)
+		if hasPrev {
+			prev = s.prevPerCore[i]
+		}
+		percents[i] = jiffyUsage(prev, cur, hasPrev)
+	}
+
+	s.prevTotal = total
+	s.prevPerCore = perCore
+	s.primed = true
+	return usage, percents, true
+}
+
+func jiffyUsage(prev, cur cpuJiffies, hasPrev bool) float64 {
+	busy, total := cur.busy, cur.total
+	if hasPrev && cur.total >= prev.total && cur.busy >= prev.busy {
+		busy, total = cur.busy-prev.busy, cur.total-prev.total
+	}
+	if total == 0 {
+		return 0
+	}
+	return min(max(float64(busy)/float64(total)*100, 0), 100)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+const procStatSample1 = `cpu  1000 0 500 8000 500 0 0 0 0 0
+cpu0 600 0 300 3900 200 0 0 0 0 0
+cpu1 400 0 200 4100 300 0 0 0 0 0
+intr 123456 0 0
+ctxt 987654
+`
+
+const procStatSample2 = `cpu  1600 0 700 8500 700 0 0 0 0 0
+cpu0 1100 0 400 3950 250 0 0 0 0 0
+cpu1 500 0 300 4550 450 0 0 0 0 0
+`
+
+func TestParseProcStat(t *testing.T) {
+	total, perCore, err := parseProcStat(procStatSample1)
+	if err != nil {
+		t.Fatalf("parseProcStat() error = %v", err)
+	}
+	if total.busy != 1500 || total.total != 10000 {
+		t.Fatalf("unexpected aggregate %#v", total)
+	}
+	if len(perCore) != 2 || perCore[1].busy != 600 || perCore[1].total != 5000 {
+		t.Fatalf("unexpected per-core %#v", perCore)
+	}
+	if _, _, err := parseProcStat("intr 1 2 3\n"); err == nil {
+		t.Fatal("expected error without a cpu line")
+	}
+}
+
+func TestProcStatSamplerUsesDeltas(t *testing.T) {
+	orig := readProcStatFunc
+	t.Cleanup(func() { readProcStatFunc = orig })
+	samples := []string{procStatSample1, procStatSample2}
+	readProcStatFunc = func() (string, error) {
+		s := samples[0]
+		samples = samples[1:]
+		return s, nil
+	}
+
+	var s procStatSampler
+	usage, _, ok := s.sample()
+	if !ok || usage != 15 {
+		t.Fatalf("first sample should use cumulative counters, got %v %v", usage, ok)
+	}
+
+	usage, perCore, ok := s.sample()
+	if !ok {
+		t.Fatal("second sample failed")
+	}
+	// Aggregate delta: busy 800 of 1500 ticks.
+	if math.Abs(usage-53.333) > 0.01 {
+		t.Fatalf("usage = %v, want ~53.3", usage)
+	}
+	// cpu0: busy 600 of 700; cpu1: busy 200 of 800.
+	if math.Abs(perCore[0]-85.714) > 0.01 || perCore[1] != 25 {
+		t.Fatalf("per-core = %v", perCore)
+	}
+}
+
+func TestProcStatSamplerHandlesCounterWrap(t *testing.T) {
+	orig := readProcStatFunc
+	t.Cleanup(func() { readProcStatFunc = orig })
+	samples := []string{procStatSample2, procStatSample1}
+	readProcStatFunc = func() (string, error) {
+		s := samples[0]
+		samples = samples[1:]
+		return s, nil
+	}
+
+	var s procStatSampler
+	s.sample()
+	usage, perCore, ok := s.sample()
+	if !ok || usage != 15 || perCore[0] != 18 || perCore[1] != 12 {
+		t.Fatalf("counters going backwards should fall back to cumulative usage, got %v %v %v", usage, perCore, ok)
+	}
+}
+
+func TestCollectCPUFallsBackToProcStat(t *testing.T) {
+	origPercent, origProcStat := cpuPercentFunc, readProcStatFunc
+	t.Cleanup(func() { cpuPercentFunc, readProcStatFunc = origPercent, origProcStat })
+	cpuPercentFunc = func(time.Duration, bool) ([]float64, error) { return nil, errors.New("broken") }
+	readProcStatFunc = func() (string, error) { return procStatSample1, nil }
+
+	var s procStatSampler
+	status, err := collectCPUWithOptions(false, &s)
+	if err != nil {
+		t.Fatalf("collectCPUWithOptions() error = %v", err)
+	}
+	if status.PerCoreEstimated || status.Usage != 15 || len(status.PerCore) != 2 {
+		t.Fatalf("expected /proc/stat figures, got %#v", status)
+	}
+}
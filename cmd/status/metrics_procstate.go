@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	zombieWarnCount          = 10
+	uninterruptibleWarnCount = 3
+)
+
+// ProcessStateCounts aggregates process states across the whole system. A
+// growing zombie count points at a parent not reaping children; processes
+// stuck in uninterruptible sleep (D on Linux, U on macOS) usually mean hung IO.
+type ProcessStateCounts struct {
+	Total           int `json:"total"`
+	Zombie          int `json:"zombie"`
+	Uninterruptible int `json:"uninterruptible"`
+}
+
+var collectProcessStatesFunc = collectProcessStates
+
+// collectProcessStates returns nil where process states are not readable.
+func collectProcessStates() (*ProcessStateCounts, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return countProcStates(procRoot)
+	case "darwin":
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		out, err := runCmd(ctx, "ps", "-Ao", "stat=")
+		if err != nil {
+			return nil, err
+		}
+		return countPsStates(out), nil
+	}
+	return nil, nil
+}
+
+// countProcStates reads the state field of every /proc/[pid]/stat. Processes
+// that exit mid-scan are skipped.
+func countProcStates(root string) (*ProcessStateCounts, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	counts := &ProcessStateCounts{}
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		state, ok := procStatState(string(data))
+		if !ok {
+			continue
+		}
+		counts.add(state)
+	}
+	return counts, nil
+}
+
+// procStatState extracts the state letter, which follows the parenthesised
+// command name. The name may itself contain spaces or parentheses.
+func procStatState(stat string) (byte, bool) {
+	end := strings.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, false
+	}
+	rest := strings.TrimSpace(stat[end+1:])
+	if rest == "" {
+		return 0, false
+	}
+	return rest[0], true
+}
+
+// countPsStates counts `ps -Ao stat=` output, whose first letter is the state.
+func countPsStates(out string) *ProcessStateCounts {
+	counts := &ProcessStateCounts{}
+	for line := range strings.Lines(out) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		counts.add(line[0])
+	}
+	return counts
+}
+
+func (c *ProcessStateCounts) add(state byte) {
+	c.Total++
+	switch state {
+	case 'Z':
+		c.Zombie++
+	case 'D', 'U':
+		c.Uninterruptible++
+	}
+}
+
+// Elevated reports whether either count is high enough to call out.
+func (c *ProcessStateCounts) Elevated() bool {
+	return c != nil && (c.Zombie >= zombieWarnCount || c.Uninterruptible >= uninterruptibleWarnCount)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCountProcStates(t *testing.T) {
+	root := t.TempDir()
+	stats := map[string]string{
+		"1":    "1 (systemd) S 0 1 1 0 -1",
+		"42":   "42 (weird) name) Z 1 42 42 0 -1",
+		"43":   "43 (defunct) Z 1 43 43 0 -1",
+		"77":   "77 (nfsd) D 2 0 0 0 -1",
+		"self": "ignored (x) Z",
+	}
+	for pid, stat := range stats {
+		dir := filepath.Join(root, pid)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A pid directory that vanished mid-scan has no stat file.
+	if err := os.MkdirAll(filepath.Join(root, "99"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := countProcStates(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *counts != (ProcessStateCounts{Total: 4, Zombie: 2, Uninterruptible: 1}) {
+		t.Fatalf("counts = %+v", *counts)
+	}
+}
+
+func TestCountPsStates(t *testing.T) {
+	counts := countPsStates("Ss\nR+\nZ\nU\nUs\nS\n")
+	if *counts != (ProcessStateCounts{Total: 6, Zombie: 1, Uninterruptible: 2}) {
+		t.Fatalf("counts = %+v", *counts)
+	}
+}
+
+func TestProcessStatesSurfaceInCardAndDiagnosis(t *testing.T) {
+	card := withProcessStates(cardData{}, &ProcessStateCounts{Total: 200, Zombie: 2})
+	if len(card.lines) != 1 || !strings.Contains(stripANSI(card.lines[0]), "2 zombie · 0 D-state") {
+		t.Fatalf("state line = %q", card.lines)
+	}
+	if card := withProcessStates(cardData{}, &ProcessStateCounts{Total: 200}); len(card.lines) != 0 {
+		t.Fatalf("zero counts should add no line, got %q", card.lines)
+	}
+	if card := withProcessStates(cardData{}, nil); len(card.lines) != 0 {
+		t.Fatalf("unknown states should add no line")
+	}
+
+	m := MetricsSnapshot{ProcessStates: &ProcessStateCounts{Uninterruptible: uninterruptibleWarnCount}}
+	if got := statusDiagnosisLine(m); got != "3 processes stuck in IO wait" {
+		t.Fatalf("diagnosis = %q", got)
+	}
+	m.ProcessStates = &ProcessStateCounts{Zombie: zombieWarnCount}
+	if got := statusDiagnosisLine(m); got != "10 zombie processes" {
+		t.Fatalf("diagnosis = %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	publicIPURL     = "https://api.ipify.org"
+	publicIPTTL     = 2 * time.Minute
+	publicIPTimeout = 3 * time.Second
+)
+
+var fetchPublicIPFunc = fetchPublicIP
+
+// collectPublicIP runs only with --public-ip. The lookup is an outbound
+// request, so it runs in the background at most every publicIPTTL and never
+// delays a refresh; empty until the first lookup succeeds, and after one
+// fails.
+func (c *Collector) collectPublicIP(now time.Time) string {
+	if !c.lookupPublicIP {
+		return ""
+	}
+	c.publicIPMu.Lock()
+	defer c.publicIPMu.Unlock()
+	if !c.publicIPRunning && (c.lastPublicIPAt.IsZero() || now.Sub(c.lastPublicIPAt) >= publicIPTTL) {
+		c.publicIPRunning = true
+		c.lastPublicIPAt = now
+		go func() {
+			ip, err := fetchPublicIPFunc()
+			c.publicIPMu.Lock()
+			defer c.publicIPMu.Unlock()
+			if err != nil {
+				ip = ""
+			}
+			c.publicIP = ip
+			c.publicIPRunning = false
+		}()
+	}
+	return c.publicIP
+}
+
+func fetchPublicIP() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), publicIPTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, publicIPURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "", err
+	}
+	return parsePublicIP(string(body))
+}
+
+// parsePublicIP accepts the plain-text address the lookup service returns.
+func parsePublicIP(body string) (string, error) {
+	ip := net.ParseIP(strings.TrimSpace(body))
+	if ip == nil {
+		return "", errors.New("public IP lookup returned no address")
+	}
+	return ip.String(), nil
+}
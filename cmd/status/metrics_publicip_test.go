@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePublicIP(t *testing.T) {
+	if ip, err := parsePublicIP("203.0.113.7\n"); err != nil || ip != "203.0.113.7" {
+		t.Fatalf("parsePublicIP() = %q, %v", ip, err)
+	}
+	if _, err := parsePublicIP("<html>captive portal</html>"); err == nil {
+		t.Fatal("non-address body should fail")
+	}
+}
+
+func TestCollectPublicIPRunsInBackground(t *testing.T) {
+	release := make(chan struct{})
+	fail := false
+	orig := fetchPublicIPFunc
+	fetchPublicIPFunc = func() (string, error) {
+		<-release
+		if fail {
+			return "", errors.New("timeout")
+		}
+		return "203.0.113.7", nil
+	}
+	defer func() { fetchPublicIPFunc = orig }()
+
+	c := &Collector{}
+	now := time.Now()
+	if got := c.collectPublicIP(now); got != "" {
+		t.Fatalf("looked up without --public-ip: %q", got)
+	}
+
+	c.lookupPublicIP = true
+	if got := c.collectPublicIP(now); got != "" {
+		t.Fatalf("first call should not wait for the request, got %q", got)
+	}
+	close(release)
+	waitFor := func(at time.Time, want string) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		got := c.collectPublicIP(at)
+		for got != want && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+			got = c.collectPublicIP(at)
+		}
+		if got != want {
+			t.Fatalf("public IP = %q, want %q", got, want)
+		}
+	}
+	waitFor(now.Add(time.Second), "203.0.113.7")
+
+	fail = true
+	c.collectPublicIP(now.Add(publicIPTTL))
+	waitFor(now.Add(publicIPTTL+time.Second), "")
+
+	card := withPublicIP(cardData{}, "203.0.113.7")
+	if got := strings.Join(card.lines, ""); got != "Public 203.0.113.7" {
+		t.Fatalf("public IP line = %q", got)
+	}
+}
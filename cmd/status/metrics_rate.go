@@ -0,0 +1,71 @@
+package main
+
+import "time"
+
+// counterSample is one reading of a pair of monotonically increasing byte
+// counters (received/sent, read/written).
+type counterSample struct {
+	at  time.Time
+	in  uint64
+	out uint64
+}
+
+// rateWindow retains recent counter samples so rates can be averaged over a
+// fixed span, independent of how often the UI refreshes.
+type rateWindow struct {
+	samples []counterSample
+}
+
+// observe records cur and returns MB/s rates measured against the newest sample
+// at or before cur.at-span. ok is false until there are two usable samples.
+func (w *rateWindow) observe(cur counterSample, span time.Duration) (inRate, outRate float64, ok bool) {
+	if n := len(w.samples); n > 0 {
+		last := w.samples[n-1]
+		if cur.in < last.in || cur.out < last.out {
+			// Counter reset (interface bounce, sleep/wake): restart the window.
+			w.samples = w.samples[:0]
+		}
+	}
+	w.samples = append(w.samples, cur)
+
+	cutoff := cur.at.Add(-span)
+	for len(w.samples) > 2 && !w.samples[1].at.After(cutoff) {
+		w.samples = w.samples[1:]
+	}
+	if len(w.samples) < 2 {
+		return 0, 0, false
+	}
+
+	base := w.samples[0]
+	elapsed := cur.at.Sub(base.at).Seconds()
+	if elapsed <= 0 {
+		return 0, 0, false
+	}
+	inRate = float64(counterDelta(cur.in, base.in)) / 1024 / 1024 / elapsed
+	outRate = float64(counterDelta(cur.out, base.out)) / 1024 / 1024 / elapsed
+	return inRate, outRate, true
+}
+
+// windowedNetRate averages an interface's rates over c.rateWindow. It reports
+// ok=false when windowing is disabled or the window has too few samples.
+func (c *Collector) windowedNetRate(name string, sample counterSample) (float64, float64, bool) {
+	if c.rateWindow <= 0 {
+		return 0, 0, false
+	}
+	if c.netWindows == nil {
+		c.netWindows = make(map[string]*rateWindow)
+	}
+	w := c.netWindows[name]
+	if w == nil {
+		w = &rateWindow{}
+		c.netWindows[name] = w
+	}
+	return w.observe(sample, c.rateWindow)
+}
+
+func (c *Collector) windowedDiskRate(sample counterSample) (float64, float64, bool) {
+	if c.rateWindow <= 0 {
+		return 0, 0, false
+	}
+	return c.diskWindow.observe(sample, c.rateWindow)
+}
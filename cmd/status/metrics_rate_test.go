@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+func TestRateWindowAveragesOverSpan(t *testing.T) {
+	var w rateWindow
+	base := time.Now()
+	const mb = 1024 * 1024
+
+	// Bursty traffic: 4 MB in the first second, then idle.
+	counts := []uint64{0, 4 * mb, 4 * mb, 4 * mb, 4 * mb, 4 * mb}
+	var rate float64
+	var ok bool
+	for i, n := range counts {
+		rate, _, ok = w.observe(counterSample{at: base.Add(time.Duration(i) * time.Second), in: n}, 4*time.Second)
+		if i == 0 && ok {
+			t.Fatal("a single sample should not produce a rate")
+		}
+		if i == 4 && (!ok || rate != 1) {
+			t.Fatalf("rate over 4s window = %v, %v; want 1 MB/s", rate, ok)
+		}
+	}
+	if rate != 0 {
+		t.Fatalf("burst should age out of the window, got %v", rate)
+	}
+	if len(w.samples) > 5 {
+		t.Fatalf("window should prune old samples, kept %d", len(w.samples))
+	}
+}
+
+func TestRateWindowRestartsOnCounterReset(t *testing.T) {
+	var w rateWindow
+	base := time.Now()
+	w.observe(counterSample{at: base, in: 100 << 20}, 10*time.Second)
+	if _, _, ok := w.observe(counterSample{at: base.Add(time.Second), in: 1 << 20}, 10*time.Second); ok {
+		t.Fatal("counter reset should restart the window")
+	}
+	rate, _, ok := w.observe(counterSample{at: base.Add(2 * time.Second), in: 3 << 20}, 10*time.Second)
+	if !ok || rate != 2 {
+		t.Fatalf("rate after reset = %v, %v; want 2 MB/s", rate, ok)
+	}
+}
+
+func TestCollectDiskIOUsesRateWindow(t *testing.T) {
+	orig := diskIOCountersFunc
+	t.Cleanup(func() { diskIOCountersFunc = orig })
+
+	var readBytes uint64
+	steps := []uint64{0, 6 << 20, 0, 0}
+	step := 0
+	diskIOCountersFunc = func(...string) (map[string]disk.IOCountersStat, error) {
+		readBytes += steps[step]
+		step++
+		return map[string]disk.IOCountersStat{"disk0": {ReadBytes: readBytes}}, nil
+	}
+
+	c := &Collector{rateWindow: 3 * time.Second}
+	base := time.Now()
+	var got DiskIOStatus
+	for i := range steps {
+		got = c.collectDiskIO(base.Add(time.Duration(i) * time.Second))
+	}
+	if got.ReadRate != 2 {
+		t.Fatalf("windowed ReadRate = %v, want 2 MB/s averaged over 3s", got.ReadRate)
+	}
+}
+
+func TestCollectDiskIOReportsBusiestDevice(t *testing.T) {
+	orig := diskIOCountersFunc
+	t.Cleanup(func() { diskIOCountersFunc = orig })
+
+	var ssd, external uint64
+	diskIOCountersFunc = func(...string) (map[string]disk.IOCountersStat, error) {
+		ssd += 1 << 20
+		external += 8 << 20
+		return map[string]disk.IOCountersStat{
+			"disk0":   {ReadBytes: ssd},
+			"disk0s1": {ReadBytes: ssd},
+			"disk4":   {WriteBytes: external},
+		}, nil
+	}
+
+	c := &Collector{}
+	base := time.Now()
+	if warm := c.collectDiskIO(base); len(warm.Devices) != 0 {
+		t.Fatalf("first sample should only prime devices, got %+v", warm.Devices)
+	}
+	got := c.collectDiskIO(base.Add(time.Second))
+	if len(got.Devices) != 2 || got.Devices[0].Name != "disk4" || got.Devices[0].WriteRate != 8 {
+		t.Fatalf("devices = %+v, want disk4 busiest and the partition skipped", got.Devices)
+	}
+	if line := stripANSI(formatBusiestDeviceLine(got)); line != "Top    disk4 R 0 · W 8.0 MB/s" {
+		t.Fatalf("busiest line = %q", line)
+	}
+	if formatBusiestDeviceLine(DiskIOStatus{Devices: got.Devices[:1]}) != "" {
+		t.Fatal("a single disk should not repeat the I/O line")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import "errors"
+
+// transientFailureLimit is how many consecutive failures of one source reuse
+// its last good reading before the failure is treated as persistent.
+const transientFailureLimit = 3
+
+var errCPUSampleUnavailable = errors.New("cpu percent sample unavailable")
+
+// lastGood remembers a collector's previous successful reading. gopsutil calls
+// occasionally fail for a single tick (device enumeration races), and reusing
+// the previous value keeps the card from blanking; only repeated failures are
+// reported through MetricsSnapshot.CollectErrors.
+type lastGood[T any] struct {
+	value    T
+	ok       bool
+	failures int
+	err      string
+}
+
+// resolve returns the value to display and whether it is usable: either the
+// fresh value, or the last good one while failures are still transient.
+func (g *lastGood[T]) resolve(value T, err error) (T, bool) {
+	if err == nil {
+		g.value, g.ok, g.failures, g.err = value, true, 0, ""
+		return value, true
+	}
+	g.failures++
+	g.err = err.Error()
+	if g.ok && g.failures <= transientFailureLimit {
+		return g.value, true
+	}
+	return value, false
+}
+
+// persistentError returns the last error once failures stop being transient.
+func (g *lastGood[T]) persistentError() string {
+	if g.failures == 0 {
+		return ""
+	}
+	if g.ok && g.failures <= transientFailureLimit {
+		return ""
+	}
+	return g.err
+}
+
+// collectCPUResilient wraps the CPU collector with the last-good policy. The
+// fast path reports errCPUSampleUnavailable alongside its zeroed estimate; the
+// estimate is still shown when there is nothing better to reuse.
+func (c *Collector) collectCPUResilient(includeSlowFallbacks bool) (CPUStatus, error) {
+	status, err := collectCPUWithOptions(includeSlowFallbacks, &c.procStat)
+	resolved, ok := c.cpuGood.resolve(status, err)
+	if ok || errors.Is(err, errCPUSampleUnavailable) {
+		return resolved, nil
+	}
+	return resolved, err
+}
+
+func (c *Collector) collectErrors() map[string]string {
+	var errs map[string]string
+	add := func(source, msg string) {
+		if msg == "" {
+			return
+		}
+		if errs == nil {
+			errs = make(map[string]string)
+		}
+		errs[source] = msg
+	}
+	add("cpu", c.cpuGood.persistentError())
+	add("disk_io", c.diskIOGood.persistentError())
+	return errs
+}
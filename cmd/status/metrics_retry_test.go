@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+func TestLastGoodReusesValueForTransientFailures(t *testing.T) {
+	var g lastGood[float64]
+	if got, ok := g.resolve(42, nil); !ok || got != 42 {
+		t.Fatalf("resolve(success) = %v, %v", got, ok)
+	}
+
+	for i := 1; i <= transientFailureLimit; i++ {
+		got, ok := g.resolve(0, errors.New("enumeration race"))
+		if !ok || got != 42 {
+			t.Fatalf("failure %d: resolve() = %v, %v; want last good value", i, got, ok)
+		}
+		if msg := g.persistentError(); msg != "" {
+			t.Fatalf("failure %d should stay transient, got %q", i, msg)
+		}
+	}
+
+	if _, ok := g.resolve(0, errors.New("still broken")); ok {
+		t.Fatal("expected failure past the limit to stop reusing the last value")
+	}
+	if msg := g.persistentError(); msg != "still broken" {
+		t.Fatalf("persistentError() = %q, want last error", msg)
+	}
+
+	if got, ok := g.resolve(7, nil); !ok || got != 7 || g.persistentError() != "" {
+		t.Fatalf("recovery should reset state, got %v %v %q", got, ok, g.persistentError())
+	}
+}
+
+func TestLastGoodWithoutHistorySurfacesFirstFailure(t *testing.T) {
+	var g lastGood[int]
+	if _, ok := g.resolve(0, errors.New("boom")); ok {
+		t.Fatal("expected no fallback without a previous good value")
+	}
+	if g.persistentError() != "boom" {
+		t.Fatalf("persistentError() = %q, want boom", g.persistentError())
+	}
+}
+
+func TestCollectDiskIOReusesLastRateOnIntermittentError(t *testing.T) {
+	orig := diskIOCountersFunc
+	t.Cleanup(func() { diskIOCountersFunc = orig })
+
+	var readBytes uint64
+	fail := false
+	diskIOCountersFunc = func(...string) (map[string]disk.IOCountersStat, error) {
+		if fail {
+			return nil, errors.New("device enumeration race")
+		}
+		readBytes += 10 << 20
+		return map[string]disk.IOCountersStat{"disk0": {ReadBytes: readBytes}}, nil
+	}
+
+	c := &Collector{}
+	start := time.Now()
+	c.collectDiskIO(start)
+	first := c.collectDiskIO(start.Add(time.Second))
+	if first.ReadRate < 9.9 || first.ReadRate > 10.1 {
+		t.Fatalf("ReadRate = %v, want ~10", first.ReadRate)
+	}
+
+	fail = true
+	reused := c.collectDiskIO(start.Add(2 * time.Second))
+	if !reflect.DeepEqual(reused, first) {
+		t.Fatalf("transient failure should reuse %#v, got %#v", first, reused)
+	}
+	if errs := c.collectErrors(); errs != nil {
+		t.Fatalf("transient failure should not be reported, got %#v", errs)
+	}
+
+	for i := range transientFailureLimit {
+		c.collectDiskIO(start.Add(time.Duration(3+i) * time.Second))
+	}
+	if errs := c.collectErrors(); errs["disk_io"] == "" {
+		t.Fatalf("persistent failure should be reported, got %#v", errs)
+	}
+}
+
+func TestCollectCPUResilientReusesLastSampleOnFastPathError(t *testing.T) {
+	orig, origProcStat := cpuPercentFunc, readProcStatFunc
+	t.Cleanup(func() { cpuPercentFunc, readProcStatFunc = orig, origProcStat })
+	readProcStatFunc = func() (string, error) { return "", errors.New("no /proc") }
+
+	fail := false
+	cpuPercentFunc = func(time.Duration, bool) ([]float64, error) {
+		if fail {
+			return nil, errors.New("transient")
+		}
+		return []float64{40, 60}, nil
+	}
+
+	c := &Collector{}
+	good, err := c.collectCPUResilient(false)
+	if err != nil || good.Usage != 50 {
+		t.Fatalf("collectCPUResilient() = %#v, %v", good, err)
+	}
+
+	fail = true
+	reused, err := c.collectCPUResilient(false)
+	if err != nil {
+		t.Fatalf("transient failure returned error %v", err)
+	}
+	if reused.Usage != 50 || reused.PerCoreEstimated {
+		t.Fatalf("expected last good CPU sample, got %#v", reused)
+	}
+
+	for range transientFailureLimit {
+		reused, err = c.collectCPUResilient(false)
+	}
+	if err != nil || !reused.PerCoreEstimated {
+		t.Fatalf("persistent fast-path failure should fall back to the estimate, got %#v, %v", reused, err)
+	}
+	if errs := c.collectErrors(); errs["cpu"] == "" {
+		t.Fatalf("expected persistent CPU failure in CollectErrors, got %#v", errs)
+	}
+}
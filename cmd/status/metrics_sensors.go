@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+const lmSensorsTimeout = time.Second
+
+var sensorsTemperaturesFunc = sensors.SensorsTemperatures
+
+// perCoreSensorKeyRe matches per-core sensor keys such as coretemp_core_3,
+// or coretemp_core_3@1 on a second CPU package.
+var perCoreSensorKeyRe = regexp.MustCompile(`(?:^|_)core_(\d+)(?:@(\d+))?$`)
+
+// sensorInstanceSep marks the chip instance on a key that would otherwise
+// repeat, e.g. coretemp_core_0@1 for core 0 of the second coretemp chip on a
+// multi-socket host, or nvme_composite@1 for a second drive.
+const sensorInstanceSep = "@"
+
+// instanceSensorKey returns key for the first chip of a kind and key@n for
+// the nth (0-based) repeat.
+func instanceSensorKey(key string, instance int) string {
+	if instance == 0 {
+		return key
+	}
+	return key + sensorInstanceSep + strconv.Itoa(instance)
+}
+
+// sensorNames gives common sensors a readable name, keyed by the normalized
+// Linux hwmon "chip_label" key or the Apple SMC key (lowercased). Sensors
+// not listed keep their raw label.
+var sensorNames = map[string]string{
+	"coretemp_package_id_0": "CPU Package",
+	"k10temp_tctl":          "CPU",
+	"k10temp_tdie":          "CPU Die",
+	"zenpower_tdie":         "CPU Die",
+	"cpu_thermal":           "CPU", // Raspberry Pi and other ARM boards
+	"acpitz":                "ACPI Zone",
+	"amdgpu_edge":           "GPU",
+	"amdgpu_junction":       "GPU Hotspot",
+	"amdgpu_mem":            "GPU Memory",
+	"radeon":                "GPU",
+	"nouveau":               "GPU",
+	"nvme_composite":        "NVMe",
+	"iwlwifi_1":             "Wi-Fi",
+	"tc0p":                  "CPU Proximity",
+	"tc0d":                  "CPU Die",
+	"tc0h":                  "CPU Heatsink",
+	"tg0p":                  "GPU Proximity",
+	"tg0d":                  "GPU Die",
+	"ta0p":                  "Ambient",
+	"tb0t":                  "Battery",
+	"tm0p":                  "Memory",
+	"th0h":                  "Heatsink",
+	"ts0p":                  "Palm Rest",
+	"tw0p":                  "Wi-Fi",
+}
+
+// numberedSensorRe matches numbered hwmon keys that get a generic name, such
+// as coretemp_core_3 ("CPU Core 3") or k10temp_tccd1 ("CPU CCD 1").
+var numberedSensorRe = regexp.MustCompile(`^(coretemp_core_|coretemp_package_id_|k10temp_tccd|nvme_sensor_)(\d+)$`)
+
+var numberedSensorNames = map[string]string{
+	"coretemp_core_":       "CPU Core",
+	"coretemp_package_id_": "CPU Package",
+	"k10temp_tccd":         "CPU CCD",
+	"nvme_sensor_":         "NVMe Sensor",
+}
+
+// sensorLabel returns the readable name for key, or label when it has none.
+// Repeated chips get a "#2"-style suffix so their readings stay apart.
+func sensorLabel(key, label string) string {
+	base, instance, repeated := strings.Cut(key, sensorInstanceSep)
+	name := label
+	if known, ok := sensorNames[base]; ok {
+		name = known
+	} else if m := numberedSensorRe.FindStringSubmatch(base); m != nil {
+		name = numberedSensorNames[m[1]] + " " + m[2]
+	}
+	if n, err := strconv.Atoi(instance); repeated && err == nil {
+		name += " #" + strconv.Itoa(n+1)
+	}
+	return name
+}
+
+// cpuSensorChips are Linux hwmon chips that measure the CPU: Intel
+// coretemp (package and cores), AMD k10temp and zenpower (Tctl, Tdie, CCDs),
+// and the SoC zone on ARM boards.
+var cpuSensorChips = []string{"coretemp_", "k10temp_", "zenpower_", "cpu_thermal"}
+
+// cpuTemperature is the hottest CPU sensor, or 0 when there is none.
+func cpuTemperature(readings []SensorReading) float64 {
+	hottest := 0.0
+	for _, r := range readings {
+		for _, chip := range cpuSensorChips {
+			if strings.HasPrefix(r.Key, chip) {
+				hottest = max(hottest, r.Value)
+			}
+		}
+	}
+	return hottest
+}
+
+// keyedSensor pairs a reading with a normalized "chip_label" key so lm-sensors
+// and gopsutil readings of the same sensor can be deduplicated.
+type keyedSensor struct {
+	key      string
+	instance int // Chip instance, counted per chip name
+	reading  SensorReading
+}
+
+// collectSensors reports temperature sensors on Linux. It prefers lm-sensors
+// (`sensors -j`), which exposes chipset, NVMe and per-core sensors that the
+// sysfs scan can miss, and fills in anything else from gopsutil. macOS already
+// shows CPU temperature in the CPU card, so it returns nothing there.
+func collectSensors() ([]SensorReading, error) {
+	if runtime.GOOS != "linux" {
+		return nil, nil
+	}
+
+	var lm []keyedSensor
+	if commandExists("sensors") {
+		ctx, cancel := context.WithTimeout(context.Background(), lmSensorsTimeout)
+		defer cancel()
+		if out, err := runCmd(ctx, "sensors", "-j"); err == nil {
+			lm = parseLMSensorsJSON(out)
+		}
+	}
+
+	// gopsutil returns partial results alongside warnings, so keep them.
+	stats, err := sensorsTemperaturesFunc()
+	readings := mergeSensorReadings(lm, stats)
+	if len(readings) == 0 && err != nil {
+		return nil, err
+	}
+	return readings, nil
+}
+
+// parseLMSensorsJSON reads temperature inputs from `sensors -j`, shaped as
+// {"coretemp-isa-0000": {"Adapter": "...", "Core 0": {"temp2_input": 43.0, "temp2_max": 80.0}}}.
+// Chips are read in name order, so on a multi-socket host coretemp-isa-0001
+// is instance 1 and its repeated keys get the @1 suffix.
+func parseLMSensorsJSON(out string) []keyedSensor {
+	var chips map[string]map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &chips); err != nil {
+		return nil
+	}
+	chipNames := make([]string, 0, len(chips))
+	for name := range chips {
+		chipNames = append(chipNames, name)
+	}
+	sort.Strings(chipNames)
+
+	var result []keyedSensor
+	instances := make(map[string]int)
+	for _, chipName := range chipNames {
+		features := chips[chipName]
+		chip, _, _ := strings.Cut(chipName, "-")
+		instance := instances[chip]
+		instances[chip]++
+		for feature, raw := range features {
+			var values map[string]float64
+			if json.Unmarshal(raw, &values) != nil {
+				continue // "Adapter" and other non-feature entries.
+			}
+			reading, ok := lmSensorsTemperature(values)
+			if !ok {
+				continue
+			}
+			reading.Label = chip + " " + feature
+			key := chip
+			if !isUnlabeledTempFeature(feature) {
+				key += "_" + normalizeSensorLabel(feature)
+			}
+			result = append(result, keyedSensor{key: key, instance: instance, reading: reading})
+		}
+	}
+	return result
+}
+
+func lmSensorsTemperature(values map[string]float64) (SensorReading, bool) {
+	for name, value := range values {
+		prefix, ok := strings.CutSuffix(name, "_input")
+		if !ok || !strings.HasPrefix(prefix, "temp") || !validSensorTemp(value) {
+			continue
+		}
+		return SensorReading{
+			Value: value,
+			Unit:  "°C",
+			Note:  sensorNote(value, values[prefix+"_max"], values[prefix+"_crit"]),
+		}, true
+	}
+	return SensorReading{}, false
+}
+
+// isUnlabeledTempFeature reports lm-sensors' fallback names ("temp1") for
+// inputs without a sysfs label; gopsutil keys those by chip name alone.
+func isUnlabeledTempFeature(feature string) bool {
+	rest, ok := strings.CutPrefix(feature, "temp")
+	if !ok || rest == "" {
+		return false
+	}
+	return strings.Trim(rest, "0123456789") == ""
+}
+
+// normalizeSensorLabel matches gopsutil's key format ("Core 0" -> "core_0").
+func normalizeSensorLabel(label string) string {
+	return strings.Join(strings.Fields(strings.ToLower(label)), "_")
+}
+
+// mergeSensorReadings combines both sources. A key that repeats on a later
+// chip instance, such as coretemp_core_0 on a second CPU package, gets the
+// instance suffix instead of being dropped. gopsutil has no chip instance,
+// so the nth repeat of one of its keys stands for instance n.
+func mergeSensorReadings(lm []keyedSensor, stats []sensors.TemperatureStat) []SensorReading {
+	uniqueKey := func(key string, instance int, seen map[string]bool) string {
+		if seen[key] && instance > 0 {
+			return instanceSensorKey(key, instance)
+		}
+		return key
+	}
+
+	seen := make(map[string]bool, len(lm))
+	lmSeen := make(map[string]bool, len(lm))
+	var readings []SensorReading
+	for _, s := range lm {
+		key := uniqueKey(s.key, s.instance, lmSeen)
+		lmSeen[s.key] = true
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		s.reading.Key = key
+		s.reading.Label = sensorLabel(key, s.reading.Label)
+		readings = append(readings, s.reading)
+	}
+	repeats := make(map[string]int)
+	gopsutilSeen := make(map[string]bool)
+	for _, t := range stats {
+		raw := strings.ToLower(t.SensorKey)
+		if raw == "" || !validSensorTemp(t.Temperature) {
+			continue
+		}
+		key := uniqueKey(raw, repeats[raw], gopsutilSeen)
+		repeats[raw]++
+		gopsutilSeen[raw] = true
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		readings = append(readings, SensorReading{
+			Key:   key,
+			Label: sensorLabel(key, t.SensorKey),
+			Value: t.Temperature,
+			Unit:  "°C",
+			Note:  sensorNote(t.Temperature, t.High, t.Critical),
+		})
+	}
+	sort.Slice(readings, func(i, j int) bool { return readings[i].Label < readings[j].Label })
+	return readings
+}
+
+func validSensorTemp(v float64) bool {
+	return v > 0 && v < 150
+}
+
+func sensorNote(value, high, critical float64) string {
+	switch {
+	case critical > 0 && value >= critical:
+		return "critical"
+	case high > 0 && value >= high:
+		return "high"
+	default:
+		return ""
+	}
+}
+
+// perCoreTemps maps per-core temperature sensors onto logical CPUs. Sensors
+// report physical cores (with gaps in the core IDs on some parts), so cores are
+// ranked by package, then ID, and logical CPU i takes the rank i%cores
+// reading, matching Linux's numbering of SMT siblings after all first threads. It returns nil
+// when no per-core sensors exist; the CPU card then keeps the package
+// temperature alone.
+func perCoreTemps(readings []SensorReading, logical int) []float64 {
+	if logical <= 0 {
+		return nil
+	}
+	type coreTemp struct {
+		pkg  int
+		id   int
+		temp float64
+	}
+	var cores []coreTemp
+	for _, r := range readings {
+		m := perCoreSensorKeyRe.FindStringSubmatch(r.Key)
+		if m == nil {
+			continue
+		}
+		id, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		pkg, _ := strconv.Atoi(m[2]) // Empty for the first package.
+		cores = append(cores, coreTemp{pkg: pkg, id: id, temp: r.Value})
+	}
+	if len(cores) == 0 {
+		return nil
+	}
+	sort.Slice(cores, func(i, j int) bool {
+		if cores[i].pkg != cores[j].pkg {
+			return cores[i].pkg < cores[j].pkg
+		}
+		return cores[i].id < cores[j].id
+	})
+
+	temps := make([]float64, logical)
+	for i := range temps {
+		temps[i] = cores[i%len(cores)].temp
+	}
+	return temps
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+const lmSensorsFixture = `{
+  "coretemp-isa-0000": {
+    "Adapter": "ISA adapter",
+    "Package id 0": {"temp1_input": 52.0, "temp1_max": 80.0, "temp1_crit": 100.0},
+    "Core 0": {"temp2_input": 49.0, "temp2_max": 80.0, "temp2_crit": 100.0}
+  },
+  "nvme-pci-0100": {
+    "Adapter": "PCI adapter",
+    "Composite": {"temp1_input": 84.85, "temp1_max": 84.85, "temp1_crit": 89.85}
+  },
+  "acpitz-acpi-0": {
+    "Adapter": "ACPI interface",
+    "temp1": {"temp1_input": 27.8}
+  },
+  "nct6798-isa-0290": {
+    "Adapter": "ISA adapter",
+    "fan1": {"fan1_input": 1200.0}
+  }
+}`
+
+func TestParseLMSensorsJSON(t *testing.T) {
+	got := map[string]SensorReading{}
+	for _, s := range parseLMSensorsJSON(lmSensorsFixture) {
+		got[s.key] = s.reading
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected four temperature sensors, got %#v", got)
+	}
+	if r := got["coretemp_package_id_0"]; r.Label != "coretemp Package id 0" || r.Value != 52 || r.Unit != "°C" {
+		t.Fatalf("unexpected package reading %#v", r)
+	}
+	if r := got["nvme_composite"]; r.Note != "high" {
+		t.Fatalf("expected NVMe at its max to be flagged high, got %#v", r)
+	}
+	if _, ok := got["acpitz"]; !ok {
+		t.Fatalf("unlabeled temp1 should key by chip name, got %#v", got)
+	}
+}
+
+func TestMergeSensorReadingsDeduplicatesGopsutil(t *testing.T) {
+	merged := mergeSensorReadings(parseLMSensorsJSON(lmSensorsFixture), []sensors.TemperatureStat{
+		{SensorKey: "coretemp_core_0", Temperature: 49},
+		{SensorKey: "acpitz", Temperature: 27.8},
+		{SensorKey: "amdgpu_edge", Temperature: 61, High: 95, Critical: 100},
+		{SensorKey: "bogus", Temperature: 0},
+	})
+	if len(merged) != 5 {
+		t.Fatalf("expected lm-sensors readings plus one new gopsutil sensor, got %#v", merged)
+	}
+	seen := map[string]bool{}
+	for _, r := range merged {
+		if seen[r.Key] {
+			t.Fatalf("gopsutil duplicate %q should be dropped", r.Key)
+		}
+		seen[r.Key] = true
+	}
+	if merged[0].Label != "ACPI Zone" || merged[1].Label != "CPU Core 0" {
+		t.Fatalf("expected readings sorted by label, got %#v", merged)
+	}
+}
+
+func TestCollectSensorsFallsBackToGopsutil(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("sensor collection is Linux-only")
+	}
+	origExists, origRun, origTemps := commandExists, runCmd, sensorsTemperaturesFunc
+	t.Cleanup(func() {
+		commandExists, runCmd, sensorsTemperaturesFunc = origExists, origRun, origTemps
+	})
+	commandExists = func(string) bool { return false }
+	runCmd = func(context.Context, string, ...string) (string, error) {
+		t.Fatal("sensors should not run when it is not installed")
+		return "", nil
+	}
+	sensorsTemperaturesFunc = func() ([]sensors.TemperatureStat, error) {
+		return []sensors.TemperatureStat{{SensorKey: "k10temp_tctl", Temperature: 55}}, errors.New("partial")
+	}
+
+	got, err := collectSensors()
+	if err != nil || len(got) != 1 || got[0].Label != "CPU" {
+		t.Fatalf("collectSensors() = %#v, %v", got, err)
+	}
+}
+
+func TestPerCoreTempsMapsCoresToLogicalCPUs(t *testing.T) {
+	readings := mergeSensorReadings(parseLMSensorsJSON(`{
+  "coretemp-isa-0000": {
+    "Package id 0": {"temp1_input": 60.0},
+    "Core 0": {"temp2_input": 50.0},
+    "Core 4": {"temp3_input": 58.0}
+  }
+}`), nil)
+
+	got := perCoreTemps(readings, 4)
+	want := []float64{50, 58, 50, 58}
+	if len(got) != len(want) {
+		t.Fatalf("perCoreTemps() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("perCoreTemps() = %v, want %v", got, want)
+		}
+	}
+	if perCoreTemps([]SensorReading{{Key: "coretemp_package_id_0", Value: 60}}, 4) != nil {
+		t.Fatal("package-only sensors should not produce per-core temps")
+	}
+}
+
+func TestMultiSocketCoresKeepTheirOwnKeys(t *testing.T) {
+	readings := mergeSensorReadings(parseLMSensorsJSON(`{
+  "coretemp-isa-0001": {
+    "Package id 1": {"temp1_input": 70.0},
+    "Core 0": {"temp2_input": 68.0},
+    "Core 1": {"temp3_input": 69.0}
+  },
+  "coretemp-isa-0000": {
+    "Package id 0": {"temp1_input": 50.0},
+    "Core 0": {"temp2_input": 48.0},
+    "Core 1": {"temp3_input": 49.0}
+  }
+}`), []sensors.TemperatureStat{
+		{SensorKey: "coretemp_core_0", Temperature: 48},
+		{SensorKey: "coretemp_core_0", Temperature: 68},
+	})
+	keys := map[string]string{}
+	for _, r := range readings {
+		keys[r.Key] = r.Label
+	}
+	if len(readings) != 6 || keys["coretemp_core_0@1"] != "CPU Core 0 #2" || keys["coretemp_package_id_1"] != "CPU Package 1" {
+		t.Fatalf("second package cores should be kept under their own keys, got %v", keys)
+	}
+
+	got := perCoreTemps(readings, 4)
+	want := []float64{48, 49, 68, 69}
+	for i := range want {
+		if len(got) != len(want) || got[i] != want[i] {
+			t.Fatalf("perCoreTemps() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSensorLabelNamesCommonSensors(t *testing.T) {
+	cases := []struct{ key, label, want string }{
+		{"coretemp_package_id_0", "coretemp Package id 0", "CPU Package"},
+		{"coretemp_package_id_1", "coretemp Package id 1", "CPU Package 1"},
+		{"coretemp_core_12", "coretemp Core 12", "CPU Core 12"},
+		{"k10temp_tccd2", "k10temp Tccd2", "CPU CCD 2"},
+		{"amdgpu_junction", "amdgpu junction", "GPU Hotspot"},
+		{"ta0p", "TA0P", "Ambient"},
+		{"tg0p", "TG0P", "GPU Proximity"},
+		{"thinkpad_fan", "thinkpad fan", "thinkpad fan"},
+	}
+	for _, tc := range cases {
+		if got := sensorLabel(tc.key, tc.label); got != tc.want {
+			t.Errorf("sensorLabel(%q) = %q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestCPUTemperaturePicksHottestCPUSensor(t *testing.T) {
+	readings := []SensorReading{
+		{Key: "coretemp_package_id_0", Value: 61},
+		{Key: "coretemp_core_3", Value: 67},
+		{Key: "nvme_composite", Value: 72},
+		{Key: "amdgpu_edge", Value: 80},
+	}
+	if got := cpuTemperature(readings); got != 67 {
+		t.Fatalf("cpuTemperature() = %v, want 67", got)
+	}
+	if got := cpuTemperature([]SensorReading{{Key: "k10temp_tctl", Value: 55.5}}); got != 55.5 {
+		t.Fatalf("cpuTemperature(k10temp) = %v", got)
+	}
+	if got := cpuTemperature([]SensorReading{{Key: "acpitz", Value: 40}}); got != 0 {
+		t.Fatalf("non-CPU sensors gave %v", got)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	smartCacheTTL = 5 * time.Minute // SMART queries are slow and can spin up sleeping disks
+	smartTimeout  = 5 * time.Second
+
+	smartPassed = "PASSED"
+	smartFailed = "FAILED"
+)
+
+var (
+	smartCacheMu sync.Mutex
+	smartCache   = make(map[string]smartCacheEntry)
+
+	smartctlFunc = querySmartctl
+
+	// Linux partition suffixes: sda1, nvme0n1p2, mmcblk0p1.
+	linuxPartitionRe = regexp.MustCompile(`^(nvme\d+n\d+|mmcblk\d+)p\d+$|^([hsv]d[a-z]+)\d+$`)
+)
+
+// smartReading is what one smartctl run reports about a drive.
+type smartReading struct {
+	status string
+	temp   float64 // Celsius, 0 when not reported
+}
+
+type smartCacheEntry struct {
+	smartReading
+	at time.Time
+}
+
+// annotateSmartStatus fills DiskStatus.SmartStatus and Temperature from
+// `smartctl -H -A` on each disk's physical device, at most once per
+// smartCacheTTL per device. Disks smartctl cannot query (no root, virtual
+// or APFS-synthesized devices) are left blank.
+func annotateSmartStatus(disks []DiskStatus, now time.Time) {
+	if len(disks) == 0 || !commandExists("smartctl") {
+		return
+	}
+	smartCacheMu.Lock()
+	defer smartCacheMu.Unlock()
+	for i := range disks {
+		device := physicalDevice(disks[i].Device)
+		if device == "" {
+			continue
+		}
+		entry, ok := smartCache[device]
+		if !ok || now.Sub(entry.at) >= smartCacheTTL {
+			entry = smartCacheEntry{smartReading: smartctlFunc(device), at: now}
+			smartCache[device] = entry
+		}
+		disks[i].SmartStatus = entry.status
+		disks[i].Temperature = entry.temp
+	}
+}
+
+// physicalDevice maps a partition to the whole disk smartctl expects.
+func physicalDevice(device string) string {
+	if !strings.HasPrefix(device, "/dev/") || strings.HasPrefix(device, "/dev/mapper/") {
+		return ""
+	}
+	if runtime.GOOS == "darwin" {
+		return "/dev/" + baseDeviceName(device)
+	}
+	name := strings.TrimPrefix(device, "/dev/")
+	if m := linuxPartitionRe.FindStringSubmatch(name); m != nil {
+		return "/dev/" + m[1] + m[2]
+	}
+	return device
+}
+
+func querySmartctl(device string) smartReading {
+	ctx, cancel := context.WithTimeout(context.Background(), smartTimeout)
+	defer cancel()
+	// smartctl encodes findings in its exit status (a failing disk sets bit
+	// 3), so the output is parsed whatever the exit code.
+	out, _ := exec.CommandContext(ctx, "smartctl", "-H", "-A", device).Output()
+	return smartReading{status: parseSmartctlHealth(string(out)), temp: parseSmartctlTemperature(string(out))}
+}
+
+// parseSmartctlHealth reads the ATA/NVMe "overall-health" verdict or the
+// SCSI "SMART Health Status" line; blank when neither is present.
+func parseSmartctlHealth(out string) string {
+	for line := range strings.Lines(out) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.ToUpper(strings.TrimSpace(value))
+		switch {
+		case strings.Contains(key, "overall-health"):
+			if strings.HasPrefix(value, smartPassed) {
+				return smartPassed
+			}
+			return smartFailed
+		case strings.Contains(key, "SMART Health Status"):
+			if value == "OK" {
+				return smartPassed
+			}
+			return smartFailed
+		}
+	}
+	return ""
+}
+
+// parseSmartctlTemperature reads the drive temperature from the ATA
+// attribute table (194 Temperature_Celsius, else 190 Airflow_Temperature_Cel,
+// whose raw value is the tenth column), NVMe's "Temperature: 38 Celsius" or
+// SCSI's "Current Drive Temperature: 34 C".
+func parseSmartctlTemperature(out string) float64 {
+	var airflow float64
+	for line := range strings.Lines(out) {
+		fields := strings.Fields(line)
+		if len(fields) >= 10 && (fields[0] == "194" || fields[0] == "190") {
+			temp, err := strconv.ParseFloat(fields[9], 64)
+			if err != nil || !validSensorTemp(temp) {
+				continue
+			}
+			if fields[0] == "194" {
+				return temp
+			}
+			airflow = temp
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || (strings.TrimSpace(key) != "Temperature" && strings.TrimSpace(key) != "Current Drive Temperature") {
+			continue
+		}
+		if fields := strings.Fields(value); len(fields) > 0 {
+			if temp, err := strconv.ParseFloat(fields[0], 64); err == nil && validSensorTemp(temp) {
+				return temp
+			}
+		}
+	}
+	return airflow
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSmartctlHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want string
+	}{
+		{"ata passed", "=== START OF READ SMART DATA SECTION ===\nSMART overall-health self-assessment test result: PASSED\n", smartPassed},
+		{"ata failed", "SMART overall-health self-assessment test result: FAILED!\nDrive failure expected in less than 24 hours. SAVE ALL DATA.\n", smartFailed},
+		{"scsi ok", "SMART Health Status: OK\n", smartPassed},
+		{"no access", "Smartctl open device: /dev/sda failed: Permission denied\n", ""},
+	}
+	for _, tt := range tests {
+		if got := parseSmartctlHealth(tt.out); got != tt.want {
+			t.Fatalf("%s: parseSmartctlHealth() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPhysicalDevice(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		if got := physicalDevice("/dev/disk3s1s1"); got != "/dev/disk3" {
+			t.Fatalf("physicalDevice() = %q", got)
+		}
+		return
+	}
+	tests := map[string]string{
+		"/dev/sda1":        "/dev/sda",
+		"/dev/nvme0n1p2":   "/dev/nvme0n1",
+		"/dev/mmcblk0p1":   "/dev/mmcblk0",
+		"/dev/sdb":         "/dev/sdb",
+		"/dev/mapper/root": "",
+		"overlay":          "",
+	}
+	for device, want := range tests {
+		if got := physicalDevice(device); got != want {
+			t.Fatalf("physicalDevice(%q) = %q, want %q", device, got, want)
+		}
+	}
+}
+
+func TestAnnotateSmartStatusCachesPerDevice(t *testing.T) {
+	origExists, origQuery := commandExists, smartctlFunc
+	defer func() {
+		commandExists, smartctlFunc = origExists, origQuery
+		smartCacheMu.Lock()
+		smartCache = make(map[string]smartCacheEntry)
+		smartCacheMu.Unlock()
+	}()
+	commandExists = func(name string) bool { return name == "smartctl" }
+	calls := 0
+	smartctlFunc = func(string) smartReading { calls++; return smartReading{status: smartFailed, temp: 41} }
+
+	now := time.Now()
+	disks := []DiskStatus{{Device: "/dev/disk0s2"}}
+	annotateSmartStatus(disks, now)
+	annotateSmartStatus(disks, now.Add(time.Minute))
+	if calls != 1 || disks[0].SmartStatus != smartFailed || disks[0].Temperature != 41 {
+		t.Fatalf("within the TTL: %d calls, status %q, temperature %v", calls, disks[0].SmartStatus, disks[0].Temperature)
+	}
+	annotateSmartStatus(disks, now.Add(smartCacheTTL))
+	if calls != 2 {
+		t.Fatalf("after the TTL: %d calls, want 2", calls)
+	}
+
+	card := renderDiskCard([]DiskStatus{{Mount: "/", Used: 1 << 30, Total: 4 << 30, UsedPercent: 25, SmartStatus: smartFailed}}, DiskIOStatus{}, 0, false, false)
+	if !strings.HasSuffix(stripANSI(card.lines[0]), " FAIL") {
+		t.Fatalf("disk line = %q, want a FAIL badge", stripANSI(card.lines[0]))
+	}
+}
+
+func TestParseSmartctlTemperature(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want float64
+	}{
+		{"ata", `ID# ATTRIBUTE_NAME          FLAG     VALUE WORST THRESH TYPE      UPDATED  WHEN_FAILED RAW_VALUE
+190 Airflow_Temperature_Cel 0x0032   064   051   000    Old_age   Always       -       36
+194 Temperature_Celsius     0x0022   036   053   000    Old_age   Always       -       38 (Min/Max 20/47)
+`, 38},
+		{"ata airflow only", "190 Airflow_Temperature_Cel 0x0032   064   051   000    Old_age   Always       -       36\n", 36},
+		{"nvme", "Critical Warning:                   0x00\nTemperature:                        42 Celsius\nAvailable Spare:                    100%\n", 42},
+		{"scsi", "Current Drive Temperature:     34 C\nDrive Trip Temperature:        65 C\n", 34},
+		{"none", "SMART overall-health self-assessment test result: PASSED\n", 0},
+	}
+	for _, tt := range tests {
+		if got := parseSmartctlTemperature(tt.out); got != tt.want {
+			t.Errorf("%s: parseSmartctlTemperature() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotateDiskMediaReadsRotationalFlag(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("queue/rotational is Linux-only")
+	}
+	orig := sysBlockRoot
+	t.Cleanup(func() { sysBlockRoot = orig })
+	sysBlockRoot = t.TempDir()
+	writeCgroupFiles(t, sysBlockRoot, map[string]string{
+		"sda/queue/rotational":     "1\n",
+		"nvme0n1/queue/rotational": "0\n",
+	})
+
+	disks := []DiskStatus{{Device: "/dev/sda1"}, {Device: "/dev/nvme0n1p2"}, {Device: "/dev/mapper/root"}}
+	annotateDiskMedia(disks)
+	if got := []string{diskMediaType(disks[0]), diskMediaType(disks[1]), diskMediaType(disks[2])}; strings.Join(got, ",") != "HDD,SSD," {
+		t.Fatalf("media types = %q", got)
+	}
+
+	if got := stripANSI(formatDiskMetaLine(DiskStatus{Total: 4 << 40, Fstype: "ext4", Rotational: disks[0].Rotational, Temperature: 41})); got != "Total  4T · EXT4 · HDD · 41°C" {
+		t.Fatalf("meta line = %q", got)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	swapGrowthWindow  = 5 * time.Minute
+	swapGrowthAlertMB = 512 // Swap-file growth within the window that counts as rapid
+	swapGrowthPenalty = 5.0
+	swapOutThrashRate = 200.0 // Pages swapped out per second that count as thrashing
+	swapOutPenalty    = 10.0
+)
+
+var (
+	swapFilesFunc    = collectSwapFiles
+	swapActivityFunc = readSwapActivity
+)
+
+type swapSample struct {
+	at    time.Time
+	bytes uint64
+}
+
+// collectSwapFiles returns the number and total size of swap files: macOS
+// grows /var/vm/swapfileN on demand; Linux lists swap areas in /proc/swaps.
+func collectSwapFiles() (int, uint64, bool) {
+	switch runtime.GOOS {
+	case "darwin":
+		return macSwapFiles("/var/vm/swapfile*")
+	case "linux":
+		data, err := os.ReadFile("/proc/swaps")
+		if err != nil {
+			return 0, 0, false
+		}
+		count, size := parseProcSwaps(string(data))
+		return count, size, true
+	}
+	return 0, 0, false
+}
+
+func macSwapFiles(pattern string) (int, uint64, bool) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return 0, 0, false
+	}
+	var total uint64
+	count := 0
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		count++
+		total += uint64(info.Size())
+	}
+	return count, total, true
+}
+
+// parseProcSwaps sums the Size column (KiB) of /proc/swaps.
+func parseProcSwaps(raw string) (int, uint64) {
+	count := 0
+	var total uint64
+	first := true
+	for line := range strings.Lines(raw) {
+		if first {
+			first = false
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		count++
+		total += kb * 1024
+	}
+	return count, total
+}
+
+// annotateSwapFiles fills the swap-file fields and flags growth of more than
+// swapGrowthAlertMB within swapGrowthWindow.
+func (c *Collector) annotateSwapFiles(now time.Time, mem *MemoryStatus) {
+	count, size, ok := swapFilesFunc()
+	if !ok {
+		return
+	}
+	mem.SwapFiles = count
+	mem.SwapFileBytes = size
+
+	c.swapSamples = append(c.swapSamples, swapSample{at: now, bytes: size})
+	cutoff := now.Add(-swapGrowthWindow)
+	drop := 0
+	for drop < len(c.swapSamples)-1 && c.swapSamples[drop].at.Before(cutoff) {
+		drop++
+	}
+	c.swapSamples = c.swapSamples[drop:]
+
+	low := size
+	for _, s := range c.swapSamples {
+		low = min(low, s.bytes)
+	}
+	mem.SwapGrowing = size-low >= swapGrowthAlertMB<<20
+}
+
+// readSwapActivity returns the cumulative pages swapped in and out since
+// boot: vm_stat's Swapins/Swapouts on macOS, pswpin/pswpout in /proc/vmstat
+// on Linux.
+func readSwapActivity() (in, out uint64, ok bool) {
+	switch runtime.GOOS {
+	case "darwin":
+		stat, ok := readVMStat()
+		if !ok {
+			return 0, 0, false
+		}
+		return stat.pages["Swapins"], stat.pages["Swapouts"], true
+	case "linux":
+		data, err := os.ReadFile(procRoot + "/vmstat")
+		if err != nil {
+			return 0, 0, false
+		}
+		return parseProcVMStatSwap(string(data))
+	}
+	return 0, 0, false
+}
+
+// parseProcVMStatSwap reads the "pswpin N" and "pswpout N" lines.
+func parseProcVMStatSwap(raw string) (in, out uint64, ok bool) {
+	var seenIn, seenOut bool
+	for line := range strings.Lines(raw) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "pswpin":
+			in, seenIn = v, true
+		case "pswpout":
+			out, seenOut = v, true
+		}
+	}
+	return in, out, seenIn && seenOut
+}
+
+// annotateSwapActivity fills the swap-in and swap-out rates in pages per
+// second from the counter deltas since the previous full collection. The
+// first sample only primes the counters.
+func (c *Collector) annotateSwapActivity(now time.Time, mem *MemoryStatus) {
+	in, out, ok := swapActivityFunc()
+	if !ok {
+		return
+	}
+	prevIn, prevOut, prevAt := c.prevSwapIn, c.prevSwapOut, c.lastSwapActivityAt
+	c.prevSwapIn, c.prevSwapOut, c.lastSwapActivityAt = in, out, now
+	if prevAt.IsZero() {
+		return
+	}
+	elapsed := now.Sub(prevAt).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	// Counters reset on reboot or wrap; skip that interval.
+	if in >= prevIn {
+		mem.SwapInRate = float64(in-prevIn) / elapsed
+	}
+	if out >= prevOut {
+		mem.SwapOutRate = float64(out-prevOut) / elapsed
+	}
+}
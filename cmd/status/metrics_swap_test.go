@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProcSwaps(t *testing.T) {
+	raw := "Filename\t\t\t\tType\t\tSize\t\tUsed\t\tPriority\n" +
+		"/swapfile                               file\t\t2097148\t\t1024\t\t-2\n" +
+		"/dev/zram0                              partition\t4194300\t\t0\t\t100\n"
+	count, size := parseProcSwaps(raw)
+	if count != 2 || size != (2097148+4194300)*1024 {
+		t.Fatalf("parseProcSwaps = %d, %d", count, size)
+	}
+}
+
+func TestMacSwapFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"swapfile0": 1024, "swapfile1": 2048, "sleepimage": 4096} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, size, ok := macSwapFiles(filepath.Join(dir, "swapfile*"))
+	if !ok || count != 2 || size != 3072 {
+		t.Fatalf("macSwapFiles = %d, %d, %v", count, size, ok)
+	}
+}
+
+func TestAnnotateSwapFilesFlagsRapidGrowth(t *testing.T) {
+	var size uint64 = 1 << 30
+	old := swapFilesFunc
+	swapFilesFunc = func() (int, uint64, bool) { return 1, size, true }
+	t.Cleanup(func() { swapFilesFunc = old })
+
+	c := NewCollector(ProcessWatchOptions{})
+	start := time.Now()
+	var mem MemoryStatus
+	c.annotateSwapFiles(start, &mem)
+	if mem.SwapFiles != 1 || mem.SwapFileBytes != size || mem.SwapGrowing {
+		t.Fatalf("first sample = %+v", mem)
+	}
+
+	size += 600 << 20
+	mem = MemoryStatus{}
+	c.annotateSwapFiles(start.Add(2*time.Minute), &mem)
+	if !mem.SwapGrowing {
+		t.Fatal("600 MiB growth in 2 minutes should be flagged")
+	}
+
+	// Once the earlier sample ages out of the window, a flat size is calm again.
+	mem = MemoryStatus{}
+	c.annotateSwapFiles(start.Add(8*time.Minute), &mem)
+	if mem.SwapGrowing {
+		t.Fatal("stable swap size should clear the growth flag")
+	}
+}
+
+func TestSwapGrowthPenalizesHealthAndShowsInMemoryCard(t *testing.T) {
+	calm := MemoryStatus{SwapTotal: 1 << 30, SwapUsed: 1 << 29, SwapFiles: 2, SwapFileBytes: 2 << 30}
+	growing := calm
+	growing.SwapGrowing = true
+
+	calmScore, _, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, calm, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil, 0)
+	growingScore, msg, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, growing, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil, 0)
+	if calmScore-growingScore != int(swapGrowthPenalty) || !strings.Contains(msg, "Swap Growing") {
+		t.Fatalf("scores %d -> %d (%q), want a %v point swap penalty", calmScore, growingScore, msg, swapGrowthPenalty)
+	}
+
+	plain := stripANSI(strings.Join(renderMemoryCard(growing, 60, false).lines, "\n"))
+	if !strings.Contains(plain, "Files  2 · 2.0 GiB ↑ growing") {
+		t.Fatalf("memory card missing swap files line:\n%s", plain)
+	}
+}
+
+func TestParseProcVMStatSwap(t *testing.T) {
+	in, out, ok := parseProcVMStatSwap("pgpgin 1000\npswpin 42\npswpout 7\npgfault 9\n")
+	if !ok || in != 42 || out != 7 {
+		t.Fatalf("parseProcVMStatSwap = %d, %d, %v", in, out, ok)
+	}
+	if _, _, ok := parseProcVMStatSwap("pgpgin 1000\n"); ok {
+		t.Fatal("missing counters reported ok")
+	}
+}
+
+func TestAnnotateSwapActivityRatesAndPenalty(t *testing.T) {
+	var in, out uint64 = 100, 1000
+	old := swapActivityFunc
+	swapActivityFunc = func() (uint64, uint64, bool) { return in, out, true }
+	t.Cleanup(func() { swapActivityFunc = old })
+
+	c := NewCollector(ProcessWatchOptions{})
+	start := time.Now()
+	mem := MemoryStatus{}
+	c.annotateSwapActivity(start, &mem)
+	if mem.SwapInRate != 0 || mem.SwapOutRate != 0 {
+		t.Fatalf("first sample should only prime: %+v", mem)
+	}
+
+	in, out = 120, 3000
+	c.annotateSwapActivity(start.Add(2*time.Second), &mem)
+	if mem.SwapInRate != 10 || mem.SwapOutRate != 1000 {
+		t.Fatalf("rates = %v in, %v out; want 10 and 1000", mem.SwapInRate, mem.SwapOutRate)
+	}
+
+	calmScore, _, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil, 0)
+	score, msg, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, mem, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil, 0)
+	if calmScore-score != int(swapOutPenalty) || !strings.Contains(msg, "Swapping") {
+		t.Fatalf("scores %d -> %d (%q), want a %v point swap-out penalty", calmScore, score, msg, swapOutPenalty)
+	}
+
+	mem.SwapTotal, mem.SwapUsed = 1<<30, 1<<29
+	plain := stripANSI(strings.Join(renderMemoryCard(mem, 60, false).lines, "\n"))
+	if !strings.Contains(plain, "Paging in 10/s · out 1000/s") {
+		t.Fatalf("memory card missing paging line:\n%s", plain)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	logErrorsTTL       = time.Minute     // One query per minute; each covers the last minute
+	logErrorsTimeout   = 5 * time.Second // log show can take several seconds on a busy Mac
+	logErrorsSpikeMin  = 50              // Errors per minute below which nothing counts as a spike
+	logErrorsSpikeMult = 3.0             // Spike when the rate exceeds this multiple of the baseline
+	logErrorsSmoothing = 0.2             // Weight of the newest sample in the baseline average
+)
+
+// LogErrorRate is the number of error and fault entries the system log
+// recorded in the last minute, with a smoothed baseline to spot spikes.
+type LogErrorRate struct {
+	PerMinute int     `json:"per_minute"`
+	Baseline  float64 `json:"baseline"`
+	Spiking   bool    `json:"spiking"`
+}
+
+var countLogErrorsFunc = countLogErrors
+
+// collectLogErrors runs only with --log-errors, at most once per minute, and
+// keeps the previous reading between queries.
+func (c *Collector) collectLogErrors(now time.Time) *LogErrorRate {
+	if !c.logErrors {
+		return nil
+	}
+	if !c.lastLogErrorsAt.IsZero() && now.Sub(c.lastLogErrorsAt) < logErrorsTTL {
+		return c.logErrorRate
+	}
+	c.lastLogErrorsAt = now
+	count, ok := countLogErrorsFunc()
+	if !ok {
+		return c.logErrorRate
+	}
+	c.logErrorRate = nextLogErrorRate(c.logErrorRate, count)
+	return c.logErrorRate
+}
+
+// nextLogErrorRate compares count with the baseline built from earlier
+// samples, then folds it in. The first sample only seeds the baseline.
+func nextLogErrorRate(prev *LogErrorRate, count int) *LogErrorRate {
+	if prev == nil {
+		return &LogErrorRate{PerMinute: count, Baseline: float64(count)}
+	}
+	spiking := count >= logErrorsSpikeMin && float64(count) > logErrorsSpikeMult*prev.Baseline
+	return &LogErrorRate{
+		PerMinute: count,
+		Baseline:  prev.Baseline + logErrorsSmoothing*(float64(count)-prev.Baseline),
+		Spiking:   spiking,
+	}
+}
+
+// countLogErrors counts the last minute of error-level log entries: the
+// unified log's error and fault messages on macOS, journald priority err and
+// above on Linux.
+func countLogErrors() (int, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), logErrorsTimeout)
+	defer cancel()
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := runCmd(ctx, "log", "show", "--last", "1m", "--style", "ndjson",
+			"--predicate", "messageType == error OR messageType == fault")
+		if err != nil {
+			return 0, false
+		}
+		return countNDJSONEntries(out), true
+	case "linux":
+		if !commandExists("journalctl") {
+			return 0, false
+		}
+		// One PRIORITY line per entry, however many lines the message spans.
+		out, err := runCmd(ctx, "journalctl", "--quiet", "--no-pager", "--since", "1 min ago",
+			"--priority", "err", "--output", "cat", "--output-fields", "PRIORITY")
+		if err != nil {
+			return 0, false
+		}
+		return countNonEmptyLines(out), true
+	}
+	return 0, false
+}
+
+// countNDJSONEntries counts `log show --style ndjson` records, skipping the
+// trailing {"count":N,"finished":1} summary.
+func countNDJSONEntries(out string) int {
+	n := 0
+	for line := range strings.Lines(out) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "{") && !strings.Contains(line, `"finished"`) {
+			n++
+		}
+	}
+	return n
+}
+
+func countNonEmptyLines(out string) int {
+	n := 0
+	for line := range strings.Lines(out) {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountLogOutput(t *testing.T) {
+	ndjson := `{"timestamp":"2026-01-01 12:00:00","messageType":"Error","eventMessage":"a"}
+{"timestamp":"2026-01-01 12:00:01","messageType":"Fault","eventMessage":"b"}
+{"count":2,"finished":1}
+`
+	if got := countNDJSONEntries(ndjson); got != 2 {
+		t.Fatalf("countNDJSONEntries() = %d, want 2", got)
+	}
+	if got := countNonEmptyLines("3\n3\n\n2\n"); got != 3 {
+		t.Fatalf("countNonEmptyLines() = %d, want 3", got)
+	}
+}
+
+func TestNextLogErrorRateFlagsSpikes(t *testing.T) {
+	rate := nextLogErrorRate(nil, 20)
+	if rate.Spiking || rate.Baseline != 20 {
+		t.Fatalf("first sample = %+v", rate)
+	}
+	rate = nextLogErrorRate(rate, 40)
+	if rate.Spiking {
+		t.Fatalf("40/min below the spike floor flagged: %+v", rate)
+	}
+	rate = nextLogErrorRate(rate, 200)
+	if !rate.Spiking || rate.PerMinute != 200 {
+		t.Fatalf("200/min over a ~24 baseline not flagged: %+v", rate)
+	}
+}
+
+func TestCollectLogErrorsIsGatedAndThrottled(t *testing.T) {
+	calls := 0
+	orig := countLogErrorsFunc
+	countLogErrorsFunc = func() (int, bool) { calls++; return 7, true }
+	defer func() { countLogErrorsFunc = orig }()
+
+	c := &Collector{}
+	now := time.Now()
+	if rate := c.collectLogErrors(now); rate != nil || calls != 0 {
+		t.Fatalf("collected without --log-errors: %+v, %d calls", rate, calls)
+	}
+	c.logErrors = true
+	c.collectLogErrors(now)
+	rate := c.collectLogErrors(now.Add(30 * time.Second))
+	if calls != 1 || rate == nil || rate.PerMinute != 7 {
+		t.Fatalf("second query within a minute: %d calls, rate %+v", calls, rate)
+	}
+	c.collectLogErrors(now.Add(logErrorsTTL))
+	if calls != 2 {
+		t.Fatalf("query after the TTL not run: %d calls", calls)
+	}
+}
+
+func TestLogErrorsSurfaceInCardAndDiagnosis(t *testing.T) {
+	if card := withLogErrors(cardData{}, nil); len(card.lines) != 0 {
+		t.Fatalf("nil rate rendered %q", card.lines)
+	}
+	spike := &LogErrorRate{PerMinute: 1200, Baseline: 30, Spiking: true}
+	line := stripANSI(formatLogErrorsLine(spike))
+	if !strings.HasPrefix(line, "Logs ") || !strings.Contains(line, "1.2K errors/min (usual 30)") {
+		t.Fatalf("spike line = %q", line)
+	}
+	if got := statusDiagnosisLine(MetricsSnapshot{LogErrors: spike}); got != "System log errors spiking, 1200/min" {
+		t.Fatalf("diagnosis = %q", got)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	networkTalkerThresholdMBs = 1.0 // Combined down+up rate that triggers a lookup
+	networkTalkerTTL          = 5 * time.Second
+	nettopTimeout             = 2 * time.Second
+)
+
+// procRoot is the procfs mount, overridable in tests.
+var procRoot = "/proc"
+
+// NetworkTalker is the process most likely responsible for current network
+// throughput. Rates come from nettop on macOS; on Linux only socket ownership
+// is visible without privileges, so Connections counts established TCP sockets
+// and the card labels it as the process with the most connections instead.
+type NetworkTalker struct {
+	PID         int     `json:"pid"`
+	Name        string  `json:"name"`
+	RxRateMBs   float64 `json:"rx_rate_mbs,omitempty"`
+	TxRateMBs   float64 `json:"tx_rate_mbs,omitempty"`
+	Connections int     `json:"connections,omitempty"`
+}
+
+type talkerBytes struct {
+	pid  int
+	name string
+	in   uint64
+	out  uint64
+}
+
+// collectNetworkTalker starts the best-effort lookup in the background only
+// while throughput is high, at most every networkTalkerTTL, and returns the
+// last result; nil when nothing could be attributed (e.g. missing permissions).
+func (c *Collector) collectNetworkTalker(now time.Time, netStats []NetworkStatus) *NetworkTalker {
+	var total float64
+	for _, n := range netStats {
+		total += n.RxRateMBs + n.TxRateMBs
+	}
+	c.talkerMu.Lock()
+	defer c.talkerMu.Unlock()
+	if total < networkTalkerThresholdMBs {
+		c.talker = nil
+		return nil
+	}
+	if !c.talkerRunning && (c.lastTalkerAt.IsZero() || now.Sub(c.lastTalkerAt) >= networkTalkerTTL) {
+		c.talkerRunning = true
+		c.lastTalkerAt = now
+		go func() {
+			// prevTalkerBytes is only touched here, and talkerRunning keeps
+			// lookups from overlapping.
+			talker := c.sampleTalker(now)
+			c.talkerMu.Lock()
+			defer c.talkerMu.Unlock()
+			c.talker = talker
+			c.talkerRunning = false
+		}()
+	}
+	return c.talker
+}
+
+func (c *Collector) sampleTalker(now time.Time) *NetworkTalker {
+	switch runtime.GOOS {
+	case "darwin":
+		return c.sampleNettopTalker(now)
+	case "linux":
+		return linuxSocketTalker()
+	}
+	return nil
+}
+
+func (c *Collector) sampleNettopTalker(now time.Time) *NetworkTalker {
+	if !commandExists("nettop") {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), nettopTimeout)
+	defer cancel()
+	out, err := runCmd(ctx, "nettop", "-P", "-L", "1", "-x", "-J", "bytes_in,bytes_out")
+	if err != nil {
+		return nil
+	}
+	cur := parseNettopOutput(out)
+	prev, prevAt := c.prevTalkerBytes, c.prevTalkerAt
+	c.prevTalkerBytes, c.prevTalkerAt = cur, now
+	if prev == nil {
+		return nil
+	}
+	return topNettopTalker(prev, cur, now.Sub(prevAt).Seconds())
+}
+
+// parseNettopOutput reads `nettop -P -L 1 -x -J bytes_in,bytes_out` CSV rows
+// such as "12:00:01.5,Safari.412,123456,7890," into cumulative byte counts.
+func parseNettopOutput(out string) map[int]talkerBytes {
+	result := make(map[int]talkerBytes)
+	for line := range strings.Lines(out) {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		for i, field := range fields {
+			dot := strings.LastIndex(field, ".")
+			if dot <= 0 || i+2 >= len(fields) {
+				continue
+			}
+			pid, err := strconv.Atoi(field[dot+1:])
+			if err != nil || pid <= 0 {
+				continue
+			}
+			in, errIn := strconv.ParseUint(fields[i+1], 10, 64)
+			outBytes, errOut := strconv.ParseUint(fields[i+2], 10, 64)
+			if errIn != nil || errOut != nil {
+				continue
+			}
+			result[pid] = talkerBytes{pid: pid, name: field[:dot], in: in, out: outBytes}
+			break
+		}
+	}
+	return result
+}
+
+func topNettopTalker(prev, cur map[int]talkerBytes, elapsed float64) *NetworkTalker {
+	if elapsed <= 0 {
+		return nil
+	}
+	var best *NetworkTalker
+	var bestBytes uint64
+	for pid, now := range cur {
+		before, ok := prev[pid]
+		if !ok {
+			continue
+		}
+		in := counterDelta(now.in, before.in)
+		out := counterDelta(now.out, before.out)
+		if in+out == 0 || in+out <= bestBytes {
+			continue
+		}
+		bestBytes = in + out
+		best = &NetworkTalker{
+			PID:       pid,
+			Name:      now.name,
+			RxRateMBs: float64(in) / 1024 / 1024 / elapsed,
+			TxRateMBs: float64(out) / 1024 / 1024 / elapsed,
+		}
+	}
+	return best
+}
+
+// linuxSocketTalker maps established TCP sockets from /proc/net/tcp{,6} to
+// their owning PIDs through /proc/[pid]/fd. Processes we cannot inspect are
+// skipped, so without root this only sees the current user's processes.
+func linuxSocketTalker() *NetworkTalker {
+	inodes := make(map[string]bool)
+	for _, name := range []string{"tcp", "tcp6"} {
+		data, err := os.ReadFile(filepath.Join(procRoot, "net", name))
+		if err != nil {
+			continue
+		}
+		for inode := range establishedSocketInodes(string(data)) {
+			inodes[inode] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil
+	}
+	var best *NetworkTalker
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(procRoot, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		count := 0
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			if inode, ok := strings.CutPrefix(target, "socket:["); ok && inodes[strings.TrimSuffix(inode, "]")] {
+				count++
+			}
+		}
+		if count == 0 || (best != nil && count <= best.Connections) {
+			continue
+		}
+		comm, _ := os.ReadFile(filepath.Join(procRoot, entry.Name(), "comm"))
+		best = &NetworkTalker{PID: pid, Name: strings.TrimSpace(string(comm)), Connections: count}
+	}
+	return best
+}
+
+// establishedSocketInodes returns inodes of ESTABLISHED (st 01) sockets from a
+// /proc/net/tcp table.
+func establishedSocketInodes(table string) map[string]bool {
+	inodes := make(map[string]bool)
+	first := true
+	for line := range strings.Lines(table) {
+		if first {
+			first = false
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[3] != "01" || fields[9] == "0" {
+			continue
+		}
+		inodes[fields[9]] = true
+	}
+	return inodes
+}
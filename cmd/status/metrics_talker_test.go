@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNettopOutputAndTopTalker(t *testing.T) {
+	first := parseNettopOutput("time,,bytes_in,bytes_out,\n" +
+		"12:00:01.1,Safari.412,1000,500,\n" +
+		"12:00:01.1,Google Chrome H.903,4000,100,\n")
+	if got := first[903]; got.name != "Google Chrome H" || got.in != 4000 || got.out != 100 {
+		t.Fatalf("parsed chrome = %+v", got)
+	}
+
+	second := parseNettopOutput("12:00:03.1,Safari.412,1000,500,\n" +
+		"12:00:03.1,Google Chrome H.903,2101152,1048676,\n" +
+		"12:00:03.1,curl.77,900000000,0,\n")
+	talker := topNettopTalker(first, second, 2)
+	if talker == nil || talker.PID != 903 {
+		t.Fatalf("top talker = %+v, want pid 903 (new pid 77 has no baseline)", talker)
+	}
+	if talker.RxRateMBs < 0.99 || talker.RxRateMBs > 1.01 || talker.TxRateMBs < 0.49 || talker.TxRateMBs > 0.51 {
+		t.Fatalf("rates = %.2f/%.2f, want ~1/0.5 MB/s", talker.RxRateMBs, talker.TxRateMBs)
+	}
+}
+
+func TestLinuxSocketTalkerCountsEstablishedSockets(t *testing.T) {
+	root := t.TempDir()
+	oldRoot := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = oldRoot })
+
+	writeFile := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	header := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+	writeFile("net/tcp", header+
+		"   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 111 1\n"+
+		"   1: 0100007F:C350 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 222 1\n"+
+		"   2: 0100007F:C351 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 333 1\n")
+	writeFile("net/tcp6", header)
+	writeFile("10/comm", "listener\n")
+	writeFile("20/comm", "rsync\n")
+
+	link := func(pid, fd, target string) {
+		t.Helper()
+		dir := filepath.Join(root, pid, "fd")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, filepath.Join(dir, fd)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link("10", "3", "socket:[111]")
+	link("20", "3", "socket:[222]")
+	link("20", "4", "socket:[333]")
+	link("20", "5", "/dev/null")
+
+	talker := linuxSocketTalker()
+	if talker == nil || talker.PID != 20 || talker.Name != "rsync" || talker.Connections != 2 {
+		t.Fatalf("talker = %+v, want rsync (20) with 2 connections", talker)
+	}
+}
+
+func TestCollectNetworkTalkerSkipsIdleNetwork(t *testing.T) {
+	c := &Collector{talker: &NetworkTalker{PID: 1}, lastTalkerAt: time.Now()}
+	if got := c.collectNetworkTalker(time.Now(), []NetworkStatus{{RxRateMBs: 0.2}}); got != nil {
+		t.Fatalf("idle network talker = %+v, want nil", got)
+	}
+
+	cached := &NetworkTalker{PID: 42, Name: "cached"}
+	c.talker, c.lastTalkerAt = cached, time.Now()
+	if got := c.collectNetworkTalker(time.Now(), []NetworkStatus{{RxRateMBs: 5}}); got != cached {
+		t.Fatalf("within TTL got %+v, want cached talker", got)
+	}
+
+	// A lookup still in flight is not restarted; the tick gets the cached value.
+	c.lastTalkerAt, c.talkerRunning = time.Time{}, true
+	if got := c.collectNetworkTalker(time.Now(), []NetworkStatus{{RxRateMBs: 5}}); got != cached {
+		t.Fatalf("with lookup running got %+v, want cached talker", got)
+	}
+	if !c.lastTalkerAt.IsZero() {
+		t.Fatalf("a second lookup was started while one was running")
+	}
+}
+
+func TestRenderNetworkCardShowsTopTalker(t *testing.T) {
+	stats := []NetworkStatus{{Name: "en0", RxRateMBs: 3, TxRateMBs: 1, RxTotalBytes: 3 << 30, TxTotalBytes: 340 << 20}}
+	card := renderNetworkCard(stats, NetworkHistory{}, ProxyStatus{}, &NetworkTalker{PID: 20, Name: "rsync", Connections: 2}, 60, false)
+	joined := stripANSI(strings.Join(card.lines, "\n"))
+	if !strings.Contains(joined, "Total  ↓3.0 GiB  ↑340.0 MiB") {
+		t.Fatalf("network card missing session totals:\n%s", joined)
+	}
+	if !strings.Contains(joined, "Conns  rsync (20)") || !strings.Contains(joined, "most conns (2)") {
+		t.Fatalf("network card missing top talker:\n%s", joined)
+	}
+
+	card = renderNetworkCard(stats, NetworkHistory{}, ProxyStatus{}, nil, 60, false)
+	if strings.Contains(stripANSI(strings.Join(card.lines, "\n")), "Top") {
+		t.Fatalf("network card should omit top line without a talker")
+	}
+}
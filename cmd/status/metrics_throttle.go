@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// cpuSysfsRoot holds the per-CPU thermal_throttle counters, overridable in tests.
+var cpuSysfsRoot = "/sys/devices/system/cpu"
+
+// thermalPressureRe matches powermetrics' "Current pressure level: Heavy".
+var thermalPressureRe = regexp.MustCompile(`(?m)^\s*Current pressure level:\s*(\w+)`)
+
+func parseThermalPressure(out string) string {
+	if m := thermalPressureRe.FindStringSubmatch(out); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// annotateThrottling sets ThermalPressure and Throttling. macOS reports a
+// pressure level, and anything above Nominal means the CPU is being held
+// back. Linux counts throttle events per core, so a rising total since the
+// previous sample means throttling happened during the interval.
+func (c *Collector) annotateThrottling(thermal *ThermalStatus, pressure string) {
+	if pressure != "" {
+		thermal.ThermalPressure = pressure
+		thermal.Throttling = !strings.EqualFold(pressure, "Nominal")
+		return
+	}
+	total, ok := readCoreThrottleCount()
+	if !ok {
+		return
+	}
+	thermal.Throttling = c.hasThrottleCount && total > c.prevThrottleCount
+	c.prevThrottleCount = total
+	c.hasThrottleCount = true
+}
+
+// readCoreThrottleCount sums core_throttle_count across CPUs (x86 Linux).
+func readCoreThrottleCount() (uint64, bool) {
+	paths, _ := filepath.Glob(filepath.Join(cpuSysfsRoot, "cpu[0-9]*", "thermal_throttle", "core_throttle_count"))
+	var total uint64
+	found := false
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			continue
+		}
+		total += n
+		found = true
+	}
+	return total, found
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnnotateThrottlingFromPressureLevel(t *testing.T) {
+	out := "**** Thermal pressure ****\n\nCurrent pressure level: Heavy\n"
+	var c Collector
+	var thermal ThermalStatus
+	c.annotateThrottling(&thermal, parseThermalPressure(out))
+	if thermal.ThermalPressure != "Heavy" || !thermal.Throttling {
+		t.Fatalf("annotateThrottling(Heavy) = %+v", thermal)
+	}
+
+	thermal = ThermalStatus{}
+	c.annotateThrottling(&thermal, "Nominal")
+	if thermal.ThermalPressure != "Nominal" || thermal.Throttling {
+		t.Fatalf("annotateThrottling(Nominal) = %+v", thermal)
+	}
+}
+
+func TestAnnotateThrottlingFromCoreThrottleCountDeltas(t *testing.T) {
+	root := t.TempDir()
+	orig := cpuSysfsRoot
+	cpuSysfsRoot = root
+	t.Cleanup(func() { cpuSysfsRoot = orig })
+	setCount := func(cpu, count string) {
+		dir := filepath.Join(root, cpu, "thermal_throttle")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "core_throttle_count"), []byte(count+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setCount("cpu0", "12")
+	setCount("cpu1", "3")
+
+	var c Collector
+	observe := func() bool {
+		var thermal ThermalStatus
+		c.annotateThrottling(&thermal, "")
+		return thermal.Throttling
+	}
+	if observe() {
+		t.Fatal("the first sample has no baseline and must not report throttling")
+	}
+	if observe() {
+		t.Fatal("unchanged counters must not report throttling")
+	}
+	setCount("cpu1", "4")
+	if !observe() {
+		t.Fatal("a rising counter should report throttling")
+	}
+}
+
+func TestCalculateHealthScorePenalizesThrottling(t *testing.T) {
+	cool, _, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{CPUTemp: 50}, nil, nil, 0)
+	throttled, msg, _ := scoreHealth(defaultHealthWeights, CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{CPUTemp: 50, Throttling: true}, nil, nil, 0)
+	if cool-throttled != int(defaultHealthWeights.Thermal) || !strings.Contains(msg, "Throttling") {
+		t.Fatalf("throttling score %d (%q) vs %d cool", throttled, msg, cool)
+	}
+}
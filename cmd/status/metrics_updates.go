@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	updatesTTL     = 6 * time.Hour
+	updatesTimeout = 90 * time.Second // softwareupdate -l contacts Apple's servers
+)
+
+// PendingUpdates is the number of OS or package updates waiting to be
+// installed, as reported by the platform's update tool.
+type PendingUpdates struct {
+	Count  int    `json:"count"`
+	Source string `json:"source"` // softwareupdate, apt, or dnf
+}
+
+var countPendingUpdatesFunc = countPendingUpdates
+
+// collectPendingUpdates runs only with --updates. The query is slow, so it
+// runs in the background at most every updatesTTL and the last result is
+// returned meanwhile; nil until the first query finishes.
+func (c *Collector) collectPendingUpdates(now time.Time) *PendingUpdates {
+	if !c.checkUpdates {
+		return nil
+	}
+	c.updatesMu.Lock()
+	defer c.updatesMu.Unlock()
+	if !c.updatesRunning && (c.lastUpdatesAt.IsZero() || now.Sub(c.lastUpdatesAt) >= updatesTTL) {
+		c.updatesRunning = true
+		c.lastUpdatesAt = now
+		go func() {
+			updates := countPendingUpdatesFunc()
+			c.updatesMu.Lock()
+			defer c.updatesMu.Unlock()
+			if updates != nil {
+				c.updates = updates
+			}
+			c.updatesRunning = false
+		}()
+	}
+	return c.updates
+}
+
+// countPendingUpdates asks the first recognised update tool; nil when none
+// is present or the query fails.
+func countPendingUpdates() *PendingUpdates {
+	ctx, cancel := context.WithTimeout(context.Background(), updatesTimeout)
+	defer cancel()
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := runCmd(ctx, "softwareupdate", "--list")
+		if err != nil {
+			return nil
+		}
+		return &PendingUpdates{Count: countSoftwareUpdateItems(out), Source: "softwareupdate"}
+	case "linux":
+		if commandExists("apt") {
+			// Reads the cached package lists; it does not refresh them.
+			out, err := runCmd(ctx, "apt", "list", "--upgradable")
+			if err != nil {
+				return nil
+			}
+			return &PendingUpdates{Count: countAptUpgradable(out), Source: "apt"}
+		}
+		if commandExists("dnf") {
+			out, err := runCmd(ctx, "dnf", "--quiet", "--cacheonly", "list", "--upgrades")
+			if err != nil {
+				return nil
+			}
+			return &PendingUpdates{Count: countDnfUpgrades(out), Source: "dnf"}
+		}
+	}
+	return nil
+}
+
+// countSoftwareUpdateItems counts the "* Label: ..." entries of
+// `softwareupdate --list`; "No new software available." goes to stderr.
+func countSoftwareUpdateItems(out string) int {
+	n := 0
+	for line := range strings.Lines(out) {
+		if strings.HasPrefix(strings.TrimSpace(line), "* ") {
+			n++
+		}
+	}
+	return n
+}
+
+// countAptUpgradable counts "pkg/suite version arch [upgradable from: x]" rows.
+func countAptUpgradable(out string) int {
+	n := 0
+	for line := range strings.Lines(out) {
+		if strings.Contains(line, "[upgradable from:") {
+			n++
+		}
+	}
+	return n
+}
+
+// countDnfUpgrades counts "name.arch version repo" rows below the
+// "Available Upgrades" heading.
+func countDnfUpgrades(out string) int {
+	n := 0
+	for line := range strings.Lines(out) {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && strings.Contains(fields[0], ".") {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountPendingUpdateOutputs(t *testing.T) {
+	softwareupdate := `Software Update Tool
+
+Finding available software
+Software Update found the following new or updated software:
+* Label: macOS Sonoma 14.5-23F79
+	Title: macOS Sonoma 14.5, Version: 14.5, Size: 1234567K, Recommended: YES, Action: restart,
+* Label: Safari17.5SonomaAuto-17.5
+	Title: Safari, Version: 17.5, Size: 123456K, Recommended: YES,
+`
+	if got := countSoftwareUpdateItems(softwareupdate); got != 2 {
+		t.Fatalf("countSoftwareUpdateItems() = %d, want 2", got)
+	}
+
+	apt := `Listing...
+curl/jammy-updates 7.81.0-1ubuntu1.16 amd64 [upgradable from: 7.81.0-1ubuntu1.15]
+openssl/jammy-security 3.0.2-0ubuntu1.15 amd64 [upgradable from: 3.0.2-0ubuntu1.14]
+`
+	if got := countAptUpgradable(apt); got != 2 {
+		t.Fatalf("countAptUpgradable() = %d, want 2", got)
+	}
+
+	dnf := `Available Upgrades
+curl.x86_64                 8.2.1-4.fc39          updates
+kernel.x86_64               6.8.9-100.fc39        updates
+openssl-libs.x86_64         1:3.1.1-4.fc39        updates
+`
+	if got := countDnfUpgrades(dnf); got != 3 {
+		t.Fatalf("countDnfUpgrades() = %d, want 3", got)
+	}
+}
+
+func TestCollectPendingUpdatesRunsInBackground(t *testing.T) {
+	release := make(chan struct{})
+	calls := 0
+	orig := countPendingUpdatesFunc
+	countPendingUpdatesFunc = func() *PendingUpdates {
+		calls++
+		<-release
+		return &PendingUpdates{Count: 3, Source: "apt"}
+	}
+	defer func() { countPendingUpdatesFunc = orig }()
+
+	c := &Collector{}
+	now := time.Now()
+	if got := c.collectPendingUpdates(now); got != nil {
+		t.Fatalf("collected without --updates: %+v", got)
+	}
+
+	c.checkUpdates = true
+	if got := c.collectPendingUpdates(now); got != nil {
+		t.Fatalf("first call should not wait for the query, got %+v", got)
+	}
+	close(release)
+	deadline := time.Now().Add(3 * time.Second)
+	var got *PendingUpdates
+	for got == nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		got = c.collectPendingUpdates(now.Add(time.Minute))
+	}
+	if got == nil || got.Count != 3 {
+		t.Fatalf("background result = %+v", got)
+	}
+	c.updatesMu.Lock()
+	defer c.updatesMu.Unlock()
+	if calls != 1 {
+		t.Fatalf("query ran %d times within the TTL", calls)
+	}
+}
+
+func TestUpdatesLineIsAdvisory(t *testing.T) {
+	if line := formatUpdatesLine(&PendingUpdates{Count: 0, Source: "apt"}); line != "" {
+		t.Fatalf("zero updates rendered %q", line)
+	}
+	line := stripANSI(formatUpdatesLine(&PendingUpdates{Count: 3, Source: "softwareupdate"}))
+	if line != "Update 3 pending (softwareupdate)" {
+		t.Fatalf("updates line = %q", line)
+	}
+	card := renderSystemExtras(cardData{}, MetricsSnapshot{PendingUpdates: &PendingUpdates{Count: 3, Source: "apt"}})
+	if len(card.lines) != 1 || !strings.Contains(stripANSI(card.lines[0]), "3 pending") {
+		t.Fatalf("process card extras = %q", card.lines)
+	}
+}
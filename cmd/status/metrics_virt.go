@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Overridable in tests: the filesystem root that holds the container marker
+// files, and this process's cgroup directory (cgroup namespaces mount the
+// container's own cgroup here).
+var (
+	virtRootDir = "/"
+	cgroupRoot  = "/sys/fs/cgroup"
+)
+
+// virtualization names the container or virtual machine Mole runs in
+// (docker, podman, lxc, kubernetes, kvm, ...), or "" on bare metal. It is
+// detected once; it does not change while the process runs.
+func (c *Collector) virtualization() string {
+	c.virtOnce.Do(func() { c.virt = detectVirtualization() })
+	return c.virt
+}
+
+// detectVirtualization checks the Docker and Podman marker files, PID 1's
+// cgroup path, and finally systemd-detect-virt. Only Linux is checked.
+func detectVirtualization() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(virtRootDir, ".dockerenv")); err == nil {
+		return "docker"
+	}
+	if _, err := os.Stat(filepath.Join(virtRootDir, "run", ".containerenv")); err == nil {
+		return "podman"
+	}
+	if data, err := os.ReadFile(filepath.Join(procRoot, "1", "cgroup")); err == nil {
+		if kind := containerFromCgroup(string(data)); kind != "" {
+			return kind
+		}
+	}
+	if !commandExists("systemd-detect-virt") {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	// Prints "none" and exits 1 on bare metal; runCmd drops that output.
+	out, _ := runCmd(ctx, "systemd-detect-virt")
+	kind := strings.TrimSpace(out)
+	if kind == "" || kind == "none" {
+		return ""
+	}
+	return kind
+}
+
+// containerFromCgroup recognizes container runtimes from /proc/1/cgroup
+// paths such as "12:memory:/docker/3f1a..." or "0::/kubepods/burstable/...".
+// Under a private cgroup namespace the path is just "/", which reveals nothing.
+func containerFromCgroup(data string) string {
+	for line := range strings.Lines(data) {
+		fields := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		switch path := fields[2]; {
+		case strings.Contains(path, "kubepods"):
+			return "kubernetes"
+		case strings.Contains(path, "/docker"):
+			return "docker"
+		case strings.Contains(path, "libpod"):
+			return "podman"
+		case strings.Contains(path, "/lxc"):
+			return "lxc"
+		}
+	}
+	return ""
+}
+
+// applyCgroupLimits caps memory at the cgroup limit and, under a CPU quota,
+// measures CPU usage against the quota instead of the whole host. Host
+// processes see the root cgroup, which has neither limit.
+func (c *Collector) applyCgroupLimits(now time.Time, collected *collectedMetrics) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	cpu := &collected.cpuStats
+	applyCgroupLimits(cgroupRoot, &collected.memStats, cpu)
+	if cpu.CPULimit <= 0 {
+		return
+	}
+	if usage, ok := c.cgroupCPUUsage(now, cpu.CPULimit, cpu.LogicalCPU); ok {
+		cpu.Usage = usage
+	}
+}
+
+// applyCgroupLimits replaces the host-wide memory totals with the cgroup's
+// limit when one is set below physical memory, and records the CPU quota in
+// cores. Usage follows docker stats: charged memory minus inactive file
+// cache, which the kernel reclaims before hitting the limit.
+func applyCgroupLimits(root string, mem *MemoryStatus, cpu *CPUStatus) {
+	if limit, used, ok := readCgroupMemory(root); ok && (mem.Total == 0 || limit < mem.Total) {
+		used = min(used, limit)
+		mem.Total = limit
+		mem.Used = used
+		mem.Available = limit - used
+		mem.UsedPercent = float64(used) / float64(limit) * 100
+	}
+	if cores, ok := readCgroupCPULimit(root); ok {
+		cpu.CPULimit = cores
+	}
+}
+
+// cgroupCPUUsage turns the cgroup's CPU time since the previous sample into
+// a percentage of its quota, which is capped at the logical CPU count. The
+// first sample only primes the counter.
+func (c *Collector) cgroupCPUUsage(now time.Time, limit float64, logicalCPU int) (float64, bool) {
+	used, ok := readCgroupCPUTime(cgroupRoot)
+	if !ok {
+		return 0, false
+	}
+	prev, prevAt := c.prevCgroupCPU, c.prevCgroupCPUAt
+	c.prevCgroupCPU, c.prevCgroupCPUAt = used, now
+	if prevAt.IsZero() || used < prev || !now.After(prevAt) {
+		return 0, false
+	}
+	if logicalCPU > 0 {
+		limit = min(limit, float64(logicalCPU))
+	}
+	percent := float64(used-prev) / (float64(now.Sub(prevAt)) * limit) * 100
+	return min(percent, 100), true
+}
+
+// readCgroupCPUTime returns the cgroup's cumulative CPU time from cgroup v2
+// cpu.stat usage_usec, or v1 cpuacct.usage (nanoseconds).
+func readCgroupCPUTime(root string) (time.Duration, bool) {
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.stat")); err == nil {
+		for line := range strings.Lines(string(data)) {
+			key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+			if key == "usage_usec" {
+				usec, err := strconv.ParseUint(value, 10, 64)
+				return time.Duration(usec) * time.Microsecond, err == nil
+			}
+		}
+	}
+	ns, ok := readCgroupUint(filepath.Join(root, "cpuacct", "cpuacct.usage"))
+	return time.Duration(ns), ok
+}
+
+// readCgroupMemory reads cgroup v2 memory.max, falling back to v1's
+// memory/memory.limit_in_bytes. ok is false when no limit is set.
+func readCgroupMemory(root string) (limit, used uint64, ok bool) {
+	dir, limitFile, usageFile, inactiveKey := root, "memory.max", "memory.current", "inactive_file"
+	if _, err := os.Stat(filepath.Join(dir, limitFile)); err != nil {
+		dir, limitFile, usageFile, inactiveKey = filepath.Join(root, "memory"), "memory.limit_in_bytes", "memory.usage_in_bytes", "total_inactive_file"
+	}
+	limit, ok = readCgroupUint(filepath.Join(dir, limitFile))
+	if !ok || limit == 0 {
+		return 0, 0, false
+	}
+	used, _ = readCgroupUint(filepath.Join(dir, usageFile))
+	if data, err := os.ReadFile(filepath.Join(dir, "memory.stat")); err == nil {
+		for line := range strings.Lines(string(data)) {
+			key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+			if key != inactiveKey {
+				continue
+			}
+			if inactive, err := strconv.ParseUint(value, 10, 64); err == nil && inactive <= used {
+				used -= inactive
+			}
+			break
+		}
+	}
+	return limit, used, true
+}
+
+// readCgroupCPULimit returns the CPU quota in cores from cgroup v2 cpu.max
+// ("200000 100000" is 2 cores) or v1 cpu.cfs_quota_us / cpu.cfs_period_us.
+func readCgroupCPULimit(root string) (float64, bool) {
+	var quota, period string
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		quota, period, _ = strings.Cut(strings.TrimSpace(string(data)), " ")
+	} else {
+		q, qerr := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+		p, perr := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+		if qerr != nil || perr != nil {
+			return 0, false
+		}
+		quota, period = strings.TrimSpace(string(q)), strings.TrimSpace(string(p))
+	}
+	// "max" (v2) and -1 (v1) mean no quota.
+	q, qerr := strconv.ParseFloat(quota, 64)
+	p, perr := strconv.ParseFloat(period, 64)
+	if qerr != nil || perr != nil || q <= 0 || p <= 0 {
+		return 0, false
+	}
+	return q / p, true
+}
+
+// readCgroupUint parses a single-number cgroup file; "max" means unlimited.
+func readCgroupUint(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return v, err == nil
+}
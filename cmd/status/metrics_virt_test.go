@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func writeCgroupFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestContainerFromCgroup(t *testing.T) {
+	cases := map[string]string{
+		"12:memory:/docker/3f1a9c\n0::/docker/3f1a9c\n":    "docker",
+		"0::/kubepods/burstable/pod1234/abcd\n":            "kubernetes",
+		"0::/machine.slice/libpod-5e6f.scope/container\n":  "podman",
+		"4:memory:/lxc/web01\n":                            "lxc",
+		"0::/\n":                                           "",
+		"0::/user.slice/user-1000.slice/session-2.scope\n": "",
+	}
+	for data, want := range cases {
+		if got := containerFromCgroup(data); got != want {
+			t.Errorf("containerFromCgroup(%q) = %q, want %q", data, got, want)
+		}
+	}
+}
+
+func TestDetectVirtualizationFromMarkerFile(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("detection only runs on Linux")
+	}
+	oldRoot, oldProc, oldExists := virtRootDir, procRoot, commandExists
+	t.Cleanup(func() { virtRootDir, procRoot, commandExists = oldRoot, oldProc, oldExists })
+	virtRootDir, procRoot = t.TempDir(), t.TempDir()
+	commandExists = func(string) bool { return false }
+
+	if got := detectVirtualization(); got != "" {
+		t.Fatalf("bare metal detected as %q", got)
+	}
+	writeCgroupFiles(t, virtRootDir, map[string]string{"run/.containerenv": ""})
+	if got := detectVirtualization(); got != "podman" {
+		t.Fatalf("detectVirtualization() = %q, want podman", got)
+	}
+}
+
+func TestApplyCgroupLimitsV2(t *testing.T) {
+	root := t.TempDir()
+	writeCgroupFiles(t, root, map[string]string{
+		"memory.max":     "2147483648\n",
+		"memory.current": "1200000000\n",
+		"memory.stat":    "anon 900000000\nfile 300000000\ninactive_file 126258176\n",
+		"cpu.max":        "150000 100000\n",
+	})
+	mem := MemoryStatus{Total: 64 << 30, Used: 20 << 30, UsedPercent: 31}
+	var cpu CPUStatus
+	applyCgroupLimits(root, &mem, &cpu)
+
+	if mem.Total != 2<<30 || mem.Used != 1200000000-126258176 || mem.Available != mem.Total-mem.Used {
+		t.Fatalf("memory = %+v", mem)
+	}
+	if mem.UsedPercent < 50 || mem.UsedPercent > 51 {
+		t.Fatalf("UsedPercent = %.2f, want about 50", mem.UsedPercent)
+	}
+	if cpu.CPULimit != 1.5 {
+		t.Fatalf("CPULimit = %v, want 1.5", cpu.CPULimit)
+	}
+}
+
+func TestApplyCgroupLimitsIgnoresUnlimited(t *testing.T) {
+	v2 := t.TempDir()
+	writeCgroupFiles(t, v2, map[string]string{"memory.max": "max\n", "cpu.max": "max 100000\n"})
+	// cgroup v1 reports "no limit" as a huge page-aligned number.
+	v1 := t.TempDir()
+	writeCgroupFiles(t, v1, map[string]string{
+		"memory/memory.limit_in_bytes": "9223372036854771712\n",
+		"memory/memory.usage_in_bytes": "1000\n",
+		"cpu/cpu.cfs_quota_us":         "-1\n",
+		"cpu/cpu.cfs_period_us":        "100000\n",
+	})
+	for _, root := range []string{v2, v1} {
+		mem := MemoryStatus{Total: 16 << 30, Used: 4 << 30}
+		var cpu CPUStatus
+		applyCgroupLimits(root, &mem, &cpu)
+		if mem.Total != 16<<30 || mem.Used != 4<<30 || cpu.CPULimit != 0 {
+			t.Fatalf("unlimited cgroup changed totals: %+v, cpu limit %v", mem, cpu.CPULimit)
+		}
+	}
+}
+
+func TestBuildCardsHidesPowerAndBluetoothWhenVirtualized(t *testing.T) {
+	m := MetricsSnapshot{
+		Batteries: []BatteryStatus{{Percent: 80}},
+		Bluetooth: []BluetoothDevice{{Name: "Keyboard", Connected: true}},
+	}
+	titles := func() map[string]bool {
+		seen := map[string]bool{}
+		for _, card := range buildCards(m, 80, viewOptions{}) {
+			seen[card.title] = true
+		}
+		return seen
+	}
+	if seen := titles(); !seen["Power"] || !seen["Bluetooth"] {
+		t.Fatalf("expected Power and Bluetooth cards on bare metal, got %v", seen)
+	}
+	m.Virtualized = true
+	if seen := titles(); seen["Power"] || seen["Bluetooth"] {
+		t.Fatalf("virtualized snapshot still shows %v", seen)
+	}
+}
+
+func TestCgroupCPUUsageAgainstQuota(t *testing.T) {
+	oldRoot := cgroupRoot
+	t.Cleanup(func() { cgroupRoot = oldRoot })
+	cgroupRoot = t.TempDir()
+
+	c := NewCollector(ProcessWatchOptions{})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	writeCgroupFiles(t, cgroupRoot, map[string]string{"cpu.stat": "usage_usec 10000000\nuser_usec 8000000\n"})
+	if _, ok := c.cgroupCPUUsage(start, 2, 16); ok {
+		t.Fatal("first sample should only prime the counter")
+	}
+	// 1.5s of CPU time over 1s against a 2-core quota is 75%.
+	writeCgroupFiles(t, cgroupRoot, map[string]string{"cpu.stat": "usage_usec 11500000\nuser_usec 9000000\n"})
+	usage, ok := c.cgroupCPUUsage(start.Add(time.Second), 2, 16)
+	if !ok || usage != 75 {
+		t.Fatalf("cgroupCPUUsage() = %v, %v; want 75", usage, ok)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+// vpnInterfacePrefixes name the tunnel devices VPN clients create: utun on
+// macOS, tun for OpenVPN, wg for WireGuard, plus a few client-specific names.
+// ppp and tap are left out: they are just as often PPPoE links and VM bridges.
+var vpnInterfacePrefixes = []string{"utun", "tun", "wg", "ipsec", "tailscale", "nordlynx"}
+
+// vpnTrafficWindow is how recently a tunnel must have moved bytes to count;
+// long enough to cover keepalive gaps (WireGuard's is 25s) on an idle VPN.
+const vpnTrafficWindow = time.Minute
+
+// VPNStatus is an active VPN. Interface is empty when only the macOS
+// network service reports it.
+type VPNStatus struct {
+	Interface string `json:"interface,omitempty"` // Tunnel device, e.g. utun4 or wg0
+	Service   string `json:"service,omitempty"`   // macOS VPN configuration name from scutil --nc list
+}
+
+// collectVPN reports the first tunnel interface that has an IPv4 or global
+// IPv6 address and has carried traffic within vpnTrafficWindow; idle tunnels
+// (macOS keeps several utun devices around) do not count. service is the
+// connected macOS VPN service, which also counts and names the VPN. It runs
+// after collectNetwork, which records the tunnel traffic.
+func (c *Collector) collectVPN(now time.Time, service string) *VPNStatus {
+	v4, v6 := c.getInterfaceIPsCached(now)
+	vpn := VPNStatus{Interface: vpnInterface(v4, v6, c.tunnelTrafficAt, now), Service: service}
+	if vpn.Interface == "" && vpn.Service == "" {
+		return nil
+	}
+	return &vpn
+}
+
+// connectedVPNService asks scutil for a connected VPN service on macOS.
+func connectedVPNService() string {
+	if runtime.GOOS != "darwin" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	out, err := runCmd(ctx, "scutil", "--nc", "list")
+	if err != nil {
+		return ""
+	}
+	return parseConnectedVPNService(out)
+}
+
+func isVPNInterface(name string) bool {
+	lower := strings.ToLower(name)
+	return slices.ContainsFunc(vpnInterfacePrefixes, func(p string) bool { return strings.HasPrefix(lower, p) })
+}
+
+// noteTunnelTraffic records when each tunnel last moved bytes. It sees the
+// raw counters, before the noise filter hides utun devices from the card.
+func (c *Collector) noteTunnelTraffic(now time.Time, stats []net.IOCountersStat) {
+	for _, cur := range stats {
+		if !isVPNInterface(cur.Name) {
+			continue
+		}
+		prev, ok := c.prevNet[cur.Name]
+		if !ok || (cur.BytesRecv == prev.BytesRecv && cur.BytesSent == prev.BytesSent) {
+			continue
+		}
+		if c.tunnelTrafficAt == nil {
+			c.tunnelTrafficAt = make(map[string]time.Time)
+		}
+		c.tunnelTrafficAt[cur.Name] = now
+	}
+}
+
+// vpnInterface picks the busy tunnel with an address, lowest name first so
+// the answer is stable between refreshes.
+func vpnInterface(v4, v6 map[string]string, trafficAt map[string]time.Time, now time.Time) string {
+	var names []string
+	for _, addrs := range []map[string]string{v4, v6} {
+		for name := range addrs {
+			if !isVPNInterface(name) {
+				continue
+			}
+			if at, ok := trafficAt[name]; ok && now.Sub(at) <= vpnTrafficWindow {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// parseConnectedVPNService returns the quoted name of the first
+// "(Connected)" entry in scutil --nc list, such as "Home" in
+// `* (Connected) 6F1A... VPN (com.wireguard.macos) "Home" [VPN/...]`.
+func parseConnectedVPNService(out string) string {
+	for line := range strings.Lines(out) {
+		if !strings.Contains(line, "(Connected)") {
+			continue
+		}
+		_, rest, ok := strings.Cut(line, `"`)
+		if !ok {
+			continue
+		}
+		if name, _, ok := strings.Cut(rest, `"`); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// formatVPNLine renders "VPN    ● utun4 · Home".
+func formatVPNLine(vpn *VPNStatus) string {
+	if vpn == nil {
+		return ""
+	}
+	var names []string
+	for _, name := range []string{vpn.Interface, vpn.Service} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return fmt.Sprintf("%-*s %s %s", metricLabelWidth, "VPN", okStyle.Render("●"), strings.Join(names, " · "))
+}
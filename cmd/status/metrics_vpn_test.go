@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+func TestVPNInterfaceNeedsAnAddressAndTraffic(t *testing.T) {
+	now := time.Now()
+	busy := map[string]time.Time{"wg0": now.Add(-5 * time.Second), "utun7": now}
+	v4 := map[string]string{"en0": "192.168.1.20", "wg0": "10.8.0.2"}
+	v6 := map[string]string{"utun7": "2001:db8::7"}
+	if got := vpnInterface(v4, v6, busy, now); got != "utun7" {
+		t.Fatalf("vpnInterface() = %q, want utun7", got)
+	}
+	if got := vpnInterface(map[string]string{"en0": "192.168.1.20"}, nil, busy, now); got != "" {
+		t.Fatalf("no tunnel should report no VPN, got %q", got)
+	}
+	idle := map[string]time.Time{"wg0": now.Add(-2 * vpnTrafficWindow)}
+	if got := vpnInterface(v4, nil, idle, now); got != "" {
+		t.Fatalf("idle tunnel reported as VPN %q", got)
+	}
+	links := map[string]string{"ppp0": "100.64.0.2", "tap0": "10.0.2.15"}
+	if got := vpnInterface(links, nil, map[string]time.Time{"ppp0": now, "tap0": now}, now); got != "" {
+		t.Fatalf("PPPoE link or VM bridge reported as VPN %q", got)
+	}
+}
+
+func TestNoteTunnelTrafficTracksOnlyMovingTunnels(t *testing.T) {
+	c := &Collector{prevNet: map[string]net.IOCountersStat{
+		"utun3": {Name: "utun3", BytesRecv: 100, BytesSent: 50},
+		"utun4": {Name: "utun4", BytesRecv: 100, BytesSent: 50},
+		"en0":   {Name: "en0", BytesRecv: 100},
+	}}
+	now := time.Now()
+	c.noteTunnelTraffic(now, []net.IOCountersStat{
+		{Name: "utun3", BytesRecv: 100, BytesSent: 50},
+		{Name: "utun4", BytesRecv: 180, BytesSent: 50},
+		{Name: "en0", BytesRecv: 900},
+	})
+	if len(c.tunnelTrafficAt) != 1 || !c.tunnelTrafficAt["utun4"].Equal(now) {
+		t.Fatalf("tunnelTrafficAt = %v, want only utun4", c.tunnelTrafficAt)
+	}
+}
+
+func TestParseConnectedVPNService(t *testing.T) {
+	out := `Available network connection services in the current set (*=enabled):
+* (Disconnected)   A1B2C3D4-0000 PPP --> L2TP       "Office"                         [PPP/L2TP]
+* (Connected)      6F1A9E00-1111 VPN (com.wireguard.macos) "Home"        [VPN/com.wireguard.macos]
+`
+	if got := parseConnectedVPNService(out); got != "Home" {
+		t.Fatalf("parseConnectedVPNService() = %q, want Home", got)
+	}
+	if got := parseConnectedVPNService("* (Disconnected) x \"Office\""); got != "" {
+		t.Fatalf("disconnected service reported as %q", got)
+	}
+}
+
+func TestFormatVPNLine(t *testing.T) {
+	if got := stripANSI(formatVPNLine(&VPNStatus{Interface: "utun4", Service: "Home"})); got != "VPN    ● utun4 · Home" {
+		t.Fatalf("formatVPNLine() = %q", got)
+	}
+	if formatVPNLine(nil) != "" {
+		t.Fatal("no VPN should render nothing")
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	wifiCacheTTL = 30 * time.Second // system_profiler SPAirPortDataType takes seconds
+	iwTimeout    = time.Second
+)
+
+// WiFiStatus describes the current wireless association. It is nil on
+// machines without WiFi or while disconnected.
+type WiFiStatus struct {
+	Interface string  `json:"interface"`
+	SSID      string  `json:"ssid"`      // "<redacted>" on macOS 14.4+ without Location access
+	RSSI      int     `json:"rssi"`      // dBm
+	LinkRate  float64 `json:"link_rate"` // Mbps
+	Channel   string  `json:"channel"`
+}
+
+func (c *Collector) collectWiFi(now time.Time) *WiFiStatus {
+	if !c.lastWiFiAt.IsZero() && now.Sub(c.lastWiFiAt) < wifiCacheTTL {
+		return c.wifi
+	}
+	c.lastWiFiAt = now
+	c.wifi = readWiFi()
+	return c.wifi
+}
+
+func readWiFi() *WiFiStatus {
+	switch runtime.GOOS {
+	case "darwin":
+		if !commandExists("system_profiler") {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), systemProfilerTimeout)
+		defer cancel()
+		out, err := runCmd(ctx, "system_profiler", "SPAirPortDataType")
+		if err != nil {
+			return nil
+		}
+		return parseSPAirPort(out)
+	case "linux":
+		if !commandExists("iw") {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), iwTimeout)
+		defer cancel()
+		out, err := runCmd(ctx, "iw", "dev")
+		if err != nil {
+			return nil
+		}
+		for _, iface := range parseIWDevInterfaces(out) {
+			link, err := runCmd(ctx, "iw", "dev", iface, "link")
+			if err != nil {
+				continue
+			}
+			if wifi := parseIWLink(link); wifi != nil {
+				wifi.Interface = iface
+				return wifi
+			}
+		}
+	}
+	return nil
+}
+
+// parseSPAirPort reads the "Current Network Information" block of
+// `system_profiler SPAirPortDataType`: the SSID is the block's first key and
+// the fields below it are indented further.
+func parseSPAirPort(out string) *WiFiStatus {
+	var (
+		wifi       *WiFiStatus
+		iface      string
+		blockDepth = -1
+	)
+	for line := range strings.Lines(out) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		depth := len(line) - len(strings.TrimLeft(line, " "))
+		if wifi == nil {
+			if strings.HasPrefix(trimmed, "en") && strings.HasSuffix(trimmed, ":") {
+				iface = strings.TrimSuffix(trimmed, ":")
+			}
+			if trimmed == "Current Network Information:" {
+				blockDepth = depth
+				wifi = &WiFiStatus{Interface: iface}
+			}
+			continue
+		}
+		if depth <= blockDepth {
+			break
+		}
+		key, value, _ := strings.Cut(trimmed, ":")
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Signal / Noise":
+			wifi.RSSI, _ = strconv.Atoi(strings.Fields(value + " 0")[0])
+		case "Transmit Rate":
+			wifi.LinkRate, _ = strconv.ParseFloat(value, 64)
+		case "Channel":
+			wifi.Channel = value
+		default:
+			if wifi.SSID == "" && value == "" {
+				wifi.SSID = key
+			}
+		}
+	}
+	return wifi
+}
+
+// parseIWDevInterfaces lists the "Interface wlan0" names from `iw dev`.
+func parseIWDevInterfaces(out string) []string {
+	var ifaces []string
+	for line := range strings.Lines(out) {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "Interface "); ok {
+			ifaces = append(ifaces, strings.TrimSpace(name))
+		}
+	}
+	return ifaces
+}
+
+// parseIWLink reads `iw dev <if> link`; nil when it prints "Not connected."
+func parseIWLink(out string) *WiFiStatus {
+	if !strings.HasPrefix(strings.TrimSpace(out), "Connected to") {
+		return nil
+	}
+	wifi := &WiFiStatus{}
+	for line := range strings.Lines(out) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		switch key {
+		case "SSID":
+			wifi.SSID = value
+		case "signal":
+			wifi.RSSI, _ = strconv.Atoi(fields[0])
+		case "tx bitrate":
+			wifi.LinkRate, _ = strconv.ParseFloat(fields[0], 64)
+		case "freq":
+			if mhz, err := strconv.ParseFloat(fields[0], 64); err == nil {
+				wifi.Channel = wifiChannel(int(mhz))
+			}
+		}
+	}
+	return wifi
+}
+
+// wifiChannel converts a centre frequency in MHz to "36 (5GHz)".
+func wifiChannel(mhz int) string {
+	switch {
+	case mhz == 2484:
+		return "14 (2.4GHz)"
+	case mhz >= 2412 && mhz < 2484:
+		return fmt.Sprintf("%d (2.4GHz)", (mhz-2407)/5)
+	case mhz >= 5000 && mhz < 5925:
+		return fmt.Sprintf("%d (5GHz)", (mhz-5000)/5)
+	case mhz >= 5925 && mhz <= 7125:
+		return fmt.Sprintf("%d (6GHz)", (mhz-5950)/5)
+	}
+	return ""
+}
+
+// wifiSignalPercent maps RSSI onto 0-100 between -90 dBm (unusable) and
+// -50 dBm (excellent; stronger signals add nothing).
+func wifiSignalPercent(rssi int) float64 {
+	return max(min(float64(rssi+90)*100/40, 100), 0)
+}
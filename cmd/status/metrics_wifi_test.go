@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const spAirPortFixture = `Wi-Fi:
+
+      Software Versions:
+          CoreWLAN: 16.0 (1657)
+      Interfaces:
+        en0:
+          Card Type: Wi-Fi  (0x14E4, 0x4387)
+          Status: Connected
+          Current Network Information:
+            HomeNet 5G:
+              PHY Mode: 802.11ax
+              Channel: 149 (5GHz, 80MHz)
+              Network Type: Infrastructure
+              Security: WPA2 Personal
+              Signal / Noise: -58 dBm / -94 dBm
+              Transmit Rate: 864
+              MCS Index: 9
+          Other Local Wi-Fi Networks:
+            Neighbour:
+              Channel: 6 (2GHz, 20MHz)
+              Signal / Noise: -80 dBm / -94 dBm
+`
+
+func TestParseSPAirPort(t *testing.T) {
+	wifi := parseSPAirPort(spAirPortFixture)
+	want := WiFiStatus{Interface: "en0", SSID: "HomeNet 5G", RSSI: -58, LinkRate: 864, Channel: "149 (5GHz, 80MHz)"}
+	if wifi == nil || *wifi != want {
+		t.Fatalf("parseSPAirPort() = %+v, want %+v", wifi, want)
+	}
+	disconnected := strings.Replace(spAirPortFixture, "Current Network Information:", "Supported Channels:", 1)
+	if wifi := parseSPAirPort(disconnected); wifi != nil {
+		t.Fatalf("disconnected = %+v, want nil", wifi)
+	}
+}
+
+func TestParseIWLink(t *testing.T) {
+	out := `Connected to aa:bb:cc:dd:ee:ff (on wlan0)
+	SSID: HomeNet
+	freq: 5180
+	RX: 123456 bytes (789 packets)
+	signal: -52 dBm
+	rx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+	tx bitrate: 780.0 MBit/s VHT-MCS 8 80MHz short GI VHT-NSS 2
+`
+	wifi := parseIWLink(out)
+	want := WiFiStatus{SSID: "HomeNet", RSSI: -52, LinkRate: 780, Channel: "36 (5GHz)"}
+	if wifi == nil || *wifi != want {
+		t.Fatalf("parseIWLink() = %+v, want %+v", wifi, want)
+	}
+	if wifi := parseIWLink("Not connected.\n"); wifi != nil {
+		t.Fatalf("not connected = %+v", wifi)
+	}
+	if got := parseIWDevInterfaces("phy#0\n\tInterface wlan0\n\t\tifindex 3\n"); len(got) != 1 || got[0] != "wlan0" {
+		t.Fatalf("parseIWDevInterfaces() = %q", got)
+	}
+	if got := wifiChannel(2437); got != "6 (2.4GHz)" {
+		t.Fatalf("wifiChannel(2437) = %q", got)
+	}
+}
+
+func TestWithWiFi(t *testing.T) {
+	if card := withWiFi(cardData{}, nil, 60); len(card.lines) != 0 {
+		t.Fatalf("ethernet-only card gained %q", card.lines)
+	}
+	card := withWiFi(cardData{}, &WiFiStatus{SSID: "HomeNet", RSSI: -58}, 60)
+	if got := stripANSI(card.lines[0]); got != "WiFi   ▮▮▮▮▯ -58 dBm HomeNet" {
+		t.Fatalf("wifi line = %q", got)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"time"
+)
+
+const (
+	moversWindow = 30 * time.Second
+	moversLimit  = 4
+)
+
+// Minimum moves worth listing, and how many points each unit is worth when
+// ranking movers of different kinds against each other: 1 GB of disk or
+// memory ranks like 10 CPU points, 1 MB/s of traffic like 2.
+const (
+	moverMinPercent = 5.0
+	moverMinBytes   = 100 << 20
+	moverMinRate    = 1.0
+	moverBytesScore = 10.0 / (1 << 30)
+	moverRateScore  = 2.0
+)
+
+// mover is one metric that changed noticeably over the movers window.
+type mover struct {
+	Label string
+	Delta string
+	Up    bool
+	score float64
+}
+
+// moversHistorySamples is how many snapshots cover moversWindow at the refresh
+// interval, plus the baseline itself and one spare for tick jitter.
+func moversHistorySamples(interval time.Duration) int {
+	if interval <= 0 {
+		interval = refreshInterval
+	}
+	return int((moversWindow+interval-1)/interval) + 2
+}
+
+// moversBaseline picks the newest sample at least moversWindow older than
+// now, or the oldest available while history is still short.
+func moversBaseline(samples []MetricsSnapshot, now time.Time) (MetricsSnapshot, bool) {
+	if len(samples) == 0 {
+		return MetricsSnapshot{}, false
+	}
+	base := samples[0]
+	for _, s := range samples {
+		if now.Sub(s.CollectedAt) < moversWindow {
+			break
+		}
+		base = s
+	}
+	return base, true
+}
+
+// biggestMovers ranks what changed most between a and b across processes,
+// memory, disks and interfaces, keeping the top moversLimit.
+func biggestMovers(a, b MetricsSnapshot) []mover {
+	var movers []mover
+	addPercent := func(label string, before, after float64) {
+		if d := after - before; math.Abs(d) >= moverMinPercent {
+			movers = append(movers, mover{label, sprintNum("%+.0f%%", d), d > 0, math.Abs(d)})
+		}
+	}
+	addBytes := func(label string, before, after uint64) {
+		d := float64(after) - float64(before)
+		if math.Abs(d) >= moverMinBytes {
+			movers = append(movers, mover{label, signedBytes(before, after), d > 0, math.Abs(d) * moverBytesScore})
+		}
+	}
+	addRate := func(label string, before, after float64) {
+		d := after - before
+		if math.Abs(d) >= moverMinRate {
+			sign := "+"
+			if d < 0 {
+				sign = "-"
+			}
+			movers = append(movers, mover{label, sign + formatRate(math.Abs(d)), d > 0, math.Abs(d) * moverRateScore})
+		}
+	}
+
+	// Processes only appear while in the top list; one that just entered it
+	// was at most the old list's lowest CPU, so use that as its baseline.
+	beforeProcs := make(map[int]ProcessInfo, len(a.TopProcesses))
+	floor := 0.0
+	for i, p := range a.TopProcesses {
+		beforeProcs[p.PID] = p
+		if i == 0 || p.CPU < floor {
+			floor = p.CPU
+		}
+	}
+	for _, p := range b.TopProcesses {
+		before := floor
+		if prev, ok := beforeProcs[p.PID]; ok {
+			before = prev.CPU
+		}
+		addPercent(shorten(p.Name, 14)+" CPU", before, p.CPU)
+	}
+
+	addPercent("CPU", a.CPU.Usage, b.CPU.Usage)
+	addBytes("Memory", a.Memory.Used, b.Memory.Used)
+	addBytes("Swap", a.Memory.SwapUsed, b.Memory.SwapUsed)
+
+	beforeDisks := make(map[string]DiskStatus, len(a.Disks))
+	for _, d := range a.Disks {
+		beforeDisks[d.Mount] = d
+	}
+	for _, d := range b.Disks {
+		if prev, ok := beforeDisks[d.Mount]; ok {
+			addBytes(diskMoverLabel(d), prev.Used, d.Used)
+		}
+	}
+
+	beforeNet := make(map[string]NetworkStatus, len(a.Network))
+	for _, n := range a.Network {
+		beforeNet[n.Name] = n
+	}
+	for _, n := range b.Network {
+		if prev, ok := beforeNet[n.Name]; ok {
+			addRate(n.Name+" down", prev.RxRateMBs, n.RxRateMBs)
+			addRate(n.Name+" up", prev.TxRateMBs, n.TxRateMBs)
+		}
+	}
+
+	slices.SortStableFunc(movers, func(x, y mover) int { return cmp.Compare(y.score, x.score) })
+	if len(movers) > moversLimit {
+		movers = movers[:moversLimit]
+	}
+	return movers
+}
+
+// diskMoverLabel prefers the short device name (disk0, sda1) over the mount.
+func diskMoverLabel(d DiskStatus) string {
+	if d.Device != "" {
+		return shorten(path.Base(d.Device), 14)
+	}
+	return shorten(d.Mount, 14)
+}
+
+// withMovers appends the movers panel when it is toggled on, diffing the
+// latest snapshot against the one from about moversWindow ago.
+func (m model) withMovers(cards []cardData) []cardData {
+	if !m.view.movers {
+		return cards
+	}
+	var movers []mover
+	base, ok := moversBaseline(m.recent.Snapshots(), m.metrics.CollectedAt)
+	ready := ok && base.CollectedAt.Before(m.metrics.CollectedAt)
+	if ready {
+		movers = biggestMovers(base, m.metrics)
+	}
+	return append(cards, renderMoversCard(movers, ready, m.historyBytes()))
+}
+
+func renderMoversCard(movers []mover, ready bool, historyBytes int) cardData {
+	var lines []string
+	switch {
+	case !ready:
+		lines = append(lines, subtleStyle.Render("Collecting..."))
+	case len(movers) == 0:
+		lines = append(lines, subtleStyle.Render(fmt.Sprintf("Nothing moving in the last %s", moversWindow)))
+	}
+	labelWidth := 0
+	for _, mv := range movers {
+		labelWidth = max(labelWidth, len(mv.Label))
+	}
+	for _, mv := range movers {
+		delta := subtleStyle.Render(mv.Delta)
+		if mv.Up {
+			delta = warnStyle.Render(mv.Delta)
+		}
+		lines = append(lines, fmt.Sprintf("%-*s %s", labelWidth, mv.Label, delta))
+	}
+	lines = append(lines, subtleStyle.Render(fmt.Sprintf("History %s of %s", humanBytes(uint64(historyBytes)), humanBytes(tuiHistoryBudget))))
+	return cardData{icon: iconMovers, title: "Movers", lines: lines}
+}
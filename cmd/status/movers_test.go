@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMoversBaselinePicksSampleFromWindowAgo(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var samples []MetricsSnapshot
+	for _, ago := range []time.Duration{45, 35, 31, 20, 1} {
+		samples = append(samples, MetricsSnapshot{CollectedAt: now.Add(-ago * time.Second)})
+	}
+	base, ok := moversBaseline(samples, now)
+	if !ok || !base.CollectedAt.Equal(now.Add(-31*time.Second)) {
+		t.Fatalf("moversBaseline() = %v, %v; want the 31s-old sample", base.CollectedAt, ok)
+	}
+	// While history is shorter than the window, use the oldest sample.
+	base, _ = moversBaseline(samples[3:], now)
+	if !base.CollectedAt.Equal(now.Add(-20 * time.Second)) {
+		t.Fatalf("short history baseline = %v", base.CollectedAt)
+	}
+	if _, ok := moversBaseline(nil, now); ok {
+		t.Fatal("moversBaseline(nil) reported a baseline")
+	}
+}
+
+func TestMoversHistoryCoversTheWindowAtFastIntervals(t *testing.T) {
+	const interval = 250 * time.Millisecond
+	recent, _, _ := newTUIHistory(defaultTrendSamples, interval)
+	start := time.Now()
+	var now time.Time
+	for i := range 200 {
+		now = start.Add(time.Duration(i) * interval)
+		recent.Add(MetricsSnapshot{CollectedAt: now})
+	}
+	base, ok := moversBaseline(recent.Snapshots(), now)
+	if !ok || now.Sub(base.CollectedAt) < moversWindow {
+		t.Fatalf("baseline is %v old at %v refresh, want at least %v", now.Sub(base.CollectedAt), interval, moversWindow)
+	}
+	if got := moversHistorySamples(time.Second); got != 32 {
+		t.Fatalf("moversHistorySamples(1s) = %d, want 32", got)
+	}
+}
+
+func TestBiggestMoversRanksAcrossKinds(t *testing.T) {
+	a := MetricsSnapshot{
+		TopProcesses: []ProcessInfo{{PID: 1, Name: "Chrome", CPU: 10}, {PID: 2, Name: "idle", CPU: 3}},
+		Disks:        []DiskStatus{{Mount: "/", Device: "/dev/disk0", Used: 100 << 30}},
+		Network:      []NetworkStatus{{Name: "en0", RxRateMBs: 1}},
+	}
+	b := MetricsSnapshot{
+		TopProcesses: []ProcessInfo{{PID: 1, Name: "Chrome", CPU: 35}, {PID: 2, Name: "idle", CPU: 4}, {PID: 9, Name: "rustc", CPU: 80}},
+		Disks:        []DiskStatus{{Mount: "/", Device: "/dev/disk0", Used: 100<<30 + 6<<30}},
+		Network:      []NetworkStatus{{Name: "en0", RxRateMBs: 41}},
+	}
+	movers := biggestMovers(a, b)
+
+	var got []string
+	for _, mv := range movers {
+		got = append(got, mv.Label+" "+mv.Delta)
+	}
+	want := []string{"en0 down +40 MB/s", "rustc CPU +77%", "disk0 +6.0 GiB", "Chrome CPU +25%"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("biggestMovers() = %q, want %q", got, want)
+	}
+}
+
+func TestMoversPanelToggles(t *testing.T) {
+	now := time.Now()
+	recent, cpuTrend, memTrend := newTUIHistory(defaultTrendSamples, refreshInterval)
+	m := model{recent: recent, cpuTrend: cpuTrend, memTrend: memTrend}
+	m.recent.Add(MetricsSnapshot{CollectedAt: now.Add(-30 * time.Second), CPU: CPUStatus{Usage: 10}})
+	m.metrics = MetricsSnapshot{CollectedAt: now, CPU: CPUStatus{Usage: 60}}
+	m.recent.Add(m.metrics)
+
+	if cards := m.withMovers(nil); len(cards) != 0 {
+		t.Fatalf("movers panel shown while toggled off: %d cards", len(cards))
+	}
+	m.view.movers = true
+	cards := m.withMovers(nil)
+	if len(cards) != 1 || cards[0].title != "Movers" {
+		t.Fatalf("withMovers() = %+v", cards)
+	}
+	if line := stripANSI(cards[0].lines[0]); line != "CPU +50%" {
+		t.Fatalf("movers line = %q", line)
+	}
+	last := stripANSI(cards[0].lines[len(cards[0].lines)-1])
+	if !strings.HasPrefix(last, "History ") || !strings.HasSuffix(last, " of 2.0 MiB") {
+		t.Fatalf("movers card missing history size, last line = %q", last)
+	}
+}
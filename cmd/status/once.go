@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+const (
+	onceSampleGap     = 800 * time.Millisecond // Rates need two samples
+	onceFallbackWidth = 80
+)
+
+// runOnceMode prints one frame of the card layout to stdout and exits, for
+// cron jobs and SSH sessions. A fast collect primes the network and disk IO
+// counters so the full collect after onceSampleGap reports real rates.
+func runOnceMode() {
+	collector := newCollectorFromFlags()
+	_, _ = collector.CollectFast()
+	time.Sleep(onceSampleGap)
+	data, err := collector.Collect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error collecting metrics: %v\n", err)
+		os.Exit(1)
+	}
+	persistRateState(collector)
+	fmt.Println(renderOnceFrame(data, terminalWidth(os.Stdout)))
+}
+
+// renderOnceFrame renders the TUI frame for a single snapshot with the mole
+// standing still and without padding to the terminal height.
+func renderOnceFrame(data MetricsSnapshot, width int) string {
+	m := model{
+		ready:     true,
+		metrics:   data,
+		width:     width,
+		catHidden: loadCatHidden() || *noMole,
+		view:      activeConfig.applyProfile(viewOptions{absolute: *absoluteFigures, topProcs: *topProcCount, sortByMem: *processSort == processSortMem, oneColumn: *singleColumn}, *profileName),
+	}
+	return m.View()
+}
+
+// terminalWidth falls back to onceFallbackWidth when f is not a terminal
+// (cron, pipes).
+func terminalWidth(f *os.File) int {
+	if w, _, err := term.GetSize(f.Fd()); err == nil && w > 0 {
+		return w
+	}
+	return onceFallbackWidth
+}
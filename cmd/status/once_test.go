@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderOnceFrameIsStableAndUnpadded(t *testing.T) {
+	data := MetricsSnapshot{
+		CollectedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		HealthScore: 90,
+		CPU:         CPUStatus{Usage: 12, LogicalCPU: 8},
+		Memory:      MemoryStatus{Used: 8 << 30, Total: 16 << 30, UsedPercent: 50},
+		Network:     []NetworkStatus{{Name: "en0", RxRateMBs: 1.5}},
+	}
+	first := renderOnceFrame(data, 100)
+	if first != renderOnceFrame(data, 100) {
+		t.Fatal("renderOnceFrame() differs between calls; the mole should not move")
+	}
+	plain := stripANSI(first)
+	for _, want := range []string{"CPU", "Memory", "Network"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("frame missing %q card", want)
+		}
+	}
+	if strings.HasSuffix(first, "\n") {
+		t.Error("frame is padded with trailing newlines")
+	}
+}
+
+func TestTerminalWidthFallsBackWhenNotATerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got := terminalWidth(f); got != onceFallbackWidth {
+		t.Fatalf("terminalWidth(file) = %d, want %d", got, onceFallbackWidth)
+	}
+}
+
+func TestNarrowAndSingleColumnLayoutsDoNotWrap(t *testing.T) {
+	data := MetricsSnapshot{
+		CollectedAt:  time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		HealthScore:  72,
+		CPU:          CPUStatus{Usage: 64, LogicalCPU: 8, PerCore: []float64{90, 80, 70, 60}},
+		Memory:       MemoryStatus{Used: 12 << 30, Total: 16 << 30, UsedPercent: 75, SwapUsed: 1 << 30, SwapTotal: 2 << 30},
+		Disks:        []DiskStatus{{Mount: "/", Used: 400 << 30, Total: 500 << 30, UsedPercent: 80}},
+		Network:      []NetworkStatus{{Name: "en0", RxRateMBs: 12.5, TxRateMBs: 1.5, IP: "192.168.1.20"}},
+		TopProcesses: []ProcessInfo{{Name: "Google Chrome Helper (Renderer)", PID: 4821, CPU: 55, Memory: 4.5}},
+	}
+	for _, tt := range []struct {
+		width     int
+		oneColumn bool
+	}{{40, false}, {120, true}} {
+		m := model{ready: true, metrics: data, width: tt.width, catHidden: true, view: viewOptions{oneColumn: tt.oneColumn}}
+		plain := stripANSI(m.View())
+		for _, line := range strings.Split(plain, "\n") {
+			if w := lipgloss.Width(line); w > tt.width {
+				t.Fatalf("width %d: line is %d columns: %q", tt.width, w, line)
+			}
+		}
+		for _, line := range strings.Split(plain, "\n") {
+			if strings.Contains(line, "CPU") && strings.Contains(line, "Memory") {
+				t.Fatalf("width %d: cards side by side: %q", tt.width, line)
+			}
+		}
+	}
+}
+
+func TestFormatStatusLinePlain(t *testing.T) {
+	data := MetricsSnapshot{
+		HealthScore: 87,
+		CPU:         CPUStatus{Usage: 23.4},
+		Memory:      MemoryStatus{UsedPercent: 61},
+		Disks:       []DiskStatus{{Mount: "/", UsedPercent: 74}},
+		Network: []NetworkStatus{
+			{Name: "en0", RxRateMBs: 1.0, TxRateMBs: 0.3},
+			{Name: "en1", RxRateMBs: 0.2},
+		},
+		Thermal: ThermalStatus{CPUTemp: 54.2},
+	}
+	got := formatStatusLine(data, false)
+	want := "CPU 23% MEM 61% DISK 74% NET ↓" + formatRateCompact(1.2) + "↑" + formatRateCompact(0.3) + " TEMP 54° ♥87"
+	if got != want {
+		t.Fatalf("formatStatusLine() = %q, want %q", got, want)
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Fatal("plain status line contains escape codes")
+	}
+
+	data.Disks, data.Thermal = nil, ThermalStatus{}
+	if got := formatStatusLine(data, false); strings.Contains(got, "DISK") || strings.Contains(got, "TEMP") {
+		t.Fatalf("missing disk/temperature should be omitted, got %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// killNoticeDuration is how long the result of a kill stays on screen.
+const killNoticeDuration = 4 * time.Second
+
+var selectedRowStyle = lipgloss.NewStyle().Reverse(true)
+
+// terminateProcess sends SIGTERM; overridable in tests.
+var terminateProcess = func(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Signal(syscall.SIGTERM)
+}
+
+// killResultMsg reports a finished terminateProcess call.
+type killResultMsg struct {
+	proc ProcessInfo
+	err  error
+}
+
+// clearKillNoticeMsg expires a notice, unless a newer one replaced it.
+type clearKillNoticeMsg struct{ notice string }
+
+// shownProcesses is the slice of TopProcesses the process card lists.
+func (m model) shownProcesses() []ProcessInfo {
+	limit := m.view.topProcs
+	if limit <= 0 {
+		limit = defaultShownProcesses
+	}
+	return m.metrics.TopProcesses[:min(limit, len(m.metrics.TopProcesses))]
+}
+
+// moveProcessSelection steps the highlighted process row up or down. The
+// selection follows the PID, so it stays on the process as rows reorder.
+func (m model) moveProcessSelection(step int) model {
+	procs := m.shownProcesses()
+	if len(procs) == 0 {
+		m.view.selectedPID = 0
+		return m
+	}
+	i := processIndex(procs, m.view.selectedPID)
+	switch {
+	case i < 0 && step > 0:
+		i = 0
+	case i < 0:
+		i = len(procs) - 1
+	default:
+		i = min(max(i+step, 0), len(procs)-1)
+	}
+	m.view.selectedPID = procs[i].PID
+	return m
+}
+
+func processIndex(procs []ProcessInfo, pid int) int {
+	if pid <= 0 {
+		return -1
+	}
+	for i, p := range procs {
+		if p.PID == pid {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateKillConfirm handles the key pressed while a kill awaits
+// confirmation: y sends SIGTERM, anything else cancels.
+func (m model) updateKillConfirm(key string) (model, tea.Cmd) {
+	target := *m.killTarget
+	m.killTarget = nil
+	if key != "y" && key != "Y" {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		return killResultMsg{proc: target, err: terminateProcess(target.PID)}
+	}
+}
+
+// handleKillResult turns the outcome into a short notice and schedules its
+// removal.
+func (m model) handleKillResult(msg killResultMsg) (model, tea.Cmd) {
+	m.killNotice = formatKillNotice(msg.proc, msg.err)
+	if msg.err == nil {
+		m.view.selectedPID = 0
+	}
+	notice := m.killNotice
+	return m, tea.Tick(killNoticeDuration, func(time.Time) tea.Msg { return clearKillNoticeMsg{notice: notice} })
+}
+
+func formatKillNotice(p ProcessInfo, err error) string {
+	target := fmt.Sprintf("%s (%d)", p.Name, p.PID)
+	switch {
+	case err == nil:
+		return okStyle.Render("Sent SIGTERM to " + target)
+	case errors.Is(err, os.ErrPermission):
+		return dangerStyle.Render("Permission denied: " + target + " belongs to another user")
+	case errors.Is(err, os.ErrProcessDone):
+		return subtleStyle.Render(target + " has already exited")
+	default:
+		return dangerStyle.Render(fmt.Sprintf("Could not signal %s: %v", target, err))
+	}
+}
+
+// renderKillBar shows the pending confirmation or the last result.
+func renderKillBar(target *ProcessInfo, notice string) string {
+	if target != nil {
+		return warnStyle.Render(fmt.Sprintf("Send SIGTERM to %s (%d)? y to confirm, any other key cancels", target.Name, target.PID))
+	}
+	return notice
+}
+
+// highlightProcessRow marks the selected row of the process card by
+// replacing its "#2" rank label with a reversed "▸ #2".
+func highlightProcessRow(card cardData, procs []ProcessInfo, pid int) cardData {
+	i := processIndex(procs, pid)
+	if i < 0 || i >= len(card.lines) || len(card.lines[i]) < metricLabelWidth {
+		return card
+	}
+	card.lines = append([]string(nil), card.lines...)
+	label := selectedRowStyle.Render(fmt.Sprintf("%-*s", metricLabelWidth, fmt.Sprintf("▸ #%d", i+1)))
+	card.lines[i] = label + card.lines[i][metricLabelWidth:]
+	return card
+}
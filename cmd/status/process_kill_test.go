@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressKey(t *testing.T, m model, key string) (model, tea.Cmd) {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(model), cmd
+}
+
+func TestProcessSelectionAndKillConfirmation(t *testing.T) {
+	var killed []int
+	old := terminateProcess
+	t.Cleanup(func() { terminateProcess = old })
+	terminateProcess = func(pid int) error {
+		killed = append(killed, pid)
+		return nil
+	}
+
+	m := model{metrics: MetricsSnapshot{TopProcesses: []ProcessInfo{
+		{PID: 10, Name: "Xcode"}, {PID: 20, Name: "node"}, {PID: 30, Name: "zsh"},
+	}}}
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, "down")
+	if m.view.selectedPID != 20 {
+		t.Fatalf("selected PID = %d, want 20", m.view.selectedPID)
+	}
+
+	// Anything but y cancels.
+	m, _ = pressKey(t, m, "x")
+	m, cmd := pressKey(t, m, "n")
+	if m.killTarget != nil || cmd != nil {
+		t.Fatal("n should cancel the kill")
+	}
+
+	m, _ = pressKey(t, m, "x")
+	if m.killTarget == nil || !strings.Contains(renderKillBar(m.killTarget, ""), "node (20)") {
+		t.Fatalf("x should ask to confirm, got %+v", m.killTarget)
+	}
+	m, cmd = pressKey(t, m, "y")
+	if cmd == nil {
+		t.Fatal("y should send the signal")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(model)
+	if len(killed) != 1 || killed[0] != 20 {
+		t.Fatalf("terminated %v, want [20]", killed)
+	}
+	if !strings.Contains(stripANSI(m.killNotice), "Sent SIGTERM to node (20)") {
+		t.Fatalf("notice = %q", m.killNotice)
+	}
+}
+
+func TestFormatKillNoticePermissionDenied(t *testing.T) {
+	got := stripANSI(formatKillNotice(ProcessInfo{PID: 1, Name: "launchd"}, os.ErrPermission))
+	if got != "Permission denied: launchd (1) belongs to another user" {
+		t.Fatalf("notice = %q", got)
+	}
+}
+
+func TestEscClearsSelectionBeforeQuitting(t *testing.T) {
+	m := model{view: viewOptions{selectedPID: 10}}
+	m, cmd := pressKey(t, m, "esc")
+	if m.view.selectedPID != 0 || cmd != nil {
+		t.Fatal("esc should clear the selection first")
+	}
+}
+
+func TestHighlightProcessRowMarksRank(t *testing.T) {
+	procs := []ProcessInfo{{PID: 10, Name: "Xcode", CPU: 50}, {PID: 20, Name: "node", CPU: 10}}
+	card := highlightProcessRow(renderProcessCard(procs, 60, 0, false), procs, 20)
+	if got := stripANSI(card.lines[1]); !strings.HasPrefix(got, "▸ #2  ") || !strings.Contains(got, "node") {
+		t.Fatalf("highlighted row = %q", got)
+	}
+	if strings.HasPrefix(stripANSI(card.lines[0]), "▸") {
+		t.Fatal("only the selected row should be marked")
+	}
+}
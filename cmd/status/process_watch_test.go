@@ -85,6 +85,19 @@ func TestTopProcessesSortsByCPU(t *testing.T) {
 	}
 }
 
+func TestTopProcessesByMemory(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "busy", CPU: 150, Memory: 2, MemoryBytes: 300 << 20},
+		{PID: 2, Name: "hog", CPU: 1, Memory: 30, MemoryBytes: 5 << 30},
+		{PID: 3, Name: "mid", CPU: 5, Memory: 10, MemoryBytes: 1 << 30},
+	}
+
+	top := topProcessesBy(procs, 2, processRanksByMemory)
+	if len(top) != 2 || top[0].PID != 2 || top[1].PID != 3 {
+		t.Fatalf("unexpected memory order: %+v", top)
+	}
+}
+
 func TestProcessNameFromCommand(t *testing.T) {
 	tests := []struct {
 		command string
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Card names used by profiles, in the default layout order.
+var cardNames = []string{"cpu", "memory", "disk", "power", "processes", "network", "gpu", "bluetooth"}
+
+// flagCards is the --cards selection, shown when no profile picks cards;
+// nil shows all.
+var flagCards []string
+
+// parseCardList splits a --cards value such as "network,cpu,disk" into known
+// card names, in order and without duplicates, and the names it did not
+// recognise.
+func parseCardList(raw string) (cards, unknown []string) {
+	for name := range strings.SplitSeq(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "" || slices.Contains(cards, name):
+		case slices.Contains(cardNames, name):
+			cards = append(cards, name)
+		default:
+			unknown = append(unknown, name)
+		}
+	}
+	return cards, unknown
+}
+
+// statusProfile is one entry of the config's "profiles" section: a named
+// preset such as "server" (disk, network, processes first) or "laptop"
+// (power and CPU thermals first).
+type statusProfile struct {
+	Cards            []string           `json:"cards"`              // Card names to show, in order; empty shows all
+	Density          string             `json:"density"`            // "normal" or "compact"; empty keeps --density
+	ProcCPUThreshold *float64           `json:"proc_cpu_threshold"` // Overrides --proc-cpu-threshold
+	HealthWeights    map[string]float64 `json:"health_weights"`     // Replaces the top-level health_weights
+	Units            string             `json:"units"`              // "iec" or "si"; empty keeps --units
+}
+
+func validateProfiles(profiles map[string]statusProfile) error {
+	for name, p := range profiles {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("profiles: empty profile name")
+		}
+		for _, card := range p.Cards {
+			if !slices.Contains(cardNames, card) {
+				return fmt.Errorf("profiles.%s: unknown card %q (want one of %s)", name, card, strings.Join(cardNames, ", "))
+			}
+		}
+		if p.Density != "" && !validDensity(p.Density) {
+			return fmt.Errorf("profiles.%s: density must be normal or compact", name)
+		}
+		if p.ProcCPUThreshold != nil && *p.ProcCPUThreshold < 0 {
+			return fmt.Errorf("profiles.%s: proc_cpu_threshold must be >= 0", name)
+		}
+		if _, err := resolveHealthWeights(p.HealthWeights); err != nil {
+			return fmt.Errorf("profiles.%s: %w", name, err)
+		}
+		if p.Units != "" && p.Units != "iec" && p.Units != "si" {
+			return fmt.Errorf("profiles.%s: units must be iec or si", name)
+		}
+	}
+	return nil
+}
+
+// profileNames returns the configured profiles sorted, for cycling with "p".
+func (cfg statusConfig) profileNames() []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// nextProfile cycles through "" (no profile) and the sorted profile names.
+func (cfg statusConfig) nextProfile(current string) string {
+	names := cfg.profileNames()
+	if len(names) == 0 {
+		return ""
+	}
+	i := slices.Index(names, current)
+	if i == len(names)-1 {
+		return ""
+	}
+	return names[i+1]
+}
+
+// applyProfile sets the view options for profile name; "" restores the
+// flag defaults.
+func (cfg statusConfig) applyProfile(opts viewOptions, name string) viewOptions {
+	opts.profile = name
+	opts.cards = flagCards
+	opts.compact = strings.EqualFold(*density, densityCompact)
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return opts
+	}
+	if len(p.Cards) > 0 {
+		opts.cards = p.Cards
+	}
+	if p.Density != "" {
+		opts.compact = strings.EqualFold(p.Density, densityCompact)
+	}
+	return opts
+}
+
+// applyProfileSettings applies the rest of profile name: health weights
+// and the process CPU threshold on the collector, and byte units. Anything
+// the profile leaves out falls back to the config and flags, and an
+// explicit --proc-cpu-threshold or --units still wins. "" restores the
+// defaults.
+func (cfg statusConfig) applyProfileSettings(c *Collector, name string) {
+	p := cfg.Profiles[name]
+	weights := cfg.HealthWeights
+	if p.HealthWeights != nil {
+		weights = p.HealthWeights
+	}
+	threshold := *procCPUThreshold
+	if p.ProcCPUThreshold != nil && !flagWasSet("proc-cpu-threshold") {
+		threshold = *p.ProcCPUThreshold
+	}
+	if c != nil {
+		w, _ := resolveHealthWeights(weights) // Validated in loadStatusConfig
+		c.setTuning(w, threshold)
+	}
+	siByteUnits.Store(*byteUnitsMode == "si")
+	if p.Units != "" && !flagWasSet("units") {
+		siByteUnits.Store(p.Units == "si")
+	}
+}
+
+// applyProfileFlags checks that --profile names a configured profile.
+func applyProfileFlags(cfg statusConfig, name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("--profile %q not found in config (have: %s)", name, strings.Join(cfg.profileNames(), ", "))
+	}
+	return nil
+}
+
+func flagWasSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// selectCards keeps only the chosen cards, in the chosen order.
+func selectCards(named map[string]cardData, order []string) []cardData {
+	var cards []cardData
+	for _, name := range selectCardNames(named, order) {
+		cards = append(cards, named[name])
+	}
+	return cards
+}
+
+// selectCardNames is the names from order (all cards when empty) that named
+// has, in order.
+func selectCardNames(named map[string]cardData, order []string) []string {
+	if len(order) == 0 {
+		order = cardNames
+	}
+	var names []string
+	for _, name := range order {
+		if _, ok := named[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLoadStatusConfigProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	write := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"profiles": {"server": {"cards": ["disk", "network", "processes"], "proc_cpu_threshold": 300}, "laptop": {"cards": ["power", "cpu"], "density": "compact"}}}`)
+	cfg, err := loadStatusConfig(path, true)
+	if err != nil {
+		t.Fatalf("loadStatusConfig() error = %v", err)
+	}
+	if got := cfg.profileNames(); strings.Join(got, ",") != "laptop,server" {
+		t.Fatalf("profileNames = %v", got)
+	}
+
+	write(`{"profiles": {"server": {"cards": ["disks"]}}}`)
+	if _, err := loadStatusConfig(path, true); err == nil || !strings.Contains(err.Error(), `unknown card "disks"`) {
+		t.Fatalf("expected unknown card error, got %v", err)
+	}
+	write(`{"profiles": {"server": {"density": "tiny"}}}`)
+	if _, err := loadStatusConfig(path, true); err == nil {
+		t.Fatal("expected invalid density error")
+	}
+}
+
+func TestProfileSelectsAndOrdersCards(t *testing.T) {
+	cfg := statusConfig{Profiles: map[string]statusProfile{
+		"server": {Cards: []string{"network", "disk", "gpu"}},
+	}}
+	opts := cfg.applyProfile(viewOptions{}, "server")
+	cards := buildCards(MetricsSnapshot{}, 40, opts)
+	var titles []string
+	for _, c := range cards {
+		titles = append(titles, c.title)
+	}
+	// gpu has no data to show, so it is skipped rather than rendered empty.
+	if strings.Join(titles, ",") != "Network,Disk" {
+		t.Fatalf("server profile cards = %v, want Network,Disk", titles)
+	}
+
+	if all := buildCards(MetricsSnapshot{}, 40, cfg.applyProfile(opts, "")); len(all) != 6 {
+		t.Fatalf("no profile should show every card, got %d", len(all))
+	}
+}
+
+func TestCardsFlagSelectsOrderAndProfileOverrides(t *testing.T) {
+	cards, unknown := parseCardList(" Network,cpu,,sensors,cpu,disk")
+	if strings.Join(cards, ",") != "network,cpu,disk" || strings.Join(unknown, ",") != "sensors" {
+		t.Fatalf("parseCardList = %v, unknown %v", cards, unknown)
+	}
+
+	flagCards = cards
+	t.Cleanup(func() { flagCards = nil })
+	cfg := statusConfig{Profiles: map[string]statusProfile{
+		"laptop": {Cards: []string{"power"}},
+		"dense":  {Density: densityCompact},
+	}}
+	titles := func(opts viewOptions) string {
+		var out []string
+		for _, c := range buildCards(MetricsSnapshot{}, 40, opts) {
+			out = append(out, c.title)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := titles(cfg.applyProfile(viewOptions{}, "")); got != "Network,CPU,Disk" {
+		t.Fatalf("--cards layout = %s", got)
+	}
+	if got := titles(cfg.applyProfile(viewOptions{}, "laptop")); got != "Power" {
+		t.Fatalf("profile cards should win over --cards, got %s", got)
+	}
+	if opts := cfg.applyProfile(viewOptions{}, "dense"); strings.Join(opts.cards, ",") != "network,cpu,disk" {
+		t.Fatalf("profile without cards should keep --cards, got %v", opts.cards)
+	}
+}
+
+func TestModelCyclesProfiles(t *testing.T) {
+	old := activeConfig
+	t.Cleanup(func() { activeConfig = old })
+	activeConfig = statusConfig{Profiles: map[string]statusProfile{
+		"laptop": {Cards: []string{"power"}, Density: "compact"},
+		"server": {Cards: []string{"disk"}},
+	}}
+
+	m := model{ready: true}
+	var seen []string
+	for range 3 {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+		m = updated.(model)
+		seen = append(seen, m.view.profile)
+		if m.view.profile == "laptop" && !m.view.compact {
+			t.Fatal("laptop profile should switch to compact density")
+		}
+	}
+	if strings.Join(seen, ",") != "laptop,server," {
+		t.Fatalf("profile cycle = %q, want laptop,server,(none)", seen)
+	}
+	if m.view.cards != nil || m.view.compact {
+		t.Fatalf("cycling back to no profile should restore defaults, got %+v", m.view)
+	}
+}
+
+func TestApplyProfileFlagsRejectsUnknownProfile(t *testing.T) {
+	cfg := statusConfig{Profiles: map[string]statusProfile{"server": {}}}
+	if err := applyProfileFlags(cfg, "laptop"); err == nil || !strings.Contains(err.Error(), "server") {
+		t.Fatalf("expected unknown profile error listing server, got %v", err)
+	}
+	if err := applyProfileFlags(cfg, "server"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCyclingProfilesReappliesWeightsThresholdAndUnits(t *testing.T) {
+	oldConfig, oldUnits := activeConfig, siByteUnits.Load()
+	t.Cleanup(func() { activeConfig = oldConfig; siByteUnits.Store(oldUnits) })
+	threshold := 250.0
+	activeConfig = statusConfig{
+		HealthWeights: map[string]float64{"cpu": 50},
+		Profiles: map[string]statusProfile{
+			"gpu": {HealthWeights: map[string]float64{"gpu": 40}, ProcCPUThreshold: &threshold, Units: "si"},
+		},
+	}
+	if err := validateProfiles(activeConfig.Profiles); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCollector(ProcessWatchOptions{Enabled: true, CPUThreshold: *procCPUThreshold})
+	activeConfig.applyProfileSettings(c, "")
+	base := c.currentHealthWeights()
+	m := model{ready: true, collector: c}
+	press := func() {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+		m = updated.(model)
+	}
+
+	press()
+	if w := c.currentHealthWeights(); w.GPU == 0 || w == base {
+		t.Fatalf("gpu profile weights = %+v", w)
+	}
+	if c.processWatcher.options.CPUThreshold != 250 || c.processWatch.CPUThreshold != 250 || !siByteUnits.Load() {
+		t.Fatalf("gpu profile threshold %v, units si %v", c.processWatcher.options.CPUThreshold, siByteUnits.Load())
+	}
+
+	press()
+	if w := c.currentHealthWeights(); w != base || c.processWatcher.options.CPUThreshold != *procCPUThreshold || siByteUnits.Load() {
+		t.Fatalf("no profile should restore the config weights and flags, got %+v", w)
+	}
+
+	bad := map[string]statusProfile{"x": {Units: "metric"}}
+	if err := validateProfiles(bad); err == nil {
+		t.Fatal("unknown units should be rejected")
+	}
+}
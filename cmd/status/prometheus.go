@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// promWriter renders gauges in the Prometheus text exposition format. Each
+// metric's HELP and TYPE lines are written once, before its first sample,
+// so labeled series (one per disk or interface) share a header.
+type promWriter struct {
+	w    io.Writer
+	seen map[string]bool
+}
+
+func newPromWriter(w io.Writer) *promWriter {
+	return &promWriter{w: w, seen: make(map[string]bool)}
+}
+
+// gauge writes one sample; labels are name/value pairs.
+func (p *promWriter) gauge(name, help string, value float64, labels ...string) {
+	if !p.seen[name] {
+		p.seen[name] = true
+		fmt.Fprintf(p.w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i] + `="` + promEscape(labels[i+1]) + `"`)
+		}
+		b.WriteByte('}')
+	}
+	fmt.Fprintf(p.w, "%s %s\n", b.String(), strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// promEscape escapes a label value: backslash, double quote and newline.
+func promEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// writePrometheusMetrics renders the gauges for one snapshot. Rates are
+// converted from MB/s to bytes per second, following Prometheus base units.
+func writePrometheusMetrics(w io.Writer, m MetricsSnapshot) {
+	p := newPromWriter(w)
+	const mb = 1024 * 1024
+	p.gauge("mole_health_score", "Overall health score (0-100).", float64(m.HealthScore))
+	p.gauge("mole_cpu_usage_percent", "CPU usage across all cores.", m.CPU.Usage)
+	p.gauge("mole_cpu_load1", "One-minute load average.", m.CPU.Load1)
+	p.gauge("mole_memory_used_percent", "Memory in use.", m.Memory.UsedPercent)
+	p.gauge("mole_memory_used_bytes", "Memory in use, in bytes.", float64(m.Memory.Used))
+	p.gauge("mole_memory_swap_used_bytes", "Swap in use, in bytes.", float64(m.Memory.SwapUsed))
+	for _, d := range m.Disks {
+		p.gauge("mole_disk_used_percent", "Disk space in use per mount.", d.UsedPercent, "mount", d.Mount)
+	}
+	p.gauge("mole_disk_read_bytes_per_second", "Disk read rate.", m.DiskIO.ReadRate*mb)
+	p.gauge("mole_disk_write_bytes_per_second", "Disk write rate.", m.DiskIO.WriteRate*mb)
+	for _, n := range m.Network {
+		p.gauge("mole_network_receive_bytes_per_second", "Receive rate per interface.", n.RxRateMBs*mb, "interface", n.Name)
+	}
+	for _, n := range m.Network {
+		p.gauge("mole_network_transmit_bytes_per_second", "Transmit rate per interface.", n.TxRateMBs*mb, "interface", n.Name)
+	}
+	if m.Thermal.CPUTemp > 0 {
+		p.gauge("mole_cpu_temp_celsius", "CPU temperature.", m.Thermal.CPUTemp)
+	}
+	if m.Thermal.GPUTemp > 0 {
+		p.gauge("mole_gpu_temp_celsius", "GPU temperature.", m.Thermal.GPUTemp)
+	}
+	if len(m.Batteries) > 0 {
+		p.gauge("mole_battery_percent", "Battery charge.", m.Batteries[0].Percent)
+	}
+}
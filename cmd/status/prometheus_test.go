@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	var b strings.Builder
+	writePrometheusMetrics(&b, MetricsSnapshot{
+		HealthScore: 91,
+		CPU:         CPUStatus{Usage: 12.5},
+		Disks:       []DiskStatus{{Mount: "/", UsedPercent: 70}, {Mount: `/Volumes/My "Disk"`, UsedPercent: 5}},
+		Network:     []NetworkStatus{{Name: "en0", RxRateMBs: 1, TxRateMBs: 0.5}, {Name: "utun3"}},
+		Thermal:     ThermalStatus{CPUTemp: 48.5},
+	})
+	out := b.String()
+
+	for _, want := range []string{
+		"# TYPE mole_health_score gauge\nmole_health_score 91\n",
+		"mole_cpu_usage_percent 12.5\n",
+		`mole_disk_used_percent{mount="/"} 70` + "\n",
+		`mole_disk_used_percent{mount="/Volumes/My \"Disk\""} 5` + "\n",
+		`mole_network_receive_bytes_per_second{interface="en0"} 1048576` + "\n",
+		`mole_network_transmit_bytes_per_second{interface="en0"} 524288` + "\n",
+		"mole_cpu_temp_celsius 48.5\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "# TYPE mole_disk_used_percent gauge"); n != 1 {
+		t.Errorf("disk gauge header written %d times, want once", n)
+	}
+	if strings.Contains(out, "mole_gpu_temp_celsius") || strings.Contains(out, "mole_battery_percent") {
+		t.Errorf("unavailable readings exported:\n%s", out)
+	}
+}
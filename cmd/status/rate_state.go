@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+// rateStateMaxAge is how old saved counters may be before they are ignored;
+// past that a rate averaged since the last run says little about now.
+const rateStateMaxAge = 5 * time.Minute
+
+// rateState is the network and disk IO counters saved on exit with
+// --persist-rates, so the next run's first sample already has a baseline.
+type rateState struct {
+	SavedAt     time.Time                      `json:"saved_at"`
+	NetAt       time.Time                      `json:"net_at"`
+	Net         map[string]net.IOCountersStat  `json:"net"`
+	DiskAt      time.Time                      `json:"disk_at"`
+	Disk        disk.IOCountersStat            `json:"disk"`
+	DiskDevices map[string]disk.IOCountersStat `json:"disk_devices"`
+}
+
+// defaultRateStatePath returns $XDG_STATE_HOME/mole/status-counters.json,
+// falling back to ~/.local/state.
+func defaultRateStatePath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "mole", "status-counters.json")
+}
+
+// saveRateState writes the collector's last counters to path. Counters from
+// a collector that never sampled are not worth keeping.
+func (c *Collector) saveRateState(path string, now time.Time) error {
+	if path == "" || (c.lastNetAt.IsZero() && c.lastDiskAt.IsZero()) {
+		return nil
+	}
+	data, err := json.Marshal(rateState{
+		SavedAt:     now,
+		NetAt:       c.lastNetAt,
+		Net:         c.prevNet,
+		DiskAt:      c.lastDiskAt,
+		Disk:        c.prevDiskIO,
+		DiskDevices: c.prevDiskDevs,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// persistRateState saves c's counters on exit when --persist-rates is set.
+func persistRateState(c *Collector) {
+	if !*persistRates || c == nil {
+		return
+	}
+	if err := c.saveRateState(defaultRateStatePath(), time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: --persist-rates: %v\n", err)
+	}
+}
+
+// restoreRateState loads counters saved by an earlier run, so the first
+// collection reports the average rate since then instead of a warm-up zero.
+// Missing, unreadable or stale state leaves the normal warm-up in place.
+func (c *Collector) restoreRateState(path string, now time.Time) bool {
+	if path == "" {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var state rateState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false
+	}
+	if age := now.Sub(state.SavedAt); age < 0 || age > rateStateMaxAge {
+		return false
+	}
+	if !state.NetAt.IsZero() && len(state.Net) > 0 {
+		c.lastNetAt = state.NetAt
+		c.prevNet = state.Net
+	}
+	if !state.DiskAt.IsZero() {
+		c.lastDiskAt = state.DiskAt
+		c.prevDiskIO = state.Disk
+		c.prevDiskDevs = state.DiskDevices
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+func TestRateStateGivesTheNextRunAnImmediateRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mole", "status-counters.json")
+	start := time.Now()
+
+	prev := &Collector{
+		prevNet:    map[string]net.IOCountersStat{"en0": {Name: "en0", BytesRecv: 1 << 20}},
+		lastNetAt:  start,
+		prevDiskIO: disk.IOCountersStat{ReadBytes: 10 << 20},
+		lastDiskAt: start,
+	}
+	if err := prev.saveRateState(path, start); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := diskIOCountersFunc
+	t.Cleanup(func() { diskIOCountersFunc = orig })
+	diskIOCountersFunc = func(...string) (map[string]disk.IOCountersStat, error) {
+		return map[string]disk.IOCountersStat{"disk0": {Name: "disk0", ReadBytes: 30 << 20}}, nil
+	}
+
+	next := &Collector{}
+	if !next.restoreRateState(path, start.Add(10*time.Second)) {
+		t.Fatal("fresh state was not restored")
+	}
+	if next.prevNet["en0"].BytesRecv != 1<<20 {
+		t.Fatalf("network counters = %+v", next.prevNet)
+	}
+	io, err := next.sampleDiskIO(start.Add(10 * time.Second))
+	if err != nil || io.ReadRate != 2 {
+		t.Fatalf("first disk sample = %+v, %v; want 2 MB/s from the saved baseline", io, err)
+	}
+
+	if (&Collector{}).restoreRateState(path, start.Add(rateStateMaxAge+time.Second)) {
+		t.Fatal("stale state should fall back to the warm-up")
+	}
+	if (&Collector{}).restoreRateState(filepath.Join(t.TempDir(), "missing.json"), start) {
+		t.Fatal("missing state reported as restored")
+	}
+}
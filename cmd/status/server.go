@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const serverReadHeaderTimeout = 5 * time.Second
+
+// serverOptions configures the optional HTTP metrics endpoint.
+type serverOptions struct {
+	Addr      string
+	TLSCert   string
+	TLSKey    string
+	AuthToken string
+}
+
+// normalizeServeAddr binds to loopback when the address omits a host (":9100"),
+// so exposing metrics on a network interface always requires naming it.
+func normalizeServeAddr(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid --serve address %q: %w", addr, err)
+	}
+	if port == "" {
+		return "", fmt.Errorf("invalid --serve address %q: missing port", addr)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// metricsServer answers scrapes from one warm Collector. Collection is
+// serialized because the Collector keeps rate and cache state between calls.
+type metricsServer struct {
+	mu        sync.Mutex
+	collector *Collector
+	state     watchState
+}
+
+func newMetricsServer(collector *Collector) *metricsServer {
+	return &metricsServer{collector: collector}
+}
+
+func (s *metricsServer) snapshot() (MetricsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.collect(s.collector)
+}
+
+func (s *metricsServer) handler(authToken string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics.json", s.serveJSON)
+	mux.HandleFunc("/metrics", s.servePrometheus)
+	return requireAuth(authToken, mux)
+}
+
+func (s *metricsServer) serveJSON(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	snap, err := s.snapshot()
+	if err != nil && snap.CollectedAt.IsZero() {
+		http.Error(w, "collect failed: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(snap)
+}
+
+// servePrometheus answers scrapes in the Prometheus text format from the
+// same fresh snapshot /metrics.json serves.
+func (s *metricsServer) servePrometheus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	snap, err := s.snapshot()
+	if err != nil && snap.CollectedAt.IsZero() {
+		http.Error(w, "collect failed: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", prometheusContentType)
+	writePrometheusMetrics(w, snap)
+}
+
+// requireAuth accepts either "Authorization: Bearer <token>" or basic auth
+// with the token as the password. An empty token disables the check.
+func requireAuth(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestAuthorized(r, token) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="mole status"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+func requestAuthorized(r *http.Request, token string) bool {
+	presented := ""
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		presented = strings.TrimSpace(bearer)
+	} else if _, password, ok := r.BasicAuth(); ok {
+		presented = password
+	}
+	return presented != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// startMetricsServer binds the listener synchronously so address errors surface
+// before the TUI takes over the terminal, then serves in the background.
+func startMetricsServer(opts serverOptions, collector *Collector) (*http.Server, error) {
+	addr, err := normalizeServeAddr(opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{
+		Handler:           newMetricsServer(collector).handler(opts.AuthToken),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+	if opts.TLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(opts.TLSCert, opts.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("load TLS certificate: %w", err)
+		}
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("start metrics server: %w", err)
+	}
+	go func() {
+		var err error
+		if srv.TLSConfig != nil {
+			err = srv.ServeTLS(ln, "", "")
+		} else {
+			err = srv.Serve(ln)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "status: metrics server stopped: %v\n", err)
+		}
+	}()
+	return srv, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeServeAddrDefaultsToLoopback(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{":9100", "127.0.0.1:9100"},
+		{"0.0.0.0:9100", "0.0.0.0:9100"},
+		{"localhost:8080", "localhost:8080"},
+		{"[::1]:9100", "[::1]:9100"},
+	}
+	for _, tt := range tests {
+		got, err := normalizeServeAddr(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeServeAddr(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"9100", "host:", ""} {
+		if _, err := normalizeServeAddr(bad); err == nil {
+			t.Errorf("normalizeServeAddr(%q) should fail", bad)
+		}
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"127.0.0.1:9100":  true,
+		"[::1]:9100":      true,
+		"localhost:9100":  true,
+		"0.0.0.0:9100":    false,
+		"192.168.1.5:900": false,
+	} {
+		if got := isLoopbackAddr(addr); got != want {
+			t.Errorf("isLoopbackAddr(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestRequireAuthAcceptsBearerOrBasicToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := requireAuth("s3cret", ok)
+
+	tests := []struct {
+		name string
+		set  func(*http.Request)
+		want int
+	}{
+		{"missing", func(*http.Request) {}, http.StatusUnauthorized},
+		{"bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") }, http.StatusNoContent},
+		{"wrong bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") }, http.StatusUnauthorized},
+		{"basic", func(r *http.Request) { r.SetBasicAuth("prometheus", "s3cret") }, http.StatusNoContent},
+		{"wrong basic", func(r *http.Request) { r.SetBasicAuth("prometheus", "nope") }, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics.json", nil)
+			tt.set(req)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireAuthWithoutTokenPassesThrough(t *testing.T) {
+	called := false
+	handler := requireAuth("", http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !called {
+		t.Fatal("empty token should not require auth")
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	statsdQueueSize  = 4
+	statsdMaxPacket  = 1432 // Fits one Ethernet frame over IPv4/IPv6 without fragmenting
+	statsdTagsNone   = "none"
+	statsdTagsDog    = "datadog" // mole.cpu.usage:42|g|#host:mac
+	statsdTagsInflux = "influx"  // mole.cpu.usage,host=mac:42|g (Telegraf)
+)
+
+// statsdExporter pushes key metrics as StatsD gauges over UDP. Observe only
+// formats and enqueues; a background worker writes the datagrams, and when the
+// queue is full the snapshot is dropped rather than buffered.
+type statsdExporter struct {
+	conn    net.Conn
+	prefix  string
+	dialect string
+	queue   chan []string
+}
+
+func validStatsdTags(dialect string) bool {
+	switch dialect {
+	case statsdTagsNone, statsdTagsDog, statsdTagsInflux:
+		return true
+	}
+	return false
+}
+
+func newStatsdExporter(addr, prefix, dialect string) (*statsdExporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("--statsd: %w", err)
+	}
+	e := &statsdExporter{
+		conn:    conn,
+		prefix:  strings.TrimSuffix(prefix, "."),
+		dialect: dialect,
+		queue:   make(chan []string, statsdQueueSize),
+	}
+	go e.run()
+	return e, nil
+}
+
+func (e *statsdExporter) Observe(snapshot MetricsSnapshot) {
+	if e == nil {
+		return
+	}
+	select {
+	case e.queue <- e.lines(snapshot):
+	default:
+	}
+}
+
+func (e *statsdExporter) run() {
+	for lines := range e.queue {
+		for _, packet := range packStatsdLines(lines, statsdMaxPacket) {
+			// UDP is fire-and-forget; a missing listener is not an error worth surfacing.
+			_, _ = e.conn.Write(packet)
+		}
+	}
+}
+
+// lines renders the gauges for one snapshot. Rates are MB/s, sizes bytes.
+func (e *statsdExporter) lines(m MetricsSnapshot) []string {
+	var lines []string
+	gauge := func(name string, value float64) {
+		lines = append(lines, e.format(name, value, m.Host))
+	}
+	gauge("health.score", float64(m.HealthScore))
+	gauge("cpu.usage", m.CPU.Usage)
+	gauge("cpu.load1", m.CPU.Load1)
+	gauge("memory.used_percent", m.Memory.UsedPercent)
+	gauge("memory.used_bytes", float64(m.Memory.Used))
+	gauge("memory.swap_used_bytes", float64(m.Memory.SwapUsed))
+	if disk, ok := rootDisk(m.Disks); ok {
+		gauge("disk.used_percent", disk.UsedPercent)
+	}
+	gauge("disk.read_mbs", m.DiskIO.ReadRate)
+	gauge("disk.write_mbs", m.DiskIO.WriteRate)
+	var rx, tx float64
+	for _, n := range m.Network {
+		rx += n.RxRateMBs
+		tx += n.TxRateMBs
+	}
+	gauge("network.rx_mbs", rx)
+	gauge("network.tx_mbs", tx)
+	if len(m.Batteries) > 0 {
+		gauge("battery.percent", m.Batteries[0].Percent)
+	}
+	if m.Thermal.CPUTemp > 0 {
+		gauge("thermal.cpu_temp", m.Thermal.CPUTemp)
+	}
+	if m.Thermal.GPUTemp > 0 {
+		gauge("thermal.gpu_temp", m.Thermal.GPUTemp)
+	}
+	return lines
+}
+
+func (e *statsdExporter) format(name string, value float64, host string) string {
+	if e.prefix != "" {
+		name = e.prefix + "." + name
+	}
+	v := strconv.FormatFloat(value, 'f', -1, 64)
+	host = statsdSanitize(host)
+	if host == "" {
+		return name + ":" + v + "|g"
+	}
+	switch e.dialect {
+	case statsdTagsDog:
+		return name + ":" + v + "|g|#host:" + host
+	case statsdTagsInflux:
+		return name + ",host=" + host + ":" + v + "|g"
+	}
+	return name + ":" + v + "|g"
+}
+
+// statsdSanitize strips the characters StatsD uses as separators.
+func statsdSanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', '#', ',', '=', ' ', '\n':
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// packStatsdLines joins lines with newlines into datagrams of at most limit bytes.
+func packStatsdLines(lines []string, limit int) [][]byte {
+	var packets [][]byte
+	var buf []byte
+	for _, line := range lines {
+		if len(buf) > 0 && len(buf)+1+len(line) > limit {
+			packets = append(packets, buf)
+			buf = nil
+		}
+		if len(buf) > 0 {
+			buf = append(buf, '\n')
+		}
+		buf = append(buf, line...)
+	}
+	if len(buf) > 0 {
+		packets = append(packets, buf)
+	}
+	return packets
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsdFormatDialects(t *testing.T) {
+	cases := map[string]string{
+		statsdTagsNone:   "mole.cpu.usage:42.5|g",
+		statsdTagsDog:    "mole.cpu.usage:42.5|g|#host:web_1",
+		statsdTagsInflux: "mole.cpu.usage,host=web_1:42.5|g",
+	}
+	for dialect, want := range cases {
+		e := &statsdExporter{prefix: "mole", dialect: dialect}
+		if got := e.format("cpu.usage", 42.5, "web:1"); got != want {
+			t.Errorf("%s format = %q, want %q", dialect, got, want)
+		}
+	}
+	if got := (&statsdExporter{dialect: statsdTagsDog}).format("cpu.usage", 1, ""); got != "cpu.usage:1|g" {
+		t.Errorf("no-prefix, no-host format = %q", got)
+	}
+}
+
+func TestPackStatsdLinesSplitsAtLimit(t *testing.T) {
+	packets := packStatsdLines([]string{"a:1|g", "b:2|g", "c:3|g"}, 11)
+	if len(packets) != 2 || string(packets[0]) != "a:1|g\nb:2|g" || string(packets[1]) != "c:3|g" {
+		t.Fatalf("packStatsdLines() = %q", packets)
+	}
+}
+
+func TestStatsdExporterSendsGaugesOverUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp listen unavailable: %v", err)
+	}
+	defer conn.Close()
+
+	e, err := newStatsdExporter(conn.LocalAddr().String(), "mole.", statsdTagsNone)
+	if err != nil {
+		t.Fatalf("newStatsdExporter() error = %v", err)
+	}
+	e.Observe(MetricsSnapshot{HealthScore: 91, CPU: CPUStatus{Usage: 42}})
+
+	buf := make([]byte, statsdMaxPacket)
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read datagram: %v", err)
+	}
+	lines := strings.Split(string(buf[:n]), "\n")
+	for _, want := range []string{"mole.health.score:91|g", "mole.cpu.usage:42|g"} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("datagram missing %q: %q", want, lines)
+		}
+	}
+}
+
+func TestStatsdObserveDropsWhenQueueFull(t *testing.T) {
+	e := &statsdExporter{queue: make(chan []string, 1)}
+	e.Observe(MetricsSnapshot{})
+	done := make(chan struct{})
+	go func() {
+		e.Observe(MetricsSnapshot{})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Observe blocked on a full queue")
+	}
+	var nilExporter *statsdExporter
+	nilExporter.Observe(MetricsSnapshot{})
+}
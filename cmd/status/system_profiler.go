@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	systemProfilerCacheTTL = 30 * time.Second
+	systemProfilerRunLimit = 3 * time.Second
+)
+
+// profilerReport is one cached system_profiler invocation. Its mutex is held
+// while the command runs, so collectors asking for the same report in one
+// tick share a single fork instead of each spawning their own.
+type profilerReport struct {
+	mu  sync.Mutex
+	out string
+	at  time.Time // Last attempt, successful or not
+}
+
+var (
+	profilerReportsMu sync.Mutex
+	profilerReports   = map[string]*profilerReport{}
+)
+
+// readSystemProfiler returns cached system_profiler output for args (e.g.
+// "SPPowerDataType", "-json"), shared by every collector that reads it.
+func readSystemProfiler(args ...string) string {
+	if runtime.GOOS != "darwin" {
+		return ""
+	}
+	key := strings.Join(args, " ")
+	profilerReportsMu.Lock()
+	report, ok := profilerReports[key]
+	if !ok {
+		report = &profilerReport{}
+		profilerReports[key] = report
+	}
+	profilerReportsMu.Unlock()
+	return report.read(time.Now(), args)
+}
+
+// read reruns system_profiler at most once per systemProfilerCacheTTL. A
+// failed or timed-out run also waits out the TTL and keeps serving the
+// previous output, so a hung system_profiler costs one timeout per TTL rather
+// than one per collector per tick.
+func (r *profilerReport) read(now time.Time, args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.at.IsZero() && now.Sub(r.at) < systemProfilerCacheTTL {
+		return r.out
+	}
+	r.at = now
+
+	ctx, cancel := context.WithTimeout(context.Background(), systemProfilerRunLimit)
+	defer cancel()
+	if out, err := runCmd(ctx, "system_profiler", args...); err == nil {
+		r.out = out
+	}
+	return r.out
+}
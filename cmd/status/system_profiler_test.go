@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProfilerReportCachesAndSurvivesFailures(t *testing.T) {
+	orig := runCmd
+	t.Cleanup(func() { runCmd = orig })
+	runs := 0
+	fail := false
+	runCmd = func(_ context.Context, name string, args ...string) (string, error) {
+		runs++
+		if fail {
+			return "", errors.New("signal: killed")
+		}
+		return "Model Name: MacBook Pro", nil
+	}
+
+	var report profilerReport
+	start := time.Now()
+	args := []string{"SPHardwareDataType"}
+	for range 3 {
+		if out := report.read(start, args); out != "Model Name: MacBook Pro" {
+			t.Fatalf("read() = %q", out)
+		}
+	}
+	if runs != 1 {
+		t.Fatalf("expected one system_profiler run within the TTL, got %d", runs)
+	}
+
+	// A hung run after the TTL keeps the previous output and is not retried
+	// until the TTL passes again.
+	fail = true
+	later := start.Add(systemProfilerCacheTTL)
+	if out := report.read(later, args); out != "Model Name: MacBook Pro" {
+		t.Fatalf("read() after failure = %q, want the previous output", out)
+	}
+	report.read(later.Add(time.Second), args)
+	if runs != 2 {
+		t.Fatalf("expected failed runs to back off for the TTL, got %d runs", runs)
+	}
+}
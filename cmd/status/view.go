@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -27,19 +30,31 @@ var (
 )
 
 const (
-	colWidth    = 38
-	iconCPU     = "◉"
-	iconMemory  = "◫"
-	iconGPU     = "◧"
-	iconDisk    = "▥"
-	iconNetwork = "⇅"
-	iconBattery = "◪"
-	iconSensors = "◈"
-	iconProcs   = "❊"
+	colWidth = 38
+	// Terminals this narrow stack cards in one column; two 38-column cards
+	// plus the gutter would wrap.
+	singleColumnMaxWidth = 80
+	iconCPU              = "◉"
+	iconMemory           = "◫"
+	iconGPU              = "◧"
+	iconDisk             = "▥"
+	iconNetwork          = "⇅"
+	iconBattery          = "◪"
+	iconSensors          = "◈"
+	iconProcs            = "❊"
+	iconMovers           = "⇵"
+	iconBluetooth        = "ᛒ"
 
 	metricLabelWidth    = 6
 	processMemoryWidth  = 7
 	processWideMinWidth = 46
+
+	processNameMinWidth   = 4
+	defaultShownProcesses = 3
+	maxShownProcesses     = 20
+
+	defaultTrendSamples = 60 // --history: CPU and memory Trend sparkline samples
+	maxTrendSamples     = 600
 )
 
 // Mole body frames (facing right).
@@ -98,11 +113,26 @@ var moleBodyMirror = [][]string{
 	},
 }
 
+// moleAnchored keeps the mole in place (--still-mole); only the leg frames
+// cycle, so each frame redraws a narrow region instead of the whole row.
+var moleAnchored bool
+
+// moleFrozen stops the animation entirely (--no-animation, --no-mole): the
+// mole stands centered on its first frame and no animation ticks run.
+var moleFrozen bool
+
 // getMoleFrame renders the animated mole.
 func getMoleFrame(animFrame int, termWidth int) string {
 	moleWidth := 15
 	maxPos := max(termWidth-moleWidth, 0)
 
+	if moleFrozen {
+		return padMoleFrame(moleBody[0], maxPos/2)
+	}
+	if moleAnchored {
+		return padMoleFrame(moleBody[animFrame%len(moleBody)], maxPos/2)
+	}
+
 	cycleLength := maxPos * 2
 	if cycleLength == 0 {
 		cycleLength = 1
@@ -122,8 +152,10 @@ func getMoleFrame(animFrame int, termWidth int) string {
 	}
 
 	bodyIdx := animFrame % len(frames)
-	body := frames[bodyIdx]
+	return padMoleFrame(frames[bodyIdx], pos)
+}
 
+func padMoleFrame(body []string, pos int) string {
 	padding := strings.Repeat(" ", pos)
 	var lines []string
 
@@ -134,6 +166,24 @@ func getMoleFrame(animFrame int, termWidth int) string {
 	return strings.Join(lines, "\n")
 }
 
+// viewOptions holds display toggles that change how cards render the same
+// snapshot.
+type viewOptions struct {
+	sinceBoot   bool      // CPU and network show since-boot figures instead of live rates
+	compact     bool      // --density compact: one line per metric, no card headers
+	ambient     bool      // Glanceable screen: big score, slow refresh, no cards
+	absolute    bool      // --absolute: memory and disk lead with sizes, not percentages
+	movers      bool      // Append the biggest-movers panel
+	topProcs    int       // --top-procs: process card rows; zero uses defaultShownProcesses
+	sortByMem   bool      // --sort mem: process rows lead with memory instead of CPU
+	oneColumn   bool      // --single-column: stack full-width cards at any terminal width
+	cpuTrend    []float64 // Recent CPU usage, oldest first, for the Trend line
+	memTrend    []float64 // Recent memory used percent, oldest first
+	profile     string
+	cards       []string // Card names to show, in order; nil shows all
+	selectedPID int      // Process row highlighted for x (terminate); zero selects none
+}
+
 type cardData struct {
 	icon  string
 	title string
@@ -199,6 +249,9 @@ func renderHeader(m MetricsSnapshot, errMsg string, animFrame int, termWidth int
 		}
 		optionalInfoParts = append(optionalInfoParts, uptimeText)
 	}
+	if !compactHeader && m.Procs > 0 {
+		optionalInfoParts = append(optionalInfoParts, subtleStyle.Render(humanCount(m.Procs)+" procs"))
+	}
 	joinInfoParts := func(groups ...[]string) []string {
 		parts := []string{}
 		for _, group := range groups {
@@ -306,6 +359,19 @@ func renderProcessAlertBar(alerts []ProcessAlert, width int) string {
 	return renderBanner(alertBarStyle, text, width)
 }
 
+// renderHookBar shows the latest health hook run so its side effect is visible.
+func renderHookBar(hook *healthHook, width int) string {
+	result, ok := hook.LastResult()
+	if !ok {
+		return ""
+	}
+	style := subtleStyle
+	if result.ExitCode != 0 || result.Err != "" {
+		style = alertBarStyle
+	}
+	return renderBanner(style, formatHookResult(result), width)
+}
+
 func renderBanner(style lipgloss.Style, text string, width int) string {
 	if width > 0 {
 		style = style.MaxWidth(width)
@@ -313,13 +379,19 @@ func renderBanner(style lipgloss.Style, text string, width int) string {
 	return style.Render(text)
 }
 
-func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
+func renderCPUCard(cpu CPUStatus, thermal ThermalStatus, sinceBoot bool) cardData {
 	var lines []string
 
+	if sinceBoot {
+		lines = append(lines, sprintNum("Avg    %s  %5.1f%%", progressBar(cpu.BootUsage), cpu.BootUsage))
+		lines = append(lines, formatLoadLine(cpu))
+		return cardData{icon: iconCPU, title: "CPU since boot", lines: lines}
+	}
+
 	// Line 1: Usage + Temp (Format: 15% @ 30.4°C)
 	usageBar := progressBar(cpu.Usage)
 
-	headerText := fmt.Sprintf("%5.1f%%", cpu.Usage)
+	headerText := sprintNum("%5.1f%%", cpu.Usage)
 	if thermal.CPUTemp > 0 {
 		headerText += fmt.Sprintf(" @ %s°C", colorizeTemp(thermal.CPUTemp))
 	}
@@ -328,6 +400,8 @@ func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
 
 	if cpu.PerCoreEstimated {
 		lines = append(lines, subtleStyle.Render("Per-core data unavailable, using averaged load"))
+	} else if split, ok := splitCoreClusters(cpu); ok {
+		lines = append(lines, split...)
 	} else if len(cpu.PerCore) > 0 {
 		type coreUsage struct {
 			idx int
@@ -342,36 +416,119 @@ func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
 		maxCores := min(len(cores), 2)
 		for i := range maxCores {
 			c := cores[i]
-			lines = append(lines, fmt.Sprintf("Core%-2d %s  %5.1f%%", c.idx+1, progressBar(c.val), c.val))
+			line := sprintNum("Core%-2d %s  %5.1f%%", c.idx+1, progressBar(c.val), c.val)
+			if c.idx < len(cpu.PerCoreTemp) && cpu.PerCoreTemp[c.idx] > 0 {
+				line += fmt.Sprintf(" @ %s°C", colorizeTemp(cpu.PerCoreTemp[c.idx]))
+			}
+			lines = append(lines, line)
 		}
 	}
 
 	// Load line at the end
+	lines = append(lines, formatLoadLine(cpu))
+
+	return cardData{icon: iconCPU, title: "CPU", lines: lines}
+}
+
+// splitCoreClusters summarises Apple Silicon P- and E-clusters separately,
+// since their baselines differ too much for a mixed top-cores list, and then
+// names the busiest core with its cluster (e.g. "P3"). macOS numbers the
+// efficiency cores first, so logical CPUs below ECoreCount are E-cores.
+func splitCoreClusters(cpu CPUStatus) ([]string, bool) {
+	if cpu.PCoreCount == 0 || cpu.ECoreCount == 0 || len(cpu.PerCore) != cpu.PCoreCount+cpu.ECoreCount {
+		return nil, false
+	}
+	eCores := cpu.PerCore[:cpu.ECoreCount]
+	pCores := cpu.PerCore[cpu.ECoreCount:]
+	pAvg, eAvg := averageUsage(pCores), averageUsage(eCores)
+
+	lines := []string{
+		sprintNum("Perf   %s  %5.1f%%", progressBar(pAvg), pAvg),
+		sprintNum("Eff    %s  %5.1f%%", progressBar(eAvg), eAvg),
+	}
+
+	busiest := 0
+	for i, v := range cpu.PerCore {
+		if v > cpu.PerCore[busiest] {
+			busiest = i
+		}
+	}
+	label := fmt.Sprintf("E%d", busiest+1)
+	if busiest >= cpu.ECoreCount {
+		label = fmt.Sprintf("P%d", busiest-cpu.ECoreCount+1)
+	}
+	line := sprintNum("%-6s %s  %5.1f%%", label, progressBar(cpu.PerCore[busiest]), cpu.PerCore[busiest])
+	if busiest < len(cpu.PerCoreTemp) && cpu.PerCoreTemp[busiest] > 0 {
+		line += fmt.Sprintf(" @ %s°C", colorizeTemp(cpu.PerCoreTemp[busiest]))
+	}
+	return append(lines, line), true
+}
+
+func averageUsage(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func formatLoadLine(cpu CPUStatus) string {
 	if cpu.PCoreCount > 0 && cpu.ECoreCount > 0 {
-		lines = append(lines, fmt.Sprintf("Load   %.2f / %.2f / %.2f, %dP+%dE",
-			cpu.Load1, cpu.Load5, cpu.Load15, cpu.PCoreCount, cpu.ECoreCount))
-	} else {
-		lines = append(lines, fmt.Sprintf("Load   %.2f / %.2f / %.2f, %d cores",
-			cpu.Load1, cpu.Load5, cpu.Load15, cpu.LogicalCPU))
+		return sprintNum("Load   %.2f / %.2f / %.2f, %dP+%dE",
+			cpu.Load1, cpu.Load5, cpu.Load15, cpu.PCoreCount, cpu.ECoreCount)
 	}
+	return sprintNum("Load   %.2f / %.2f / %.2f, %d cores",
+		cpu.Load1, cpu.Load5, cpu.Load15, cpu.LogicalCPU)
+}
 
-	return cardData{icon: iconCPU, title: "CPU", lines: lines}
+// formatMemoryBreakdownLine shows what "used" is made of, e.g.
+// "Wired 3.1G · Comp 1.2G · Active 6.0G": on macOS much of it is compressed
+// or reclaimable, so a high used percentage alone is not pressure. Trailing
+// parts are dropped to fit the card.
+func formatMemoryBreakdownLine(mem MemoryStatus, cardWidth int) string {
+	var parts []string
+	for _, p := range []struct {
+		label string
+		bytes uint64
+	}{{"Wired", mem.Wired}, {"Comp", mem.Compressed}, {"Active", mem.Active}} {
+		if p.bytes > 0 {
+			parts = append(parts, p.label+" "+humanBytesCompact(p.bytes))
+		}
+	}
+	if cardWidth <= 0 {
+		cardWidth = colWidth
+	}
+	for len(parts) > 0 && lipgloss.Width(strings.Join(parts, " · ")) > cardWidth {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return subtleStyle.Render(strings.Join(parts, " · "))
 }
 
-func renderMemoryCard(mem MemoryStatus, cardWidth int) cardData {
+func renderMemoryCard(mem MemoryStatus, cardWidth int, absolute bool) cardData {
 	// Check if swap is being used (or at least allocated).
 	hasSwap := mem.SwapTotal > 0 || mem.SwapUsed > 0
 
 	var lines []string
-	// Line 1: Used
-	lines = append(lines, fmt.Sprintf("Used   %s  %5.1f%%", progressBar(mem.UsedPercent), mem.UsedPercent))
-
-	// Line 2: Free
 	var freePercent float64
 	if mem.Total > 0 {
 		freePercent = (float64(mem.Available) / float64(mem.Total)) * 100.0
 	}
-	lines = append(lines, fmt.Sprintf("Free   %s  %5.1f%%", progressBar(freePercent), freePercent))
+	if absolute {
+		// Lead with sizes; the percentage becomes the secondary figure.
+		lines = append(lines, formatAbsoluteLine("Used", mem.UsedPercent, humanBytes(mem.Used)+" / "+humanBytes(mem.Total)))
+		lines = append(lines, formatAbsoluteLine("Free", freePercent, humanBytes(mem.Available)))
+	} else {
+		// Line 1: Used
+		lines = append(lines, sprintNum("Used   %s  %5.1f%%", progressBar(mem.UsedPercent), mem.UsedPercent))
+		// Line 2: Free
+		lines = append(lines, sprintNum("Free   %s  %5.1f%%", progressBar(freePercent), freePercent))
+	}
 
 	if hasSwap {
 		// Layout with Swap:
@@ -381,7 +538,7 @@ func renderMemoryCard(mem MemoryStatus, cardWidth int) cardData {
 		if mem.SwapTotal > 0 {
 			swapPercent = (float64(mem.SwapUsed) / float64(mem.SwapTotal)) * 100.0
 		}
-		swapLine := fmt.Sprintf("Swap   %s  %5.1f%%", progressBar(swapPercent), swapPercent)
+		swapLine := sprintNum("Swap   %s  %5.1f%%", progressBar(swapPercent), swapPercent)
 		swapText := fmt.Sprintf("%s/%s", humanBytesCompact(mem.SwapUsed), humanBytesCompact(mem.SwapTotal))
 		swapLineWithText := swapLine + " " + swapText
 		if cardWidth > 0 && lipgloss.Width(swapLineWithText) <= cardWidth {
@@ -391,6 +548,12 @@ func renderMemoryCard(mem MemoryStatus, cardWidth int) cardData {
 		} else {
 			lines = append(lines, swapLine)
 		}
+		if mem.SwapFiles > 0 {
+			lines = append(lines, formatSwapFilesLine(mem))
+		}
+		if mem.SwapInRate > 0 || mem.SwapOutRate > 0 {
+			lines = append(lines, formatSwapActivityLine(mem))
+		}
 
 		lines = append(lines, formatMemoryDetailLine("Total", humanBytes(mem.Used)+" / "+humanBytes(mem.Total), mem.Available, cardWidth))
 	} else {
@@ -405,10 +568,16 @@ func renderMemoryCard(mem MemoryStatus, cardWidth int) cardData {
 			lines = append(lines, fmt.Sprintf("Avail  %s", humanBytes(mem.Available)))
 		}
 	}
+	if line := formatMemoryBreakdownLine(mem, cardWidth); line != "" {
+		lines = append(lines, line)
+	}
 	// Memory pressure status.
 	if mem.Pressure != "" {
 		pressureStyle := okStyle
 		pressureText := "Status " + mem.Pressure
+		if mem.PressurePercent > 0 {
+			pressureText += sprintNum(" · %.0f%% pressure", mem.PressurePercent)
+		}
 		switch mem.Pressure {
 		case "warn":
 			pressureStyle = warnStyle
@@ -420,6 +589,24 @@ func renderMemoryCard(mem MemoryStatus, cardWidth int) cardData {
 	return cardData{icon: iconMemory, title: "Memory", lines: lines}
 }
 
+func formatSwapFilesLine(mem MemoryStatus) string {
+	line := fmt.Sprintf("%-*s %d · %s", metricLabelWidth, "Files", mem.SwapFiles, humanBytes(mem.SwapFileBytes))
+	if mem.SwapGrowing {
+		return warnStyle.Render(line + " ↑ growing")
+	}
+	return line
+}
+
+// formatSwapActivityLine shows pages moved per second, e.g.
+// "Paging in 12/s · out 340/s"; warn-colored while swap-outs thrash.
+func formatSwapActivityLine(mem MemoryStatus) string {
+	line := fmt.Sprintf("%-*s in %.0f/s · out %.0f/s", metricLabelWidth, "Paging", mem.SwapInRate, mem.SwapOutRate)
+	if mem.SwapOutRate >= swapOutThrashRate {
+		return warnStyle.Render(line)
+	}
+	return line
+}
+
 func formatMemoryDetailLine(label string, value string, available uint64, cardWidth int) string {
 	line := fmt.Sprintf("%-6s %s · Avail %s", label, value, humanBytes(available))
 	if cardWidth <= 0 || lipgloss.Width(line) <= cardWidth {
@@ -428,19 +615,34 @@ func formatMemoryDetailLine(label string, value string, available uint64, cardWi
 	return fmt.Sprintf("%-6s %s · Avail %s", label, value, humanBytesCompact(available))
 }
 
-func renderDiskCard(disks []DiskStatus, io DiskIOStatus, _ uint64, _ bool) cardData {
+func renderDiskCard(disks []DiskStatus, io DiskIOStatus, _ uint64, _ bool, absolute bool) cardData {
 	var lines []string
 	if len(disks) == 0 {
 		lines = append(lines, subtleStyle.Render("Collecting..."))
 	} else {
 		internal, external := splitDisks(disks)
+		// With several volumes, one line each names the mount so an external
+		// backup drive can be told apart from the boot disk.
+		perMount := len(disks) > 1
 		addGroup := func(prefix string, list []DiskStatus) {
 			if len(list) == 0 {
 				return
 			}
 			for i, d := range list {
 				label := diskLabel(prefix, i, len(list))
-				lines = append(lines, formatDiskLine(label, d))
+				line := formatDiskLine(label, d)
+				if absolute {
+					line = formatAbsoluteLine(label, d.UsedPercent, humanBytesShort(d.Used)+" / "+humanBytesShort(d.Total))
+				} else if perMount {
+					line = formatDiskMountLine(label, d)
+				}
+				if perMount {
+					line += diskMediaBadge(d)
+				}
+				lines = append(lines, line+smartBadge(d.SmartStatus))
+				if d.InodesUsedPercent > diskWarnThreshold {
+					lines = append(lines, fmt.Sprintf("%-*s %s", metricLabelWidth, "Inodes", dangerStyle.Render(sprintNum("%.0f%%", d.InodesUsedPercent))))
+				}
 			}
 		}
 		addGroup("INTR", internal)
@@ -452,6 +654,9 @@ func renderDiskCard(disks []DiskStatus, io DiskIOStatus, _ uint64, _ bool) cardD
 		}
 	}
 	lines = append(lines, formatDiskIOLine(io))
+	if line := formatBusiestDeviceLine(io); line != "" {
+		lines = append(lines, line)
+	}
 	return cardData{icon: iconDisk, title: "Disk", lines: lines}
 }
 
@@ -486,14 +691,86 @@ func formatDiskLine(label string, d DiskStatus) string {
 	return fmt.Sprintf("%-6s %s  %s used, %s free", label, bar, used, humanBytesShort(free))
 }
 
+// diskMountNameWidth is the mount column in the multi-disk layout, sized so
+// the line fits colWidth.
+const diskMountNameWidth = 12
+
+// formatDiskMountLine is the one-line-per-volume layout used when several
+// disks are listed: "EXTR   Backup        82% · 180G free".
+func formatDiskMountLine(label string, d DiskStatus) string {
+	free := uint64(0)
+	if d.Total > d.Used {
+		free = d.Total - d.Used
+	}
+	name := shorten(diskMountName(d.Mount), diskMountNameWidth)
+	percent := colorizePercent(d.UsedPercent, sprintNum("%3.0f%%", d.UsedPercent))
+	return fmt.Sprintf("%-*s %-*s %s · %s free", metricLabelWidth, label, diskMountNameWidth, name, percent, humanBytesShort(free))
+}
+
+// diskMountName drops the /Volumes/ prefix macOS puts on every external
+// volume, leaving the name the user gave the drive.
+func diskMountName(mount string) string {
+	if name, ok := strings.CutPrefix(mount, "/Volumes/"); ok && name != "" {
+		return name
+	}
+	return mount
+}
+
+// smartBadge is the " OK" or " FAIL" suffix for a disk's SMART verdict.
+func smartBadge(status string) string {
+	switch status {
+	case smartPassed:
+		return " " + okStyle.Render("OK")
+	case smartFailed:
+		return " " + dangerStyle.Render("FAIL")
+	}
+	return ""
+}
+
+// formatAbsoluteLine is the --absolute layout: "Used   ▮▮▮▯▯ 48.2 GB / 64.0 GB 75%",
+// with a short bar and the percentage dimmed after the sizes.
+func formatAbsoluteLine(label string, percent float64, sizes string) string {
+	return fmt.Sprintf("%-*s %s %s %s", metricLabelWidth, label, miniBar(percent), sizes, subtleStyle.Render(sprintNum("%.0f%%", percent)))
+}
+
 func formatDiskMetaLine(d DiskStatus) string {
 	parts := []string{humanBytesShort(d.Total)}
 	if d.Fstype != "" {
 		parts = append(parts, strings.ToUpper(d.Fstype))
 	}
+	if media := diskMediaType(d); media != "" {
+		parts = append(parts, media)
+	}
+	if d.Temperature > 0 {
+		parts = append(parts, tempStyle(d.Temperature).Render(sprintNum("%.0f°C", d.Temperature)))
+	}
 	return fmt.Sprintf("Total  %s", strings.Join(parts, " · "))
 }
 
+// diskMediaType is "SSD" or "HDD", or "" when the media type is unknown.
+func diskMediaType(d DiskStatus) string {
+	switch {
+	case d.Rotational == nil:
+		return ""
+	case *d.Rotational:
+		return "HDD"
+	}
+	return "SSD"
+}
+
+// diskMediaBadge is the " HDD 41°C" suffix on a volume's line in the
+// multi-disk layout, where there is no meta line to hold it.
+func diskMediaBadge(d DiskStatus) string {
+	var badge string
+	if media := diskMediaType(d); media != "" {
+		badge += " " + subtleStyle.Render(media)
+	}
+	if d.Temperature > 0 {
+		badge += " " + tempStyle(d.Temperature).Render(sprintNum("%.0f°C", d.Temperature))
+	}
+	return badge
+}
+
 func formatDiskIOLine(io DiskIOStatus) string {
 	text := fmt.Sprintf("%s R %s · %s W %s MB/s",
 		ioBar(io.ReadRate),
@@ -504,6 +781,21 @@ func formatDiskIOLine(io DiskIOStatus) string {
 	return fmt.Sprintf("%-*s %s", metricLabelWidth, "I/O", text)
 }
 
+// formatBusiestDeviceLine names the disk doing most of the IO, e.g.
+// "Top    disk4 R 12.0 · W 3.0 MB/s". Omitted with a single disk, where it
+// would repeat the I/O line, and while every disk is idle.
+func formatBusiestDeviceLine(io DiskIOStatus) string {
+	if len(io.Devices) < 2 {
+		return ""
+	}
+	top := io.Devices[0]
+	if top.ReadRate+top.WriteRate < 0.1 {
+		return ""
+	}
+	return fmt.Sprintf("%-*s %s R %s · W %s MB/s", metricLabelWidth, "Top", top.Name,
+		formatRateCompact(top.ReadRate), formatRateCompact(top.WriteRate))
+}
+
 func ioBar(rate float64) string {
 	filled := max(min(int(rate/10.0), 5), 0)
 	bar := strings.Repeat("▮", filled) + strings.Repeat("▯", 5-filled)
@@ -516,33 +808,162 @@ func ioBar(rate float64) string {
 	return okStyle.Render(bar)
 }
 
-func renderProcessCard(procs []ProcessInfo, cardWidth int) cardData {
+// renderSystemExtras appends the system-wide lines (process states, limits,
+// log errors, pending updates) below the top processes.
+func renderSystemExtras(card cardData, m MetricsSnapshot) cardData {
+	card = withWatchedProcess(card, m.WatchedProcess)
+	card = withProcessStates(card, m.ProcessStates)
+	card = withSystemLimits(card, m.SystemLimits)
+	card = withLogErrors(card, m.LogErrors)
+	return withUpdates(card, m.PendingUpdates)
+}
+
+// withProcessStates appends the zombie / uninterruptible summary to the
+// process card. Nothing is shown while both counts are zero or unknown.
+func withProcessStates(card cardData, states *ProcessStateCounts) cardData {
+	if states == nil || (states.Zombie == 0 && states.Uninterruptible == 0) {
+		return card
+	}
+	text := fmt.Sprintf("%-*s %d zombie · %d D-state", metricLabelWidth, "State", states.Zombie, states.Uninterruptible)
+	if states.Elevated() {
+		text = warnStyle.Render(text)
+	} else {
+		text = subtleStyle.Render(text)
+	}
+	card.lines = append(card.lines, text)
+	return card
+}
+
+// formatSystemLimitsLine summarises open files against the system limit and,
+// on Linux, the entropy pool; "" when neither is known.
+func formatSystemLimitsLine(l *SystemLimits) string {
+	if l == nil {
+		return ""
+	}
+	var text string
+	if l.MaxFiles > 0 {
+		text = fmt.Sprintf("%-*s %s %s / %s", metricLabelWidth, "FDs", miniBar(l.FilePercent()), humanCount(l.OpenFiles), humanCount(l.MaxFiles))
+		if l.FilesNearLimit() {
+			text += " " + warnStyle.Render("near limit")
+		}
+	} else if l.EntropyPresent {
+		text = fmt.Sprintf("%-*s", metricLabelWidth, "Rand")
+	} else {
+		return ""
+	}
+	if l.EntropyPresent {
+		entropy := fmt.Sprintf("entropy %d", l.EntropyAvail)
+		if l.EntropyLow() {
+			entropy = warnStyle.Render(entropy + " low")
+		} else {
+			entropy = subtleStyle.Render(entropy)
+		}
+		if l.MaxFiles > 0 {
+			text += subtleStyle.Render(" · ")
+		} else {
+			text += " "
+		}
+		text += entropy
+	}
+	return text
+}
+
+// formatLogErrorsLine shows system log errors per minute, warning on a spike.
+func formatLogErrorsLine(rate *LogErrorRate) string {
+	if rate == nil {
+		return ""
+	}
+	text := fmt.Sprintf("%-*s %s errors/min", metricLabelWidth, "Logs", humanCount(uint64(rate.PerMinute)))
+	if rate.Spiking {
+		return warnStyle.Render(text + sprintNum(" (usual %.0f)", rate.Baseline))
+	}
+	return subtleStyle.Render(text)
+}
+
+// formatUpdatesLine is an advisory; pending updates never affect the score.
+func formatUpdatesLine(updates *PendingUpdates) string {
+	if updates == nil || updates.Count == 0 {
+		return ""
+	}
+	return subtleStyle.Render(fmt.Sprintf("%-*s %d pending (%s)", metricLabelWidth, "Update", updates.Count, updates.Source))
+}
+
+// withSystemLimits appends the open-files / entropy line to the process card.
+func withSystemLimits(card cardData, limits *SystemLimits) cardData {
+	if line := formatSystemLimitsLine(limits); line != "" {
+		card.lines = append(card.lines, line)
+	}
+	return card
+}
+
+// withLogErrors appends the --log-errors rate to the process card.
+func withLogErrors(card cardData, rate *LogErrorRate) cardData {
+	if line := formatLogErrorsLine(rate); line != "" {
+		card.lines = append(card.lines, line)
+	}
+	return card
+}
+
+// withUpdates appends the --updates advisory to the process card.
+func withUpdates(card cardData, updates *PendingUpdates) cardData {
+	if line := formatUpdatesLine(updates); line != "" {
+		card.lines = append(card.lines, line)
+	}
+	return card
+}
+
+// renderProcessCard leads each row with the CPU bar, or with the memory
+// percent bar under --sort mem, where the size column then shows only RSS.
+func renderProcessCard(procs []ProcessInfo, cardWidth, maxProcs int, byMemory bool) cardData {
 	var lines []string
-	maxProcs := 3
+	if maxProcs <= 0 {
+		maxProcs = defaultShownProcesses
+	}
+	title := "Processes"
+	if byMemory {
+		title = "Processes by memory"
+	}
 	for i, p := range procs {
 		if i >= maxProcs {
 			break
 		}
 		rank := fmt.Sprintf("#%d", i+1)
-		cpuBar := processBar(p.CPU, cardWidth)
-		line := fmt.Sprintf(
+		percent, size := p.CPU, processMemoryText(p)
+		if byMemory {
+			percent, size = p.Memory, ""
+			if p.MemoryBytes > 0 {
+				size = humanBytesCompact(p.MemoryBytes)
+			}
+		}
+		line := sprintNum(
 			"%-*s %s %5.1f%% %*s",
 			metricLabelWidth,
 			rank,
-			cpuBar,
-			p.CPU,
+			processBar(percent, cardWidth),
+			percent,
 			processMemoryWidth,
-			processMemoryText(p),
+			size,
 		)
 		if nameWidth := remainingLineWidth(cardWidth, line); nameWidth > 0 {
-			line += " " + shorten(p.Name, nameWidth)
+			line += " " + processNameWithPID(p, nameWidth)
 		}
 		lines = append(lines, strings.TrimRight(line, " "))
 	}
 	if len(lines) == 0 {
 		lines = append(lines, subtleStyle.Render("Collecting..."))
 	}
-	return cardData{icon: iconProcs, title: "Processes", lines: lines}
+	return cardData{icon: iconProcs, title: title, lines: lines}
+}
+
+// processNameWithPID appends the dimmed PID so same-named processes can be
+// told apart, dropping it when it would squeeze the name below
+// processNameMinWidth.
+func processNameWithPID(p ProcessInfo, width int) string {
+	suffix := fmt.Sprintf(" (%d)", p.PID)
+	if p.PID <= 0 || width-len(suffix) < min(len(p.Name), processNameMinWidth) {
+		return shorten(p.Name, width)
+	}
+	return shorten(p.Name, width-len(suffix)) + subtleStyle.Render(suffix)
 }
 
 func processBar(percent float64, cardWidth int) string {
@@ -557,20 +978,39 @@ func processMemoryText(p ProcessInfo) string {
 		return humanBytesCompact(p.MemoryBytes)
 	}
 	if p.Memory >= 10 {
-		return fmt.Sprintf("M%.0f%%", p.Memory)
+		return sprintNum("M%.0f%%", p.Memory)
 	}
 	return ""
 }
 
-func buildCards(m MetricsSnapshot, width int) []cardData {
-	cards := []cardData{
-		renderCPUCard(m.CPU, m.Thermal),
-		renderMemoryCard(m.Memory, width),
-		renderDiskCard(m.Disks, m.DiskIO, m.TrashSize, m.TrashApprox),
-		renderBatteryCard(m.Batteries, m.Thermal),
-		renderProcessCard(m.TopProcesses, width),
-		renderNetworkCard(m.Network, m.NetworkHistory, m.Proxy, width),
+func buildCards(m MetricsSnapshot, width int, opts viewOptions) []cardData {
+	named := map[string]cardData{
+		"cpu":       renderCPUCard(m.CPU, m.Thermal, opts.sinceBoot),
+		"memory":    renderMemoryCard(m.Memory, width, opts.absolute),
+		"disk":      renderDiskCard(m.Disks, m.DiskIO, m.TrashSize, m.TrashApprox, opts.absolute),
+		"power":     renderBatteryCard(m.Batteries, m.Thermal),
+		"processes": renderSystemExtras(highlightProcessRow(renderProcessCard(m.TopProcesses, width, opts.topProcs, opts.sortByMem), m.TopProcesses, opts.selectedPID), m),
+		"network":   renderNetworkExtras(renderNetworkCard(m.Network, m.NetworkHistory, m.Proxy, m.NetworkTalker, width, opts.sinceBoot), m, width),
+	}
+	if !opts.sinceBoot {
+		named["cpu"] = withTrendLine(named["cpu"], opts.cpuTrend, m.CPU.Usage, width)
+	}
+	named["memory"] = withTrendLine(named["memory"], opts.memTrend, m.Memory.UsedPercent, width)
+	if hasGPUCardData(m.GPU) {
+		named["gpu"] = renderGPUCard(m.GPU, width)
 	}
+	if connected := connectedBluetooth(m.Bluetooth); len(connected) > 0 && !m.Virtualized {
+		named["bluetooth"] = renderBluetoothCard(connected, width)
+	}
+	if m.Virtualized {
+		// Battery and thermal readings in a container or VM are the host's
+		// or made up by the hypervisor.
+		delete(named, "power")
+	}
+	if opts.compact {
+		return buildCompactCards(m, width, opts, selectCardNames(named, opts.cards))
+	}
+	cards := selectCards(named, opts.cards)
 	// Sensors card disabled - redundant with CPU temp
 	// if hasSensorData(m.Sensors) {
 	// 	cards = append(cards, renderSensorsCard(m.Sensors))
@@ -578,26 +1018,297 @@ func buildCards(m MetricsSnapshot, width int) []cardData {
 	return cards
 }
 
+// connectedBluetooth drops paired-but-absent devices and the "No devices"
+// placeholders the collectors return.
+func connectedBluetooth(devices []BluetoothDevice) []BluetoothDevice {
+	var connected []BluetoothDevice
+	for _, d := range devices {
+		if d.Connected {
+			connected = append(connected, d)
+		}
+	}
+	return connected
+}
+
+// renderBluetoothCard lists connected devices with their battery level,
+// e.g. "AirPods Pro          ▮▮▮▮▯  80%"; low batteries turn red.
+func renderBluetoothCard(devices []BluetoothDevice, cardWidth int) cardData {
+	if cardWidth <= 0 || cardWidth > colWidth {
+		cardWidth = colWidth // Keep the level next to the name on wide single-column cards
+	}
+	const levelWidth = 11 // " ▮▮▮▮▯  80%"
+	nameWidth := max(cardWidth-levelWidth, processNameMinWidth)
+	var lines []string
+	for _, d := range devices {
+		name := fmt.Sprintf("%-*s", nameWidth, shorten(d.Name, nameWidth))
+		if d.BatteryPercent <= 0 {
+			lines = append(lines, name+" "+subtleStyle.Render("Connected"))
+			continue
+		}
+		percent := float64(d.BatteryPercent)
+		lines = append(lines, fmt.Sprintf("%s %s %s", name, chargeBar(percent), colorizePercent(100-percent, fmt.Sprintf("%3d%%", d.BatteryPercent))))
+	}
+	return cardData{icon: iconBluetooth, title: "Bluetooth", lines: lines}
+}
+
+// hasGPUCardData reports whether the GPU card has live usage, an eGPU, or
+// attached displays to show; a bare GPU name is already in the header.
+func hasGPUCardData(gpus []GPUStatus) bool {
+	for _, g := range gpus {
+		if gpuHasLiveUsage(g) || g.External || len(g.Displays) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// gpuHasLiveUsage filters out the static (-1) and placeholder entries that
+// collectGPU returns when no usage source is available.
+func gpuHasLiveUsage(g GPUStatus) bool {
+	if g.Usage < 0 {
+		return false
+	}
+	return g.Note == "" || g.Note == gpuSharedMemoryNote || g.CoreCount > 0 || g.MemoryTotal > 0
+}
+
+func renderGPUCard(gpus []GPUStatus, cardWidth int) cardData {
+	var lines []string
+	var displays []DisplayInfo
+	// Static entries (an idle iGPU name next to an eGPU) have no lines of
+	// their own, so only GPUs reporting usage count towards the summary.
+	live := 0
+	for _, g := range gpus {
+		if gpuHasLiveUsage(g) {
+			live++
+		}
+	}
+	if live > 1 {
+		lines = append(lines, formatGPUAggregateLine(gpus))
+	}
+	index := 0
+	for _, g := range gpus {
+		// With several GPUs, name each one so its lines can be told apart.
+		label := ""
+		switch {
+		case g.External:
+			label = "eGPU"
+		case live > 1 && gpuHasLiveUsage(g):
+			label = diskLabel("GPU", index, live)
+		}
+		if gpuHasLiveUsage(g) {
+			index++
+		}
+		if label != "" {
+			line := fmt.Sprintf("%-*s ", metricLabelWidth, label)
+			lines = append(lines, line+shorten(g.Name, max(remainingLineWidth(cardWidth, line), 2)))
+		}
+		if gpuHasLiveUsage(g) {
+			lines = append(lines, sprintNum("Usage  %s  %5.1f%%", progressBar(g.Usage), g.Usage))
+		}
+		if line := formatGPUSensorLine(g); line != "" {
+			lines = append(lines, line)
+		}
+		displays = append(displays, g.Displays...)
+	}
+
+	external := 0
+	for _, d := range displays {
+		if !d.BuiltIn {
+			external++
+		}
+	}
+	extIndex := 0
+	for _, d := range displays {
+		label := "Built"
+		if !d.BuiltIn {
+			extIndex++
+			label = diskLabel("Ext", extIndex-1, external)
+		}
+		line := fmt.Sprintf("%-*s %s", metricLabelWidth, label, formatDisplayMode(d))
+		if !d.BuiltIn && d.Name != "" {
+			if nameWidth := remainingLineWidth(cardWidth, line); nameWidth > 1 {
+				line += " " + subtleStyle.Render(shorten(d.Name, nameWidth))
+			}
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, subtleStyle.Render("No GPU metrics"))
+	}
+	return cardData{icon: iconGPU, title: "GPU", lines: lines}
+}
+
+// formatGPUAggregateLine sums a multi-GPU box into one line, e.g.
+// "All    ▮▮▯▯▯ 47.5% · VRAM 21.0G/48.0G": mean usage of the GPUs that
+// report it and total dedicated memory.
+func formatGPUAggregateLine(gpus []GPUStatus) string {
+	var usage, memUsed, memTotal float64
+	live := 0
+	for _, g := range gpus {
+		if gpuHasLiveUsage(g) {
+			usage += g.Usage
+			live++
+		}
+		if g.MemoryTotal > 0 {
+			memUsed += g.MemoryUsed
+			memTotal += g.MemoryTotal
+		}
+	}
+	var parts []string
+	if live > 0 {
+		usage /= float64(live)
+		parts = append(parts, miniBar(usage)+" "+sprintNum("%.1f%%", usage))
+	}
+	if memTotal > 0 {
+		// nvidia-smi and amdgpu sysfs both report MiB.
+		parts = append(parts, "VRAM "+humanBytesCompact(uint64(memUsed*(1<<20)))+"/"+humanBytesCompact(uint64(memTotal*(1<<20))))
+	}
+	return fmt.Sprintf("%-*s %s", metricLabelWidth, "All", strings.Join(parts, " · "))
+}
+
+// formatGPUSensorLine shows "Temp   72.0°C · 180 W" for GPUs that report
+// either reading; empty otherwise.
+func formatGPUSensorLine(g GPUStatus) string {
+	var parts []string
+	if g.Temperature > 0 {
+		parts = append(parts, colorizeGPUTemp(g.Temperature)+"°C")
+	}
+	if g.PowerWatts > 0 {
+		parts = append(parts, sprintNum("%.0f W", g.PowerWatts))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%-*s %s", metricLabelWidth, "Temp", strings.Join(parts, " · "))
+}
+
+func formatDisplayMode(d DisplayInfo) string {
+	text := d.Resolution
+	if text == "" {
+		text = "Unknown"
+	}
+	if d.RefreshRate > 0 {
+		text += fmt.Sprintf(" @ %dHz", d.RefreshRate)
+	}
+	return text
+}
+
+// chargeBar is a miniBar for charge left: a full battery is green, not "hot".
+func chargeBar(percent float64) string {
+	filled := max(min(int(percent/20), 5), 0)
+	return colorizePercent(100-percent, strings.Repeat("▮", filled)+strings.Repeat("▯", 5-filled))
+}
+
 func miniBar(percent float64) string {
 	filled := max(min(int(percent/20), 5), 0)
 	return colorizePercent(percent, strings.Repeat("▮", filled)+strings.Repeat("▯", 5-filled))
 }
 
-func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy ProxyStatus, cardWidth int) cardData {
+// renderNetworkExtras appends the network lines that come from slower
+// collectors (connections, latency, WiFi, public IP) below the rates.
+func renderNetworkExtras(card cardData, m MetricsSnapshot, width int) cardData {
+	if m.TCPConnections > 0 {
+		card.lines = append(card.lines, fmt.Sprintf("%-*s %d", metricLabelWidth, "Conns", m.TCPConnections))
+	}
+	if line := formatPingLine(m.Ping); line != "" {
+		card.lines = append(card.lines, line)
+	}
+	if line := formatVPNLine(m.VPN); line != "" {
+		card.lines = append(card.lines, line)
+	}
+	card = withWiFi(card, m.WiFi, width)
+	return withPublicIP(card, m.PublicIP)
+}
+
+// formatPingLine renders "Ping   18ms", colored by latency.
+func formatPingLine(probe *LatencyProbe) string {
+	if probe == nil {
+		return ""
+	}
+	var value string
+	switch {
+	case !probe.OK:
+		value = dangerStyle.Render("timeout")
+	case probe.RTT < pingGoodMs:
+		value = okStyle.Render(sprintNum("%.0fms", probe.RTT))
+	case probe.RTT < pingSlowMs:
+		value = warnStyle.Render(sprintNum("%.0fms", probe.RTT))
+	default:
+		value = dangerStyle.Render(sprintNum("%.0fms", probe.RTT))
+	}
+	return fmt.Sprintf("%-*s %s", metricLabelWidth, "Ping", value)
+}
+
+// withPublicIP appends the --public-ip egress address.
+func withPublicIP(card cardData, ip string) cardData {
+	if ip != "" {
+		card.lines = append(card.lines, fmt.Sprintf("%-*s %s", metricLabelWidth, "Public", ip))
+	}
+	return card
+}
+
+// withWiFi appends "WiFi   ▮▮▮▮▯ -58 dBm HomeNet" when associated; the bar
+// is colored by signal quality.
+func withWiFi(card cardData, wifi *WiFiStatus, cardWidth int) cardData {
+	if wifi == nil {
+		return card
+	}
+	// miniBar colors high values as danger; signal needs the reverse.
+	filled := max(min(int(wifiSignalPercent(wifi.RSSI)/20), 5), 0)
+	bar := strings.Repeat("▮", filled) + strings.Repeat("▯", 5-filled)
+	switch {
+	case wifi.RSSI >= -60:
+		bar = okStyle.Render(bar)
+	case wifi.RSSI >= -70:
+		bar = warnStyle.Render(bar)
+	default:
+		bar = dangerStyle.Render(bar)
+	}
+	line := fmt.Sprintf("%-*s %s %d dBm", metricLabelWidth, "WiFi", bar, wifi.RSSI)
+	if nameWidth := remainingLineWidth(cardWidth, line); nameWidth > 1 && wifi.SSID != "" {
+		line += " " + shorten(wifi.SSID, nameWidth)
+	}
+	card.lines = append(card.lines, line)
+	return card
+}
+
+func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy ProxyStatus, talker *NetworkTalker, cardWidth int, sinceBoot bool) cardData {
 	var lines []string
 	var totalRx, totalTx float64
-	var primaryIP string
+	var bootRx, bootTx, sessionRx, sessionTx uint64
+	var primaryIP, en0IP string
+	title := "Network"
 
 	for _, n := range netStats {
 		totalRx += n.RxRateMBs
 		totalTx += n.TxRateMBs
-		if primaryIP == "" && n.IP != "" && n.Name == "en0" {
-			primaryIP = n.IP
+		bootRx += n.RxBootBytes
+		bootTx += n.TxBootBytes
+		sessionRx += n.RxTotalBytes
+		sessionTx += n.TxTotalBytes
+		ip := n.IP
+		if ip == "" {
+			// IPv6-only network: show the global address instead.
+			ip = n.IPv6
 		}
+		if n.Primary && ip != "" {
+			primaryIP = ip
+		}
+		if en0IP == "" && ip != "" && n.Name == "en0" {
+			en0IP = ip
+		}
+	}
+	if primaryIP == "" {
+		// No primary detected (or a snapshot from an older --json): keep en0.
+		primaryIP = en0IP
 	}
 
 	if len(netStats) == 0 {
 		lines = append(lines, subtleStyle.Render("Collecting..."))
+	} else if sinceBoot {
+		title = "Network since boot"
+		lines = append(lines, fmt.Sprintf("Down   %s", humanBytes(bootRx)))
+		lines = append(lines, fmt.Sprintf("Up     %s", humanBytes(bootTx)))
 	} else {
 		// Calculate dynamic width
 		// Layout: "Down   " (7) + graph + "  " (2) + rate (approx 10-12)
@@ -610,10 +1321,19 @@ func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy P
 		txSparkline := sparkline(history.TxHistory, totalTx, graphWidth)
 		lines = append(lines, fmt.Sprintf("Down   %s  %s", rxSparkline, formatRate(totalRx)))
 		lines = append(lines, fmt.Sprintf("Up     %s  %s", txSparkline, formatRate(totalTx)))
+		lines = append(lines, fmt.Sprintf("Total  ↓%s  ↑%s", humanBytes(sessionRx), humanBytes(sessionTx)))
+		if talker != nil {
+			lines = append(lines, formatNetworkTalkerLine(*talker, cardWidth))
+		}
+	}
+	if len(netStats) > 0 {
 		// Show proxy and IP on one line.
 		var infoParts []string
 		if proxy.Enabled {
-			infoParts = append(infoParts, "Proxy "+proxy.Type)
+			infoParts = append(infoParts, formatProxyBadge(proxy))
+			if n := len(proxy.NoProxy); n > 0 {
+				infoParts = append(infoParts, fmt.Sprintf("%d bypass", n))
+			}
 		}
 		if primaryIP != "" {
 			infoParts = append(infoParts, primaryIP)
@@ -622,7 +1342,69 @@ func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy P
 			lines = append(lines, strings.Join(infoParts, " · "))
 		}
 	}
-	return cardData{icon: iconNetwork, title: "Network", lines: lines}
+	return cardData{icon: iconNetwork, title: title, lines: lines}
+}
+
+// formatProxyBadge labels a WireGuard tunnel as a VPN so it is not mistaken
+// for an HTTP or SOCKS proxy, and says when an environment proxy covers
+// only one scheme ("Proxy https only") or sends schemes to different
+// proxies ("Proxy split").
+func formatProxyBadge(proxy ProxyStatus) string {
+	switch {
+	case proxy.Type == "WireGuard":
+		return "VPN WireGuard"
+	case proxySplit(proxy.Routes):
+		return "Proxy split"
+	case len(proxy.Routes) == 1 && proxy.Routes[0].Scheme != "all":
+		return "Proxy " + proxy.Routes[0].Scheme + " only"
+	}
+	return "Proxy " + proxy.Type
+}
+
+func formatNetworkTalkerLine(t NetworkTalker, cardWidth int) string {
+	// Without byte rates (Linux) the process only holds the most connections,
+	// which is not necessarily the one moving the most data; say so.
+	label, detail := "Top", formatRate(t.RxRateMBs+t.TxRateMBs)
+	if t.Connections > 0 {
+		label, detail = "Conns", fmt.Sprintf("most conns (%d)", t.Connections)
+	}
+	prefix := fmt.Sprintf("%-*s ", metricLabelWidth, label)
+	nameWidth := max(remainingLineWidth(cardWidth, prefix+detail)-1, 4)
+	return prefix + shorten(formatProcessLabel(ProcessInfo{PID: t.PID, Name: t.Name}), nameWidth) + " " + subtleStyle.Render(detail)
+}
+
+// withTrendLine inserts a "Trend" sparkline of recent percentages below the
+// card's first line, once there are at least two samples.
+func withTrendLine(card cardData, trend []float64, current float64, cardWidth int) cardData {
+	if len(trend) < 2 || len(card.lines) == 0 {
+		return card
+	}
+	if cardWidth <= 0 {
+		cardWidth = colWidth
+	}
+	// Leave room for the label and a little slack, like the network graphs.
+	graphWidth := max(cardWidth-metricLabelWidth-9, 8)
+	line := fmt.Sprintf("%-*s %s", metricLabelWidth, "Trend", percentSparkline(trend, current, graphWidth))
+	lines := make([]string, 0, len(card.lines)+1)
+	lines = append(lines, card.lines[0], line)
+	card.lines = append(lines, card.lines[1:]...)
+	return card
+}
+
+// percentSparkline draws the most recent width samples on a fixed 0-100
+// scale, so a flat 40% line does not look like a spike the way an autoscaled
+// graph would. It is colored by current, with the progress bar thresholds.
+func percentSparkline(history []float64, current float64, width int) string {
+	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	if len(history) > width {
+		history = history[len(history)-width:]
+	}
+	var builder strings.Builder
+	for _, v := range history {
+		level := int(min(max(v, 0), 100) / 100 * float64(len(blocks)-1))
+		builder.WriteRune(blocks[level])
+	}
+	return colorizePercent(current, builder.String())
 }
 
 // 8 levels: ▁▂▃▄▅▆▇█
@@ -676,86 +1458,126 @@ func renderBatteryCard(batts []BatteryStatus, thermal ThermalStatus) cardData {
 	var lines []string
 	if len(batts) == 0 {
 		lines = append(lines, subtleStyle.Render("No battery"))
-	} else {
-		b := batts[0]
-		statusLower := strings.ToLower(b.Status)
-		percentText := fmt.Sprintf("%5.1f%%", b.Percent)
-		if b.Percent < 20 && statusLower != "charging" && statusLower != "charged" {
-			percentText = dangerStyle.Render(percentText)
-		}
-		lines = append(lines, fmt.Sprintf("Level  %s  %s", batteryProgressBar(b.Percent), percentText))
-
-		// Add capacity line if available.
-		if b.Capacity > 0 {
-			capacityText := fmt.Sprintf("%5d%%", b.Capacity)
-			if b.Capacity < 70 {
-				capacityText = dangerStyle.Render(capacityText)
-			} else if b.Capacity < 85 {
-				capacityText = warnStyle.Render(capacityText)
-			}
-			lines = append(lines, fmt.Sprintf("Health %s  %s", batteryProgressBar(float64(b.Capacity)), capacityText))
+	}
+	for i, b := range batts {
+		label := "Level"
+		if len(batts) > 1 {
+			label = batteryLabel(b, i)
 		}
+		// Adapter, temperature and fan readings are system-wide, so they go
+		// with the last battery only.
+		last := i == len(batts)-1
+		lines = append(lines, renderBatteryLines(b, label, thermal, last)...)
+	}
+	if thermal.GPUTemp > 0 {
+		lines = append(lines, fmt.Sprintf("%-*s %s°C", metricLabelWidth, "GPU", colorizeGPUTemp(thermal.GPUTemp)))
+	}
 
-		if thermal.AdapterPower > 0 && isPoweredByAC(statusLower) {
-			lines = append(lines, fmt.Sprintf("%-6s %s  %6s",
-				"Input",
-				okStyle.Render(plainProgressBar(100)),
-				fmt.Sprintf("%.0fW max", thermal.AdapterPower),
-			))
-		}
+	return cardData{icon: iconBattery, title: "Power", lines: lines}
+}
 
-		statusStyle := subtleStyle
-		if isPoweredByAC(statusLower) {
-			statusStyle = okStyle
-		} else if b.Percent < 20 {
-			statusStyle = dangerStyle
-		}
-		statusText := formatBatteryStatus(b.Status)
-		if b.TimeLeft != "" && b.TimeLeft != "0:00" {
-			statusText += " · " + b.TimeLeft
+// batteryLabel names one of several batteries: its source name when that
+// fits the label column, "BAT<i>" otherwise.
+func batteryLabel(b BatteryStatus, i int) string {
+	if b.Name != "" && len(b.Name) <= metricLabelWidth {
+		return b.Name
+	}
+	return fmt.Sprintf("BAT%d", i)
+}
+
+func renderBatteryLines(b BatteryStatus, label string, thermal ThermalStatus, withSystem bool) []string {
+	var lines []string
+	statusLower := strings.ToLower(b.Status)
+	percentText := sprintNum("%5.1f%%", b.Percent)
+	if b.Percent < 20 && statusLower != "charging" && statusLower != "charged" {
+		percentText = dangerStyle.Render(percentText)
+	}
+	lines = append(lines, fmt.Sprintf("%-*s %s  %s", metricLabelWidth, label, batteryProgressBar(b.Percent), percentText))
+
+	// Add capacity line if available.
+	if b.Capacity > 0 {
+		capacityText := fmt.Sprintf("%5d%%", b.Capacity)
+		if b.Capacity < 70 {
+			capacityText = dangerStyle.Render(capacityText)
+		} else if b.Capacity < 85 {
+			capacityText = warnStyle.Render(capacityText)
 		}
+		lines = append(lines, fmt.Sprintf("Health %s  %s", batteryProgressBar(float64(b.Capacity)), capacityText))
+	}
 
-		healthParts := []string{}
+	if withSystem && thermal.AdapterPower > 0 && isPoweredByAC(statusLower) {
+		lines = append(lines, fmt.Sprintf("%-6s %s  %6s",
+			"Input",
+			okStyle.Render(plainProgressBar(100)),
+			fmt.Sprintf("%.0fW max", thermal.AdapterPower),
+		))
+	}
 
-		// Battery health assessment label.
-		if b.CycleCount > 0 || b.Capacity > 0 {
-			label, severity := batteryHealthLabel(b.CycleCount, b.Capacity)
-			switch severity {
-			case "danger":
-				healthParts = append(healthParts, dangerStyle.Render(label))
-			case "warn":
-				healthParts = append(healthParts, warnStyle.Render(label))
-			default:
-				healthParts = append(healthParts, okStyle.Render(label))
-			}
-		} else if b.Health != "" {
-			healthParts = append(healthParts, b.Health)
-		}
+	statusStyle := subtleStyle
+	if isPoweredByAC(statusLower) {
+		statusStyle = okStyle
+	} else if b.Percent < 20 {
+		statusStyle = dangerStyle
+	}
+	statusText := formatBatteryStatus(b.Status)
+	if b.TimeLeft != "" && b.TimeLeft != "0:00" {
+		statusText += " · " + b.TimeLeft
+	}
+	if power := formatBatteryPower(b.PowerWatts); power != "" {
+		statusText += " · " + power
+	}
 
-		if b.CycleCount > 0 {
-			cycleText := fmt.Sprintf("%d cycles", b.CycleCount)
-			if b.CycleCount > batteryCycleDanger {
-				cycleText = dangerStyle.Render(cycleText)
-			} else if b.CycleCount > batteryCycleWarn {
-				cycleText = warnStyle.Render(cycleText)
-			}
-			healthParts = append(healthParts, cycleText)
-		}
+	healthParts := []string{}
 
-		if thermal.BatteryTemp > 0 {
-			tempText := colorizeTemp(thermal.BatteryTemp) + "°C"
-			healthParts = append(healthParts, tempText)
+	// Battery health assessment label.
+	if b.CycleCount > 0 || b.Capacity > 0 {
+		label, severity := batteryHealthLabel(b.CycleCount, b.Capacity)
+		switch severity {
+		case "danger":
+			healthParts = append(healthParts, dangerStyle.Render(label))
+		case "warn":
+			healthParts = append(healthParts, warnStyle.Render(label))
+		default:
+			healthParts = append(healthParts, okStyle.Render(label))
 		}
+	} else if b.Health != "" {
+		healthParts = append(healthParts, b.Health)
+	}
 
-		if thermal.FanSpeed > 0 {
-			healthParts = append(healthParts, fmt.Sprintf("%d RPM", thermal.FanSpeed))
+	if b.CycleCount > 0 {
+		cycleText := fmt.Sprintf("%d cycles", b.CycleCount)
+		if b.CycleCount > batteryCycleDanger {
+			cycleText = dangerStyle.Render(cycleText)
+		} else if b.CycleCount > batteryCycleWarn {
+			cycleText = warnStyle.Render(cycleText)
 		}
+		healthParts = append(healthParts, cycleText)
+	}
 
-		summaryParts := append([]string{statusStyle.Render(statusText)}, healthParts...)
-		lines = append(lines, strings.Join(summaryParts, " · "))
+	if withSystem && thermal.BatteryTemp > 0 {
+		tempText := colorizeTemp(thermal.BatteryTemp) + "°C"
+		healthParts = append(healthParts, tempText)
 	}
 
-	return cardData{icon: iconBattery, title: "Power", lines: lines}
+	if withSystem && thermal.FanSpeed > 0 {
+		healthParts = append(healthParts, formatFanSpeeds(thermal))
+	}
+
+	summaryParts := append([]string{statusStyle.Render(statusText)}, healthParts...)
+	lines = append(lines, strings.Join(summaryParts, " · "))
+	return lines
+}
+
+// formatFanSpeeds renders "1200 RPM", or "1200/2400 RPM" with several fans.
+func formatFanSpeeds(thermal ThermalStatus) string {
+	if len(thermal.FanSpeeds) < 2 {
+		return fmt.Sprintf("%d RPM", thermal.FanSpeed)
+	}
+	speeds := make([]string, len(thermal.FanSpeeds))
+	for i, rpm := range thermal.FanSpeeds {
+		speeds[i] = strconv.Itoa(rpm)
+	}
+	return strings.Join(speeds, "/") + " RPM"
 }
 
 func isPoweredByAC(statusLower string) bool {
@@ -765,6 +1587,18 @@ func isPoweredByAC(statusLower string) bool {
 		strings.Contains(statusLower, "ac attached")
 }
 
+// formatBatteryPower renders "−12.3 W" while discharging and "+8.0 W" while
+// charging; empty when the battery is idle or the reading is unavailable.
+func formatBatteryPower(watts float64) string {
+	switch {
+	case watts <= -0.05:
+		return sprintNum("−%.1f W", -watts)
+	case watts >= 0.05:
+		return sprintNum("+%.1f W", watts)
+	}
+	return ""
+}
+
 func formatBatteryStatus(status string) string {
 	status = strings.TrimSpace(status)
 	if status == "" {
@@ -789,15 +1623,17 @@ func renderCard(data cardData, width int, height int) string {
 		width = colWidth
 	}
 
-	titleText := data.icon + " " + data.title
-	lineLen := max(width-lipgloss.Width(titleText)-2, 0)
+	var lines []string
+	if data.title != "" {
+		titleText := data.icon + " " + data.title
+		lineLen := max(width-lipgloss.Width(titleText)-2, 0)
 
-	header := titleStyle.Render(titleText)
-	if lineLen > 0 {
-		header += "  " + lineStyle.Render(strings.Repeat("╌", lineLen))
+		header := titleStyle.Render(titleText)
+		if lineLen > 0 {
+			header += "  " + lineStyle.Render(strings.Repeat("╌", lineLen))
+		}
+		lines = wrapToWidth(header, width)
 	}
-
-	lines := wrapToWidth(header, width)
 	for _, line := range data.lines {
 		lines = append(lines, wrapToWidth(line, width)...)
 	}
@@ -885,13 +1721,30 @@ func colorizeBattery(percent float64, s string) string {
 }
 
 func colorizeTemp(t float64) string {
+	return tempStyle(t).Render(sprintNum("%.1f", t))
+}
+
+// tempStyle picks the CPU temperature color band.
+func tempStyle(t float64) lipgloss.Style {
 	switch {
 	case t >= thermalHighThreshold:
-		return dangerStyle.Render(fmt.Sprintf("%.1f", t))
+		return dangerStyle
 	case t >= thermalNormalThreshold:
-		return warnStyle.Render(fmt.Sprintf("%.1f", t))
+		return warnStyle
 	default:
-		return okStyle.Render(fmt.Sprintf("%.1f", t))
+		return okStyle
+	}
+}
+
+// colorizeGPUTemp uses the GPU thresholds, which sit above the CPU ones.
+func colorizeGPUTemp(t float64) string {
+	switch {
+	case t >= gpuTempHighThreshold:
+		return dangerStyle.Render(sprintNum("%.1f", t))
+	case t >= gpuTempNormalThreshold:
+		return warnStyle.Render(sprintNum("%.1f", t))
+	default:
+		return okStyle.Render(sprintNum("%.1f", t))
 	}
 }
 
@@ -900,12 +1753,12 @@ func formatRate(mb float64) string {
 		return "0 MB/s"
 	}
 	if mb < 1 {
-		return fmt.Sprintf("%.2f MB/s", mb)
+		return sprintNum("%.2f MB/s", mb)
 	}
 	if mb < 10 {
-		return fmt.Sprintf("%.1f MB/s", mb)
+		return sprintNum("%.1f MB/s", mb)
 	}
-	return fmt.Sprintf("%.0f MB/s", mb)
+	return sprintNum("%.0f MB/s", mb)
 }
 
 func formatRateCompact(mb float64) string {
@@ -913,21 +1766,58 @@ func formatRateCompact(mb float64) string {
 		return "0"
 	}
 	if mb < 10 {
-		return fmt.Sprintf("%.1f", mb)
+		return sprintNum("%.1f", mb)
 	}
-	return fmt.Sprintf("%.0f", mb)
+	return sprintNum("%.0f", mb)
 }
 
-func humanBytes(v uint64) string {
+// siByteUnits switches byte sizes to decimal (1000-based) units labelled
+// GB, matching vendor disk sizes (--units si). The default is binary units
+// labelled GiB. It is atomic because the "p" key can switch it while
+// collector goroutines format sizes.
+var siByteUnits atomic.Bool
+
+// formatBytes is the unlocalized size label, e.g. "16.0 GiB".
+func formatBytes(v uint64) string {
+	if siByteUnits.Load() {
+		return units.BytesSI(int64(min(v, math.MaxInt64)))
+	}
 	return units.BytesBin(v)
 }
 
+func humanBytes(v uint64) string {
+	return localizeDecimal(formatBytes(v))
+}
+
 func humanBytesShort(v uint64) string {
-	return units.BytesBinShort(v)
+	if siByteUnits.Load() {
+		return localizeDecimal(units.BytesSIShort(v))
+	}
+	return localizeDecimal(units.BytesBinShort(v))
 }
 
 func humanBytesCompact(v uint64) string {
-	return units.BytesBinCompact(v)
+	if siByteUnits.Load() {
+		return localizeDecimal(units.BytesSICompact(v))
+	}
+	return localizeDecimal(units.BytesBinCompact(v))
+}
+
+// humanCount shortens large counts with decimal K/M/G suffixes (e.g. "1.2K").
+// Counts below 1000 are returned unchanged.
+func humanCount(n uint64) string {
+	if n < 1000 {
+		return strconv.FormatUint(n, 10)
+	}
+	value := float64(n)
+	for _, suffix := range []string{"K", "M", "G"} {
+		value /= 1000
+		if value < 999.95 || suffix == "G" {
+			text := strconv.FormatFloat(value, 'f', 1, 64)
+			return localizeDecimal(strings.TrimSuffix(text, ".0") + suffix)
+		}
+	}
+	return strconv.FormatUint(n, 10)
 }
 
 func shorten(s string, maxLen int) string {
@@ -944,6 +1834,18 @@ func remainingLineWidth(width int, prefix string) int {
 	return max(width-lipgloss.Width(prefix)-1, 0)
 }
 
+// renderSingleColumn stacks cards at the full card width, one per row.
+func renderSingleColumn(cards []cardData, cardWidth int) string {
+	var rendered []string
+	for i, c := range cards {
+		if i > 0 {
+			rendered = append(rendered, "")
+		}
+		rendered = append(rendered, renderCard(c, cardWidth, 0))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rendered...)
+}
+
 func renderTwoColumns(cards []cardData, width int) string {
 	if len(cards) == 0 {
 		return ""
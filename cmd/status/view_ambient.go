@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	ambientRefreshInterval = 5 * time.Second
+	ambientAnimInterval    = 600 * time.Millisecond
+)
+
+// bigDigits is a 5-row block font for the ambient health score.
+var bigDigits = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" █ ", "██ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+}
+
+func renderBigNumber(n int) string {
+	var rows [5][]string
+	for _, r := range strconv.Itoa(n) {
+		glyph, ok := bigDigits[r]
+		if !ok {
+			continue
+		}
+		for i := range rows {
+			rows[i] = append(rows[i], glyph[i])
+		}
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.Join(row, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderAmbient is the glanceable always-on screen: a large, dimmed health
+// score, the diagnosis, a one-line summary and the mole, centred in the
+// terminal. Dense card details are left out on purpose.
+func renderAmbient(m MetricsSnapshot, alertBar string, animFrame, width, height int, catHidden bool) string {
+	if width <= 0 {
+		width = 80
+	}
+	score := getScoreStyle(m.HealthScore).Faint(true).Render(renderBigNumber(m.HealthScore))
+	diagnosis := subtleStyle.Render("Health · " + statusDiagnosisLine(m))
+
+	summary := []string{sprintNum("CPU %.0f%%", m.CPU.Usage), sprintNum("Mem %.0f%%", m.Memory.UsedPercent)}
+	if disk, ok := rootDisk(m.Disks); ok {
+		summary = append(summary, sprintNum("Disk %.0f%%", disk.UsedPercent))
+	}
+	if len(m.Batteries) > 0 {
+		summary = append(summary, sprintNum("Batt %.0f%%", m.Batteries[0].Percent))
+	}
+	if !m.CollectedAt.IsZero() {
+		summary = append(summary, m.CollectedAt.Format("15:04"))
+	}
+
+	parts := []string{score, "", diagnosis, subtleStyle.Render(strings.Join(summary, " · "))}
+	if alertBar != "" {
+		parts = append(parts, "", alertBar)
+	}
+	block := lipgloss.JoinVertical(lipgloss.Center, parts...)
+	if !catHidden {
+		mole := subtleStyle.Faint(true).Render(getMoleFrame(animFrame, width))
+		block = lipgloss.JoinVertical(lipgloss.Center, block, "", mole)
+	}
+	if height <= 0 {
+		return lipgloss.PlaceHorizontal(width, lipgloss.Center, block)
+	}
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, block)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	densityNormal  = "normal"
+	densityCompact = "compact"
+)
+
+// compactSystemCards and compactIOCards split the compact lines into a system
+// card and an I/O card so both fit side by side.
+var (
+	compactSystemCards = []string{"cpu", "memory", "gpu", "power", "bluetooth"}
+	compactIOCards     = []string{"disk", "network", "processes"}
+)
+
+// buildCompactCards is the --density compact layout: no card headers and one
+// "Label bar value" line per metric. names is the card selection buildCards
+// already filtered (--cards, profile, hidden cards); each contributes its
+// lines in that order.
+func buildCompactCards(m MetricsSnapshot, width int, opts viewOptions, names []string) []cardData {
+	var system, io []string
+	for _, name := range names {
+		lines := compactCardLines(name, m, width, opts)
+		switch {
+		case slices.Contains(compactSystemCards, name):
+			system = append(system, lines...)
+		case slices.Contains(compactIOCards, name):
+			io = append(io, lines...)
+		}
+	}
+	if slices.Contains(names, "processes") {
+		// The normal layout shows these under the process card.
+		for _, line := range []string{formatSystemLimitsLine(m.SystemLimits), formatLogErrorsLine(m.LogErrors), formatUpdatesLine(m.PendingUpdates)} {
+			if line != "" {
+				system = append(system, line)
+			}
+		}
+	}
+
+	var cards []cardData
+	for _, lines := range [][]string{system, io} {
+		if len(lines) > 0 {
+			cards = append(cards, cardData{lines: lines})
+		}
+	}
+	return cards
+}
+
+// compactCardLines is the compact form of one named card.
+func compactCardLines(name string, m MetricsSnapshot, width int, opts viewOptions) []string {
+	var lines []string
+	switch name {
+	case "cpu":
+		if opts.sinceBoot {
+			lines = append(lines, compactMetricLine("CPU", m.CPU.BootUsage, sprintNum("%.1f%% avg", m.CPU.BootUsage)))
+		} else {
+			value := sprintNum("%.1f%%", m.CPU.Usage)
+			if m.Thermal.CPUTemp > 0 {
+				value += fmt.Sprintf(" @ %s°C", colorizeTemp(m.Thermal.CPUTemp))
+			}
+			lines = append(lines, compactMetricLine("CPU", m.CPU.Usage, value))
+		}
+		lines = append(lines, sprintNum("%-*s %.2f / %.2f / %.2f", metricLabelWidth, "Load", m.CPU.Load1, m.CPU.Load5, m.CPU.Load15))
+	case "memory":
+		lines = append(lines, compactMetricLine("Mem", m.Memory.UsedPercent,
+			sprintNum("%.1f%%", m.Memory.UsedPercent)+" "+subtleStyle.Render(humanBytesCompact(m.Memory.Used)+"/"+humanBytesCompact(m.Memory.Total))))
+		if m.Memory.SwapTotal > 0 {
+			swapPercent := float64(m.Memory.SwapUsed) / float64(m.Memory.SwapTotal) * 100
+			lines = append(lines, compactMetricLine("Swap", swapPercent, sprintNum("%.1f%%", swapPercent)))
+		}
+	case "gpu":
+		for _, g := range m.GPU {
+			if gpuHasLiveUsage(g) {
+				lines = append(lines, compactMetricLine("GPU", g.Usage, sprintNum("%.1f%%", g.Usage)))
+				break
+			}
+		}
+	case "power":
+		if len(m.Batteries) > 0 {
+			b := m.Batteries[0]
+			lines = append(lines, fmt.Sprintf("%-*s %s %s %s", metricLabelWidth, "Batt", chargeBar(b.Percent),
+				sprintNum("%.0f%%", b.Percent), subtleStyle.Render(formatBatteryStatus(b.Status))))
+		}
+	case "disk":
+		for _, d := range m.Disks {
+			label := "Disk"
+			if d.External {
+				label = "Ext"
+			}
+			line := compactMetricLine(label, d.UsedPercent, sprintNum("%.0f%%", d.UsedPercent))
+			if mountWidth := remainingLineWidth(width, line); mountWidth > 1 {
+				line += " " + subtleStyle.Render(shorten(d.Mount, mountWidth))
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, formatDiskIOLine(m.DiskIO))
+	case "network":
+		lines = append(lines, compactNetworkLine(m.Network, opts.sinceBoot))
+	case "processes":
+		if len(m.TopProcesses) > 0 {
+			p := m.TopProcesses[0]
+			percent := p.CPU
+			if opts.sortByMem {
+				percent = p.Memory
+			}
+			line := compactMetricLine("Top", percent, sprintNum("%.1f%%", percent))
+			if nameWidth := remainingLineWidth(width, line); nameWidth > 0 {
+				line += " " + shorten(p.Name, nameWidth)
+			}
+			lines = highlightProcessRow(cardData{lines: []string{line}}, m.TopProcesses[:1], opts.selectedPID).lines
+		}
+	}
+	return lines
+}
+
+// compactMetricLine renders "CPU    ▮▮▯▯▯ 42.0%" using the shared mini bar.
+func compactMetricLine(label string, percent float64, value string) string {
+	return fmt.Sprintf("%-*s %s %s", metricLabelWidth, label, miniBar(percent), value)
+}
+
+func compactNetworkLine(netStats []NetworkStatus, sinceBoot bool) string {
+	var rx, tx float64
+	var bootRx, bootTx uint64
+	for _, n := range netStats {
+		rx += n.RxRateMBs
+		tx += n.TxRateMBs
+		bootRx += n.RxBootBytes
+		bootTx += n.TxBootBytes
+	}
+	if sinceBoot {
+		return fmt.Sprintf("%-*s ↓ %s  ↑ %s", metricLabelWidth, "Net", humanBytesShort(bootRx), humanBytesShort(bootTx))
+	}
+	return fmt.Sprintf("%-*s ↓ %s  ↑ %s", metricLabelWidth, "Net", formatRate(rx), formatRate(tx))
+}
+
+func validDensity(density string) bool {
+	switch strings.ToLower(density) {
+	case densityNormal, densityCompact:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildCardsCompactDensity(t *testing.T) {
+	m := MetricsSnapshot{
+		CPU:          CPUStatus{Usage: 42, Load1: 1.5, Load5: 1, Load15: 0.5, LogicalCPU: 8},
+		Memory:       MemoryStatus{UsedPercent: 60, Used: 6 << 30, Total: 10 << 30},
+		Disks:        []DiskStatus{{Mount: "/", UsedPercent: 70}},
+		Network:      []NetworkStatus{{Name: "en0", RxRateMBs: 2.5, TxRateMBs: 0.5}},
+		Batteries:    []BatteryStatus{{Percent: 90, Status: "charging"}},
+		TopProcesses: []ProcessInfo{{PID: 1, Name: "kernel_task", CPU: 12}},
+	}
+
+	cards := buildCards(m, 40, viewOptions{compact: true})
+	if len(cards) != 2 {
+		t.Fatalf("compact density built %d cards, want 2", len(cards))
+	}
+	rendered := stripANSI(renderCard(cards[0], 40, 0) + "\n" + renderCard(cards[1], 40, 0))
+	for _, want := range []string{"CPU    ▮▮▯▯▯ 42.0%", "Mem    ▮▮▮▯▯ 60.0%", "Batt   ▮▮▮▮▯ 90%", "Disk   ▮▮▮▯▯ 70% /", "Net    ↓ 2.5 MB/s", "Top    ▯▯▯▯▯ 12.0% kernel_task"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("compact cards missing %q:\n%s", want, rendered)
+		}
+	}
+	if strings.Contains(rendered, "╌") {
+		t.Errorf("compact cards should not render headers:\n%s", rendered)
+	}
+
+	if normal := buildCards(m, 40, viewOptions{}); len(normal) < 6 || normal[0].title != "CPU" {
+		t.Fatalf("normal density should keep the per-area cards, got %d", len(normal))
+	}
+}
+
+func TestBuildCardsCompactDensityHonoursCardSelection(t *testing.T) {
+	m := MetricsSnapshot{
+		CPU:          CPUStatus{Usage: 42},
+		Memory:       MemoryStatus{UsedPercent: 60},
+		Disks:        []DiskStatus{{Mount: "/", UsedPercent: 70}},
+		Network:      []NetworkStatus{{Name: "en0", RxRateMBs: 2.5}},
+		Batteries:    []BatteryStatus{{Percent: 90}},
+		TopProcesses: []ProcessInfo{{PID: 1, Name: "kernel_task", CPU: 12}},
+		Virtualized:  true,
+	}
+
+	cards := buildCards(m, 40, viewOptions{compact: true, cards: []string{"network", "cpu", "power"}})
+	var rendered []string
+	for _, c := range cards {
+		rendered = append(rendered, stripANSI(strings.Join(c.lines, "\n")))
+	}
+	joined := strings.Join(rendered, "\n")
+	if len(cards) != 2 || !strings.HasPrefix(rendered[0], "CPU") || !strings.HasPrefix(rendered[1], "Net") {
+		t.Fatalf("compact --cards network,cpu = %q, want a CPU card and a network card", rendered)
+	}
+	for _, hidden := range []string{"Mem", "Disk", "Top", "Batt"} {
+		if strings.Contains(joined, hidden) {
+			t.Errorf("compact cards show unselected or hidden %q:\n%s", hidden, joined)
+		}
+	}
+
+	selected := buildCards(m, 40, viewOptions{compact: true, selectedPID: 1})
+	if joined := stripANSI(strings.Join(selected[len(selected)-1].lines, "\n")); !strings.Contains(joined, "▸ #1") {
+		t.Fatalf("compact Top line should mark the selected process:\n%s", joined)
+	}
+}
+
+func TestValidDensity(t *testing.T) {
+	for _, d := range []string{"normal", "compact", "Compact"} {
+		if !validDensity(d) {
+			t.Errorf("validDensity(%q) = false", d)
+		}
+	}
+	if validDensity("dense") {
+		t.Error("validDensity(dense) = true")
+	}
+}
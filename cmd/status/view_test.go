@@ -173,8 +173,8 @@ func TestHumanBytesShort(t *testing.T) {
 }
 
 func TestHumanBytes(t *testing.T) {
-	if got := humanBytes((1 << 20) + 1); got != "1.0 MB" {
-		t.Errorf("humanBytes(1MB+1) = %q, want %q", got, "1.0 MB")
+	if got := humanBytes((1 << 20) + 1); got != "1.0 MiB" {
+		t.Errorf("humanBytes(1MB+1) = %q, want %q", got, "1.0 MiB")
 	}
 }
 
@@ -385,7 +385,7 @@ func TestIsNoiseInterface(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isNoiseInterface(tt.input)
+			got := isNoiseInterface(tt.input, defaultNoiseInterfacePrefixes)
 			if got != tt.want {
 				t.Errorf("isNoiseInterface(%q) = %v, want %v", tt.input, got, tt.want)
 			}
@@ -555,6 +555,30 @@ func TestBatteryProgressBar(t *testing.T) {
 	}
 }
 
+func TestRenderBatteryCardListsEveryBattery(t *testing.T) {
+	raw := `Now drawing from 'Battery Power'
+ -InternalBattery-0 (id=1234)	62%; discharging; 3:10 remaining present: true
+ -InternalBattery-1 (id=5678)	15%; discharging; 0:40 remaining present: true`
+	batts := parsePMSet(raw, "", 0, 0)
+	if len(batts) != 2 || batts[0].Name != "InternalBattery-0" || batts[1].Name != "InternalBattery-1" {
+		t.Fatalf("parsePMSet() = %+v", batts)
+	}
+	batts[1].Name = "BAT1"
+
+	card := renderBatteryCard(batts, ThermalStatus{BatteryTemp: 31, FanSpeed: 1200})
+	var plain []string
+	for _, line := range card.lines {
+		plain = append(plain, stripANSI(line))
+	}
+	got := strings.Join(plain, "\n")
+	if !strings.HasPrefix(plain[0], "BAT0 ") || !strings.Contains(got, "\nBAT1 ") {
+		t.Fatalf("expected a labeled row per battery, got:\n%s", got)
+	}
+	if strings.Count(got, "RPM") != 1 || !strings.HasSuffix(got, "31.0°C · 1200 RPM") {
+		t.Fatalf("expected fan and temperature once at the bottom, got:\n%s", got)
+	}
+}
+
 func TestRenderBatteryCardShowsAdapterInputOnly(t *testing.T) {
 	card := renderBatteryCard([]BatteryStatus{{
 		Percent:    80,
@@ -771,7 +795,7 @@ func TestRenderDiskCardAddsMetaLineForSingleDisk(t *testing.T) {
 		Used:        263 << 30,
 		Total:       926 << 30,
 		Fstype:      "apfs",
-	}}, DiskIOStatus{ReadRate: 0, WriteRate: 0.1}, 0, false)
+	}}, DiskIOStatus{ReadRate: 0, WriteRate: 0.1}, 0, false, false)
 
 	if len(card.lines) != 3 {
 		t.Fatalf("renderDiskCard() single disk expected 3 lines, got %d", len(card.lines))
@@ -787,7 +811,7 @@ func TestRenderDiskCardDoesNotAddMetaLineForMultipleDisks(t *testing.T) {
 	card := renderDiskCard([]DiskStatus{
 		{UsedPercent: 28.4, Used: 263 << 30, Total: 926 << 30, Fstype: "apfs"},
 		{UsedPercent: 50.0, Used: 500 << 30, Total: 1000 << 30, Fstype: "apfs"},
-	}, DiskIOStatus{}, 0, false)
+	}, DiskIOStatus{}, 0, false, false)
 
 	if len(card.lines) != 3 {
 		t.Fatalf("renderDiskCard() multiple disks expected 3 lines, got %d", len(card.lines))
@@ -808,12 +832,12 @@ func TestRenderDiskCardOmitsTrashFromMainView(t *testing.T) {
 		approx    bool
 	}{
 		{"no trash", 0, false},
-		{"1.5 GB exact", 1536 << 20, false},
-		{"approx 12 GB", 12 << 30, true},
+		{"1.5 GiB exact", 1536 << 20, false},
+		{"approx 12 GiB", 12 << 30, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			card := renderDiskCard([]DiskStatus{disk}, DiskIOStatus{}, tt.trashSize, tt.approx)
+			card := renderDiskCard([]DiskStatus{disk}, DiskIOStatus{}, tt.trashSize, tt.approx, false)
 			ioLine := ""
 			trashLine := ""
 			for _, line := range card.lines {
@@ -840,7 +864,7 @@ func TestRenderDiskCardUsesGraphicIOLine(t *testing.T) {
 		{UsedPercent: 50.0, Used: 500 << 30, Total: 1000 << 30},
 		{UsedPercent: 95.0, Used: 18 << 30, Total: 18<<30 + 472<<20, External: true},
 		{UsedPercent: 95.0, Used: 16 << 30, Total: 16<<30 + 444<<20, External: true},
-	}, DiskIOStatus{ReadRate: 0, WriteRate: 24.6}, 101<<20, false)
+	}, DiskIOStatus{ReadRate: 0, WriteRate: 24.6}, 101<<20, false, false)
 
 	if len(card.lines) != 4 {
 		t.Fatalf("renderDiskCard() expected 4 lines without trash, got %d", len(card.lines))
@@ -1044,7 +1068,7 @@ func TestRenderProcessCardAddsInlineMemoryWithoutExtraRows(t *testing.T) {
 	card := renderProcessCard([]ProcessInfo{
 		{Name: "Chrome", CPU: 12, Memory: 22, MemoryBytes: 2 * 1024 * 1024 * 1024},
 		{Name: "Xcode", CPU: 95, Memory: 8, MemoryBytes: 512 * 1024 * 1024},
-	}, colWidth)
+	}, colWidth, 0, false)
 
 	if len(card.lines) != 2 {
 		t.Fatalf("renderProcessCard() lines = %d, want 2", len(card.lines))
@@ -1059,7 +1083,7 @@ func TestRenderProcessCardAddsInlineMemoryWithoutExtraRows(t *testing.T) {
 }
 
 func TestRenderProcessCardShowsCollectingWhenEmpty(t *testing.T) {
-	card := renderProcessCard(nil, colWidth)
+	card := renderProcessCard(nil, colWidth, 0, false)
 
 	if len(card.lines) != 1 {
 		t.Fatalf("renderProcessCard() empty lines = %d, want 1", len(card.lines))
@@ -1075,7 +1099,7 @@ func TestRenderProcessCardAlignsMetricColumns(t *testing.T) {
 		{Name: "duetexpertd", CPU: 97.3, MemoryBytes: 75 << 20},
 		{Name: "WindowServer", CPU: 46.8, MemoryBytes: 352 << 20},
 		{Name: "Xcode", CPU: 24.3, MemoryBytes: 1018 << 20},
-	}, wideCardWidth)
+	}, wideCardWidth, 0, false)
 
 	if len(card.lines) != 3 {
 		t.Fatalf("renderProcessCard() lines = %d, want 3", len(card.lines))
@@ -1124,7 +1148,7 @@ func TestRenderProcessCardAlignsMetricColumns(t *testing.T) {
 func TestRenderProcessCardFallsBackToMemoryPercent(t *testing.T) {
 	card := renderProcessCard([]ProcessInfo{
 		{Name: "Chrome", CPU: 12, Memory: 22},
-	}, colWidth)
+	}, colWidth, 0, false)
 
 	plain := stripANSI(strings.Join(card.lines, "\n"))
 	if !strings.Contains(plain, "M22%") {
@@ -1132,6 +1156,60 @@ func TestRenderProcessCardFallsBackToMemoryPercent(t *testing.T) {
 	}
 }
 
+func TestRenderProcessCardHonorsTopProcs(t *testing.T) {
+	var procs []ProcessInfo
+	for i := range 8 {
+		procs = append(procs, ProcessInfo{PID: i + 1, Name: "node", CPU: float64(80 - i)})
+	}
+	if card := renderProcessCard(procs, colWidth, 0, false); len(card.lines) != defaultShownProcesses {
+		t.Fatalf("default rows = %d, want %d", len(card.lines), defaultShownProcesses)
+	}
+	card := renderProcessCard(procs, colWidth, 6, false)
+	if len(card.lines) != 6 || !strings.HasPrefix(stripANSI(card.lines[5]), "#6") {
+		t.Fatalf("--top-procs 6 rows = %q", card.lines)
+	}
+
+	// The taller process card stretches its row partner to match.
+	short := cardData{icon: iconCPU, title: "CPU", lines: []string{"Total 10%"}}
+	rows := strings.Split(renderTwoColumns([]cardData{short, card}, 100), "\n")
+	for _, row := range rows[1:] {
+		if lipgloss.Width(row) != lipgloss.Width(rows[0]) {
+			t.Fatalf("paired cards misaligned:\n%s", strings.Join(rows, "\n"))
+		}
+	}
+}
+
+func TestRenderProcessCardSortedByMemory(t *testing.T) {
+	card := renderProcessCard([]ProcessInfo{
+		{Name: "Chrome", CPU: 3, Memory: 22.5, MemoryBytes: 3 << 30},
+	}, colWidth, 0, true)
+
+	if card.title != "Processes by memory" {
+		t.Fatalf("title = %q", card.title)
+	}
+	plain := stripANSI(card.lines[0])
+	if !strings.Contains(plain, "22.5%") || strings.Contains(plain, " 3.0%") || !strings.Contains(plain, "Chrome") {
+		t.Fatalf("memory-sorted row = %q", plain)
+	}
+}
+
+func TestProcessNameWithPID(t *testing.T) {
+	p := ProcessInfo{PID: 4821, Name: "node"}
+	if got := stripANSI(processNameWithPID(p, 20)); got != "node (4821)" {
+		t.Fatalf("roomy = %q", got)
+	}
+	long := ProcessInfo{PID: 4821, Name: "WindowServer"}
+	if got := stripANSI(processNameWithPID(long, 11)); got != "Win… (4821)" {
+		t.Fatalf("tight = %q", got)
+	}
+	if got := stripANSI(processNameWithPID(long, 8)); got != "WindowS…" {
+		t.Fatalf("too tight for the PID = %q", got)
+	}
+	if got := processNameWithPID(ProcessInfo{Name: "kernel"}, 20); got != "kernel" {
+		t.Fatalf("unknown PID = %q", got)
+	}
+}
+
 func TestRenderHeaderUsesFastMetricSpecFallbacks(t *testing.T) {
 	const ram = uint64(16 * 1024 * 1024 * 1024)
 	const diskSize = uint64(512 * 1024 * 1024 * 1024)
@@ -1233,8 +1311,8 @@ func TestRenderHeaderDropsLowPriorityInfoToStaySingleLine(t *testing.T) {
 		Hardware: HardwareInfo{
 			Model:       "MacBook Pro",
 			CPUModel:    "Apple M2 Pro",
-			TotalRAM:    "32.0 GB",
-			DiskSize:    "460.4 GB",
+			TotalRAM:    "32.0 GiB",
+			DiskSize:    "460.4 GiB",
 			RefreshRate: "60Hz",
 			OSVersion:   "macOS 26.3",
 		},
@@ -1281,7 +1359,7 @@ func TestRenderCPUCardKeepsOnlyTwoHotCores(t *testing.T) {
 		Load5:      2.27,
 		Load15:     2.16,
 		LogicalCPU: 4,
-	}, ThermalStatus{})
+	}, ThermalStatus{}, false)
 
 	plain := stripANSI(strings.Join(card.lines, "\n"))
 	if len(card.lines) != 4 {
@@ -1324,7 +1402,7 @@ func TestRenderMemoryCardHidesSwapSizeOnNarrowWidth(t *testing.T) {
 		UsedPercent: 50.0,
 		SwapUsed:    482,
 		SwapTotal:   1000,
-	}, 38)
+	}, 38, false)
 
 	if len(card.lines) < 3 {
 		t.Fatalf("renderMemoryCard() expected at least 3 lines, got %d", len(card.lines))
@@ -1336,6 +1414,28 @@ func TestRenderMemoryCardHidesSwapSizeOnNarrowWidth(t *testing.T) {
 	}
 }
 
+func TestRenderMemoryAndDiskCardsAbsolute(t *testing.T) {
+	mem := renderMemoryCard(MemoryStatus{
+		Used:        48 << 30,
+		Total:       64 << 30,
+		Available:   16 << 30,
+		UsedPercent: 75.0,
+	}, 38, true)
+	used := stripANSI(mem.lines[0])
+	if !strings.HasPrefix(used, "Used ") || !strings.Contains(used, "48.0 GiB / 64.0 GiB") || !strings.HasSuffix(used, " 75%") {
+		t.Fatalf("absolute memory Used line = %q", used)
+	}
+	if free := stripANSI(mem.lines[1]); !strings.Contains(free, "16.0 GiB") || !strings.HasSuffix(free, " 25%") {
+		t.Fatalf("absolute memory Free line = %q", free)
+	}
+
+	disk := renderDiskCard([]DiskStatus{{UsedPercent: 50.0, Used: 500 << 30, Total: 1000 << 30}}, DiskIOStatus{}, 0, false, true)
+	line := stripANSI(disk.lines[0])
+	if !strings.Contains(line, " / ") || !strings.HasSuffix(line, " 50%") {
+		t.Fatalf("absolute disk line = %q", line)
+	}
+}
+
 func TestRenderMemoryCardShowsSwapSizeOnWideWidth(t *testing.T) {
 	card := renderMemoryCard(MemoryStatus{
 		Used:        8 << 30,
@@ -1344,7 +1444,7 @@ func TestRenderMemoryCardShowsSwapSizeOnWideWidth(t *testing.T) {
 		UsedPercent: 50.0,
 		SwapUsed:    482,
 		SwapTotal:   1000,
-	}, 60)
+	}, 60, false)
 
 	if len(card.lines) < 3 {
 		t.Fatalf("renderMemoryCard() expected at least 3 lines, got %d", len(card.lines))
@@ -1362,13 +1462,13 @@ func TestRenderMemoryCardUsesCollectedAvailableMemory(t *testing.T) {
 		Total:       16 << 30,
 		Available:   9 << 30,
 		UsedPercent: 75.0,
-	}, 60)
+	}, 60, false)
 
 	plain := stripANSI(strings.Join(card.lines, "\n"))
 	if !strings.Contains(plain, "Free") || !strings.Contains(plain, "56.2%") {
 		t.Fatalf("renderMemoryCard() should derive free percent from Available, got %q", plain)
 	}
-	if !strings.Contains(plain, "Avail  9.0 GB") {
+	if !strings.Contains(plain, "Avail  9.0 GiB") {
 		t.Fatalf("renderMemoryCard() should render collected Available memory, got %q", plain)
 	}
 }
@@ -1380,13 +1480,13 @@ func TestRenderMemoryCardCombinesCacheAndAvailable(t *testing.T) {
 		Available:   9 << 30,
 		Cached:      2 << 30,
 		UsedPercent: 75.0,
-	}, 60)
+	}, 60, false)
 
 	plain := stripANSI(strings.Join(card.lines, "\n"))
 	if len(card.lines) != 4 {
 		t.Fatalf("renderMemoryCard() lines = %d, want 4", len(card.lines))
 	}
-	if !strings.Contains(plain, "Cache  2.0 GB · Avail 9.0 GB") {
+	if !strings.Contains(plain, "Cache  2.0 GiB · Avail 9.0 GiB") {
 		t.Fatalf("renderMemoryCard() should combine cache and available memory, got %q", plain)
 	}
 }
@@ -1458,3 +1558,332 @@ func stripANSI(s string) string {
 	}
 	return result.String()
 }
+
+func TestRenderGPUCardListsDisplays(t *testing.T) {
+	card := renderGPUCard([]GPUStatus{{
+		Name:  "Apple M3 Pro",
+		Usage: -1,
+		Displays: []DisplayInfo{
+			{Name: "Color LCD", Resolution: "3024x1964", RefreshRate: 120, BuiltIn: true},
+			{Name: "DELL U2720Q", Resolution: "3840x2160", RefreshRate: 60},
+		},
+	}}, 60)
+
+	if len(card.lines) != 2 {
+		t.Fatalf("expected one line per display, got %#v", card.lines)
+	}
+	if !strings.Contains(card.lines[0], "Built") || !strings.Contains(card.lines[0], "3024x1964 @ 120Hz") {
+		t.Fatalf("unexpected built-in line %q", card.lines[0])
+	}
+	if !strings.Contains(card.lines[1], "Ext") || !strings.Contains(card.lines[1], "DELL U2720Q") {
+		t.Fatalf("unexpected external line %q", card.lines[1])
+	}
+}
+
+func TestRenderGPUCardShowsTemperatureAndPower(t *testing.T) {
+	card := renderGPUCard([]GPUStatus{{Name: "RTX 4090", Usage: 80, Temperature: 78, PowerWatts: 312.6}}, 60)
+	plain := stripANSI(strings.Join(card.lines, "\n"))
+	if !strings.Contains(plain, "Temp   78.0°C · 313 W") {
+		t.Fatalf("GPU card missing sensor line:\n%s", plain)
+	}
+	if line := formatGPUSensorLine(GPUStatus{Usage: 10}); line != "" {
+		t.Fatalf("GPU without sensors rendered %q", line)
+	}
+}
+
+func TestRenderGPUCardSummarizesSeveralGPUs(t *testing.T) {
+	card := renderGPUCard([]GPUStatus{
+		{Name: "NVIDIA GeForce RTX 4090", Usage: 80, MemoryUsed: 12288, MemoryTotal: 24576},
+		{Name: "NVIDIA GeForce RTX 3090", Usage: 20, MemoryUsed: 4096, MemoryTotal: 24576},
+	}, 60)
+	var plain []string
+	for _, line := range card.lines {
+		plain = append(plain, stripANSI(line))
+	}
+	if len(plain) != 5 || plain[0] != "All    ▮▮▯▯▯ 50.0% · VRAM 16.0G/48.0G" {
+		t.Fatalf("GPU card lines = %q", plain)
+	}
+	if !strings.HasPrefix(plain[1], "GPU1   NVIDIA GeForce RTX 4090") || !strings.HasPrefix(plain[3], "GPU2   NVIDIA GeForce RTX 3090") {
+		t.Fatalf("per-GPU name lines = %q, %q", plain[1], plain[3])
+	}
+}
+
+func TestHasGPUCardData(t *testing.T) {
+	if hasGPUCardData([]GPUStatus{{Name: "Apple M3", Usage: -1}}) {
+		t.Fatal("static GPU name alone should not add a card")
+	}
+	if hasGPUCardData([]GPUStatus{{Name: "No GPU metrics available", Note: "Install nvidia-smi"}}) {
+		t.Fatal("placeholder GPU should not add a card")
+	}
+	if !hasGPUCardData([]GPUStatus{{Name: "RTX 4090", Usage: 12}}) {
+		t.Fatal("live GPU usage should add a card")
+	}
+	if !hasGPUCardData([]GPUStatus{{Name: "Apple M3", Usage: 0, CoreCount: 10, Note: "Metal 3"}}) {
+		t.Fatal("Apple GPU with powermetrics usage should add a card")
+	}
+	if !hasGPUCardData([]GPUStatus{{Usage: -1, Displays: []DisplayInfo{{Resolution: "1920x1080"}}}}) {
+		t.Fatal("attached displays should add a card")
+	}
+}
+
+func TestRenderCPUCardSinceBootShowsAverage(t *testing.T) {
+	card := renderCPUCard(CPUStatus{Usage: 90, BootUsage: 12.5, PerCore: []float64{90, 90}, LogicalCPU: 2}, ThermalStatus{}, true)
+
+	if card.title != "CPU since boot" {
+		t.Fatalf("title = %q, want since-boot title", card.title)
+	}
+	plain := stripANSI(strings.Join(card.lines, "\n"))
+	if !strings.Contains(plain, "12.5%") || strings.Contains(plain, "90.0%") {
+		t.Fatalf("since-boot card should show boot average only, got %q", plain)
+	}
+}
+
+func TestRenderNetworkCardSinceBootShowsCumulativeTotals(t *testing.T) {
+	stats := []NetworkStatus{
+		{Name: "en0", RxRateMBs: 1, RxBootBytes: 3 << 30, TxBootBytes: 512 << 20},
+		{Name: "en1", RxBootBytes: 1 << 30},
+	}
+	card := renderNetworkCard(stats, NetworkHistory{}, ProxyStatus{}, nil, 40, true)
+
+	plain := stripANSI(strings.Join(card.lines, "\n"))
+	if !strings.Contains(plain, "Down   4.0 GiB") || !strings.Contains(plain, "Up     512.0 MiB") {
+		t.Fatalf("since-boot network card = %q", plain)
+	}
+	if strings.Contains(plain, "MB/s") {
+		t.Fatalf("since-boot network card should not show live rates, got %q", plain)
+	}
+}
+
+func TestHumanCount(t *testing.T) {
+	tests := []struct {
+		input uint64
+		want  string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1K"},
+		{1234, "1.2K"},
+		{45678, "45.7K"},
+		{999_949, "999.9K"},
+		{999_999, "1M"},
+		{2_500_000, "2.5M"},
+		{3_000_000_000, "3G"},
+	}
+	for _, tt := range tests {
+		if got := humanCount(tt.input); got != tt.want {
+			t.Errorf("humanCount(%d) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRenderHeaderShowsCompactProcessCount(t *testing.T) {
+	m := MetricsSnapshot{HealthScore: 90, Procs: 1234}
+
+	header, _ := renderHeader(m, "", 0, 160, true)
+	if plain := stripANSI(header); !strings.Contains(plain, "1.2K procs") {
+		t.Fatalf("renderHeader() should show compact process count, got %q", plain)
+	}
+}
+
+func TestRenderGPUCardShowsEGPUBadge(t *testing.T) {
+	gpus := []GPUStatus{
+		{Name: "Intel Iris Plus Graphics", Usage: -1},
+		{Name: "AMD Radeon RX 6800 XT", Usage: -1, External: true},
+	}
+	if !hasGPUCardData(gpus) {
+		t.Fatal("an attached eGPU should add the GPU card")
+	}
+	card := renderGPUCard(gpus, 60)
+	if len(card.lines) != 1 || !strings.HasPrefix(card.lines[0], "eGPU") || !strings.Contains(card.lines[0], "RX 6800 XT") {
+		t.Fatalf("expected eGPU badge line, got %#v", card.lines)
+	}
+}
+
+func TestRenderCPUCardShowsPerCoreTemps(t *testing.T) {
+	card := renderCPUCard(CPUStatus{
+		Usage:       40,
+		PerCore:     []float64{10, 80},
+		PerCoreTemp: []float64{45, 71.5},
+		LogicalCPU:  2,
+	}, ThermalStatus{CPUTemp: 65}, false)
+
+	plain := stripANSI(strings.Join(card.lines, "\n"))
+	if !strings.Contains(plain, "Core2") || !strings.Contains(plain, "80.0% @ 71.5°C") {
+		t.Fatalf("expected hottest core with its temperature, got %q", plain)
+	}
+}
+
+func TestRenderCPUCardSplitsAppleSiliconClusters(t *testing.T) {
+	card := renderCPUCard(CPUStatus{
+		Usage:      30,
+		PerCore:    []float64{60, 40, 10, 90, 20, 10},
+		PCoreCount: 4,
+		ECoreCount: 2,
+		LogicalCPU: 6,
+	}, ThermalStatus{}, false)
+
+	plain := stripANSI(strings.Join(card.lines, "\n"))
+	for _, want := range []string{"Perf", " 32.5%", "Eff", " 50.0%", "P2", " 90.0%", "4P+2E"} {
+		if !strings.Contains(plain, want) {
+			t.Fatalf("renderCPUCard() missing %q in %q", want, plain)
+		}
+	}
+	if strings.Contains(plain, "Core") {
+		t.Fatalf("split clusters should replace CoreN rows, got %q", plain)
+	}
+
+	// A core count that does not match the topology falls back to CoreN rows.
+	card = renderCPUCard(CPUStatus{PerCore: []float64{10, 20}, PCoreCount: 4, ECoreCount: 2}, ThermalStatus{}, false)
+	if !strings.Contains(stripANSI(strings.Join(card.lines, "\n")), "Core2") {
+		t.Fatalf("mismatched topology should keep CoreN rows")
+	}
+}
+
+func TestGetMoleFrameAnchored(t *testing.T) {
+	moleAnchored = true
+	t.Cleanup(func() { moleAnchored = false })
+
+	first := getMoleFrame(0, 80)
+	for frame := 1; frame < 40; frame++ {
+		got := getMoleFrame(frame, 80)
+		want := strings.Repeat(" ", (80-15)/2) + moleBody[frame%len(moleBody)][0]
+		if firstLine, _, _ := strings.Cut(got, "\n"); firstLine != want {
+			t.Fatalf("frame %d first line = %q, want fixed position %q", frame, firstLine, want)
+		}
+		if frame%len(moleBody) == 0 && got != first {
+			t.Fatalf("frame %d should repeat the first leg frame", frame)
+		}
+	}
+}
+
+func TestGetMoleFrameFrozenAndInitSkipsAnimation(t *testing.T) {
+	moleFrozen = true
+	t.Cleanup(func() { moleFrozen = false })
+
+	first := getMoleFrame(0, 80)
+	if want := strings.Repeat(" ", (80-15)/2) + moleBody[0][0]; !strings.HasPrefix(first, want+"\n") {
+		t.Fatalf("frozen mole first line = %q, want centered first frame", first)
+	}
+	for frame := 1; frame < 10; frame++ {
+		if getMoleFrame(frame, 80) != first {
+			t.Fatalf("frame %d moved or changed legs", frame)
+		}
+	}
+	// Only the collection tick runs; a batch would also start animTick.
+	if _, ok := (model{}).Init()().(tickMsg); !ok {
+		t.Fatal("Init started the animation tick while frozen")
+	}
+}
+
+func TestRenderNetworkCardShowsPrimaryInterfaceIP(t *testing.T) {
+	stats := []NetworkStatus{
+		{Name: "en0", IP: "192.168.1.20"},
+		{Name: "en7", IP: "10.0.0.5", Primary: true},
+	}
+	card := renderNetworkCard(stats, NetworkHistory{}, ProxyStatus{}, nil, 60, false)
+	last := stripANSI(card.lines[len(card.lines)-1])
+	if last != "10.0.0.5" {
+		t.Fatalf("IP line = %q, want the primary interface's IP", last)
+	}
+
+	stats[1].Primary = false
+	card = renderNetworkCard(stats, NetworkHistory{}, ProxyStatus{}, nil, 60, false)
+	if last := stripANSI(card.lines[len(card.lines)-1]); last != "192.168.1.20" {
+		t.Fatalf("IP line without a primary = %q, want en0's IP", last)
+	}
+}
+
+func TestTrendLinesFollowTheFirstLineOfCPUAndMemoryCards(t *testing.T) {
+	opts := viewOptions{cpuTrend: []float64{0, 50, 100}, memTrend: []float64{40, 40}}
+	cards := buildCards(MetricsSnapshot{CPU: CPUStatus{Usage: 100}, Memory: MemoryStatus{UsedPercent: 40}}, 40, opts)
+	for _, c := range cards[:2] {
+		if len(c.lines) < 2 || !strings.HasPrefix(stripANSI(c.lines[1]), "Trend  ") {
+			t.Fatalf("%s card lines = %q, want Trend second", c.title, c.lines)
+		}
+	}
+	if got := stripANSI(cards[0].lines[1]); got != "Trend  ▁▄█" {
+		t.Fatalf("CPU trend = %q, want a fixed 0-100 scale", got)
+	}
+	// Narrow cards keep only the newest samples.
+	if got := stripANSI(percentSparkline([]float64{100, 100, 0, 0}, 0, 2)); got != "▁▁" {
+		t.Fatalf("percentSparkline width 2 = %q", got)
+	}
+	single := buildCards(MetricsSnapshot{}, 40, viewOptions{cpuTrend: []float64{10}})
+	if strings.Contains(stripANSI(strings.Join(single[0].lines, "\n")), "Trend") {
+		t.Fatal("a single sample should not draw a trend")
+	}
+}
+
+func TestRenderDiskCardUsesOneLinePerMountWhenMany(t *testing.T) {
+	var disks []DiskStatus
+	for _, mount := range []string{"/", "/home", "/srv/data", "/var/lib/docker/volumes"} {
+		disks = append(disks, DiskStatus{Mount: mount, Total: 512 << 30, Used: 256 << 30, UsedPercent: 50})
+	}
+	disks = append(disks, DiskStatus{Mount: "/Volumes/Time Machine", Total: 2 << 40, Used: 1 << 40, UsedPercent: 50, External: true})
+	card := renderDiskCard(disks, DiskIOStatus{}, 0, false, false)
+	if len(card.lines) != len(disks)+1 {
+		t.Fatalf("expected one line per disk plus I/O, got %d: %q", len(card.lines), card.lines)
+	}
+	for _, line := range card.lines {
+		if w := lipgloss.Width(line); w > colWidth {
+			t.Fatalf("line %q is %d wide, want <= %d", stripANSI(line), w, colWidth)
+		}
+	}
+	if got := stripANSI(card.lines[0]); got != "INTR1  /             50% · 256G free" {
+		t.Fatalf("unexpected per-mount disk line %q", got)
+	}
+	if got := stripANSI(card.lines[3]); !strings.HasPrefix(got, "INTR4  /var/lib/do… ") {
+		t.Fatalf("expected long mounts to be shortened, got %q", got)
+	}
+	if got := stripANSI(card.lines[4]); !strings.HasPrefix(got, "EXTR   Time Machine ") {
+		t.Fatalf("expected the external volume by name, got %q", got)
+	}
+}
+
+func TestBuildCardsAddsBluetoothCardForConnectedDevices(t *testing.T) {
+	none := buildCards(MetricsSnapshot{Bluetooth: []BluetoothDevice{{Name: "No devices"}}}, 40, viewOptions{})
+	for _, c := range none {
+		if c.title == "Bluetooth" {
+			t.Fatal("Bluetooth card shown without a connected device")
+		}
+	}
+
+	m := MetricsSnapshot{Bluetooth: []BluetoothDevice{
+		{Name: "AirPods Pro", Connected: true, Battery: "9%", BatteryPercent: 9},
+		{Name: "Magic Keyboard with Touch ID and Numeric Keypad", Connected: true},
+		{Name: "Old Speaker"},
+	}}
+	var card cardData
+	for _, c := range buildCards(m, 200, viewOptions{}) {
+		if c.title == "Bluetooth" {
+			card = c
+		}
+	}
+	if len(card.lines) != 2 {
+		t.Fatalf("expected a line per connected device, got %q", card.lines)
+	}
+	if got := stripANSI(card.lines[0]); got != "AirPods Pro                 ▯▯▯▯▯   9%" {
+		t.Fatalf("battery line = %q", got)
+	}
+	if !strings.Contains(card.lines[0], dangerStyle.Render("  9%")) {
+		t.Fatalf("expected a low battery to render in the danger style: %q", card.lines[0])
+	}
+	if got := stripANSI(card.lines[1]); lipgloss.Width(got) > colWidth || !strings.HasSuffix(got, "… Connected") {
+		t.Fatalf("device without battery = %q", got)
+	}
+}
+
+func TestHumanBytesSIUnits(t *testing.T) {
+	siByteUnits.Store(true)
+	t.Cleanup(func() { siByteUnits.Store(false) })
+
+	if got := humanBytes(500_107_862_016); got != "500.1 GB" {
+		t.Errorf("humanBytes(SI) = %q, want %q", got, "500.1 GB")
+	}
+	if got := humanBytesShort(2_000_398_934_016); got != "2T" {
+		t.Errorf("humanBytesShort(SI) = %q, want %q", got, "2T")
+	}
+	if got := humanBytesCompact(1_500_000_000); got != "1.5G" {
+		t.Errorf("humanBytesCompact(SI) = %q, want %q", got, "1.5G")
+	}
+}
@@ -10,8 +10,8 @@ import (
 // runWatchMode streams metrics continuously as newline-delimited JSON (one full
 // MetricsSnapshot per line) using a single warm Collector, so rate metrics
 // (network, disk IO) stay accurate across ticks.
-func runWatchMode(interval time.Duration) {
-	runWatchStdout(interval)
+func runWatchMode(interval time.Duration, notifier *alertNotifier, hook *healthHook, statsd *statsdExporter, bell *criticalBell, csv *csvLog) {
+	runWatchStdout(interval, notifier, hook, statsd, bell, csv)
 }
 
 // watchState mirrors the TUI's collection cadence (cmd/status/main.go): a full
@@ -45,6 +45,7 @@ func (s *watchState) collect(c *Collector) (MetricsSnapshot, error) {
 	}
 
 	if err == nil {
+		crashes.Record(snap)
 		recordCollectionFreshness(mode, snap.CollectedAt, &s.lastFullAt, &s.lastProcessAt)
 		s.ready = true
 	}
@@ -56,8 +57,8 @@ func (s *watchState) collect(c *Collector) (MetricsSnapshot, error) {
 // successful fast snapshot is followed by an immediate full snapshot, and later
 // ticks wait for the configured interval after each collection finishes. Exits
 // cleanly when stdout closes (parent process gone).
-func runWatchStdout(interval time.Duration) {
-	collector := NewCollector(processWatchOptionsFromFlags())
+func runWatchStdout(interval time.Duration, notifier *alertNotifier, hook *healthHook, statsd *statsdExporter, bell *criticalBell, csv *csvLog) {
+	collector := newCollectorFromFlags()
 	enc := json.NewEncoder(os.Stdout)
 	var st watchState
 
@@ -71,7 +72,15 @@ func runWatchStdout(interval time.Duration) {
 				continue
 			}
 		}
+		if err == nil {
+			notifier.Observe(snap)
+			hook.Observe(snap)
+			statsd.Observe(snap)
+			bell.Observe(snap)
+			csv.Observe(snap)
+		}
 		if err := enc.Encode(snap); err != nil {
+			persistRateState(collector)
 			return // stdout closed; parent died, nothing left to feed.
 		}
 		if wasReady {
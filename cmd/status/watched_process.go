@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// watchedNameWidth caps the pattern shown on the Watch line.
+const watchedNameWidth = 16
+
+// WatchedProcess totals every process whose name matches --watch-process,
+// so it stays visible even when it is not in the top list.
+type WatchedProcess struct {
+	Pattern     string  `json:"pattern"`
+	Count       int     `json:"count"`  // Matching processes; 0 when not running
+	CPU         float64 `json:"cpu"`    // Summed percent of one core
+	Memory      float64 `json:"memory"` // Summed percent of physical memory
+	MemoryBytes uint64  `json:"memory_bytes,omitempty"`
+}
+
+// watchProcesses sums the processes whose name contains pattern, ignoring
+// case. It returns nil when no pattern is set.
+func watchProcesses(pattern string, procs []ProcessInfo) *WatchedProcess {
+	if pattern == "" {
+		return nil
+	}
+	needle := strings.ToLower(pattern)
+	w := &WatchedProcess{Pattern: pattern}
+	for _, p := range procs {
+		if !strings.Contains(strings.ToLower(p.Name), needle) {
+			continue
+		}
+		w.Count++
+		w.CPU += p.CPU
+		w.Memory += p.Memory
+		w.MemoryBytes += p.MemoryBytes
+	}
+	return w
+}
+
+// withWatchedProcess appends the --watch-process line to the process card,
+// e.g. "Watch  postgres ×4  12.3% · 1.2 GiB".
+func withWatchedProcess(card cardData, w *WatchedProcess) cardData {
+	if w == nil {
+		return card
+	}
+	name := shorten(w.Pattern, watchedNameWidth)
+	if w.Count == 0 {
+		card.lines = append(card.lines, fmt.Sprintf("%-*s %s %s", metricLabelWidth, "Watch", name, subtleStyle.Render("(not running)")))
+		return card
+	}
+	if w.Count > 1 {
+		name += fmt.Sprintf(" ×%d", w.Count)
+	}
+	usage := colorizePercent(w.CPU, sprintNum("%.1f%%", w.CPU))
+	if w.MemoryBytes > 0 {
+		usage += " · " + humanBytes(w.MemoryBytes)
+	} else {
+		usage += sprintNum(" · %.1f%% mem", w.Memory)
+	}
+	card.lines = append(card.lines, fmt.Sprintf("%-*s %s  %s", metricLabelWidth, "Watch", name, usage))
+	return card
+}
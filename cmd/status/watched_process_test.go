@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestWatchProcessesSumsCaseInsensitiveMatches(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "postgres", CPU: 4.5, Memory: 1.0, MemoryBytes: 100 << 20},
+		{PID: 2, Name: "Postgres: walwriter", CPU: 0.5, Memory: 0.5, MemoryBytes: 20 << 20},
+		{PID: 3, Name: "zsh", CPU: 30},
+	}
+	w := watchProcesses("PostGres", procs)
+	if w.Count != 2 || w.CPU != 5 || w.Memory != 1.5 || w.MemoryBytes != 120<<20 {
+		t.Fatalf("watchProcesses() = %+v", w)
+	}
+	if w := watchProcesses("redis", procs); w == nil || w.Count != 0 {
+		t.Fatalf("no match should report zero processes, got %+v", w)
+	}
+	if watchProcesses("", procs) != nil {
+		t.Fatal("no pattern should not watch anything")
+	}
+}
+
+func TestWithWatchedProcessLine(t *testing.T) {
+	card := withWatchedProcess(cardData{}, &WatchedProcess{Pattern: "postgres", Count: 2, CPU: 5, MemoryBytes: 120 << 20})
+	if got, want := stripANSI(card.lines[0]), "Watch  postgres ×2  5.0% · "+humanBytes(120<<20); got != want {
+		t.Fatalf("watch line = %q, want %q", got, want)
+	}
+	card = withWatchedProcess(cardData{}, &WatchedProcess{Pattern: "redis"})
+	if got := stripANSI(card.lines[0]); got != "Watch  redis (not running)" {
+		t.Fatalf("not running line = %q", got)
+	}
+	if card := withWatchedProcess(cardData{}, nil); len(card.lines) != 0 {
+		t.Fatal("nil watch should add no line")
+	}
+}
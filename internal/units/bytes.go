@@ -4,8 +4,9 @@
 // The two callers intentionally use different conventions: analyze formats
 // disk-related figures with SI (1000-based) units to match Finder/diskutil,
 // while status reports memory and live counters with binary (1024-based)
-// units to match macOS Activity Monitor and gopsutil. Both styles live here so
-// that any future tweak (precision, rounding, label set) stays in one place.
+// units, labelled GiB/MiB, to match gopsutil (status --units si switches to
+// the SI helpers). Both styles live here so that any future tweak (precision,
+// rounding, label set) stays in one place.
 package units
 
 import (
@@ -33,18 +34,18 @@ func BytesSI(size int64) string {
 }
 
 // BytesBin formats an unsigned byte count using binary (1024-based) units with
-// a trailing space and unit label (e.g. "1.0 GB"). Boundary uses '>' so values
-// at exactly 1<<n stay in the smaller unit (e.g. 1024 -> "1024 B").
+// a trailing space and IEC unit label (e.g. "1.0 GiB"). Boundary uses '>' so
+// values at exactly 1<<n stay in the smaller unit (e.g. 1024 -> "1024 B").
 func BytesBin(v uint64) string {
 	switch {
 	case v > 1<<40:
-		return fmt.Sprintf("%.1f TB", float64(v)/(1<<40))
+		return fmt.Sprintf("%.1f TiB", float64(v)/(1<<40))
 	case v > 1<<30:
-		return fmt.Sprintf("%.1f GB", float64(v)/(1<<30))
+		return fmt.Sprintf("%.1f GiB", float64(v)/(1<<30))
 	case v > 1<<20:
-		return fmt.Sprintf("%.1f MB", float64(v)/(1<<20))
+		return fmt.Sprintf("%.1f MiB", float64(v)/(1<<20))
 	case v > 1<<10:
-		return fmt.Sprintf("%.1f KB", float64(v)/(1<<10))
+		return fmt.Sprintf("%.1f KiB", float64(v)/(1<<10))
 	default:
 		return strconv.FormatUint(v, 10) + " B"
 	}
@@ -85,3 +86,36 @@ func BytesBinCompact(v uint64) string {
 		return strconv.FormatUint(v, 10)
 	}
 }
+
+// BytesSIShort is BytesBinShort with decimal (1000-based) division, so "2T"
+// matches the size printed on the drive.
+func BytesSIShort(v uint64) string {
+	switch {
+	case v >= 1e12:
+		return fmt.Sprintf("%.0fT", float64(v)/1e12)
+	case v >= 1e9:
+		return fmt.Sprintf("%.0fG", float64(v)/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.0fM", float64(v)/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.0fK", float64(v)/1e3)
+	default:
+		return strconv.FormatUint(v, 10)
+	}
+}
+
+// BytesSICompact is BytesBinCompact with decimal (1000-based) division.
+func BytesSICompact(v uint64) string {
+	switch {
+	case v >= 1e12:
+		return fmt.Sprintf("%.1fT", float64(v)/1e12)
+	case v >= 1e9:
+		return fmt.Sprintf("%.1fG", float64(v)/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", float64(v)/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.1fK", float64(v)/1e3)
+	default:
+		return strconv.FormatUint(v, 10)
+	}
+}
@@ -40,20 +40,20 @@ func TestBytesBin(t *testing.T) {
 		{"1023 bytes", 1023, "1023 B"},
 
 		{"exactly 1KB", 1 << 10, "1024 B"},
-		{"just over 1KB", (1 << 10) + 1, "1.0 KB"},
-		{"1.5KB", 1536, "1.5 KB"},
+		{"just over 1KB", (1 << 10) + 1, "1.0 KiB"},
+		{"1.5KB", 1536, "1.5 KiB"},
 
-		{"exactly 1MB", 1 << 20, "1024.0 KB"},
-		{"just over 1MB", (1 << 20) + 1, "1.0 MB"},
-		{"500MB", 500 << 20, "500.0 MB"},
+		{"exactly 1MB", 1 << 20, "1024.0 KiB"},
+		{"just over 1MB", (1 << 20) + 1, "1.0 MiB"},
+		{"500MB", 500 << 20, "500.0 MiB"},
 
-		{"exactly 1GB", 1 << 30, "1024.0 MB"},
-		{"just over 1GB", (1 << 30) + 1, "1.0 GB"},
-		{"100GB", 100 << 30, "100.0 GB"},
+		{"exactly 1GB", 1 << 30, "1024.0 MiB"},
+		{"just over 1GB", (1 << 30) + 1, "1.0 GiB"},
+		{"100GB", 100 << 30, "100.0 GiB"},
 
-		{"exactly 1TB", 1 << 40, "1024.0 GB"},
-		{"just over 1TB", (1 << 40) + 1, "1.0 TB"},
-		{"2TB", 2 << 40, "2.0 TB"},
+		{"exactly 1TB", 1 << 40, "1024.0 GiB"},
+		{"just over 1TB", (1 << 40) + 1, "1.0 TiB"},
+		{"2TB", 2 << 40, "2.0 TiB"},
 	}
 
 	for _, tt := range tests {
@@ -136,3 +136,27 @@ func TestBytesBinCompact(t *testing.T) {
 		})
 	}
 }
+
+func TestBytesSIShortAndCompact(t *testing.T) {
+	tests := []struct {
+		input   uint64
+		short   string
+		compact string
+	}{
+		{999, "999", "999"},
+		{1000, "1K", "1.0K"},
+		{1500000, "2M", "1.5M"},
+		{500_000_000_000, "500G", "500.0G"},
+		{2_000_000_000_000, "2T", "2.0T"},
+		{2 << 40, "2T", "2.2T"},
+	}
+
+	for _, tt := range tests {
+		if got := BytesSIShort(tt.input); got != tt.short {
+			t.Errorf("BytesSIShort(%d) = %q, want %q", tt.input, got, tt.short)
+		}
+		if got := BytesSICompact(tt.input); got != tt.compact {
+			t.Errorf("BytesSICompact(%d) = %q, want %q", tt.input, got, tt.compact)
+		}
+	}
+}